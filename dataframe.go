@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"math/rand"
 	"reflect"
+	"time"
 
 	"github.com/ptiger10/tablewriter"
 )
@@ -100,7 +101,8 @@ func ConcatSeries(series ...*Series) (*DataFrame, error) {
 }
 
 // Cast coerces the underlying container values (column or label level) to
-// []float64, []string, []time.Time (aka timezone-aware DateTime), []civil.Date, or []civil.Time
+// []float64, []string, []time.Time (aka timezone-aware DateTime), []civil.Date, []civil.Time, []int64, []bool, []Category (dictionary-encoded strings),
+// []*big.Rat (Decimal), or []time.Duration (Duration)
 // and caches the []byte values of the container (if inexpensive).
 // Use cast to improve performance when calling multiple operations on values.
 func (df *DataFrame) Cast(containerAsType map[string]DType) {
@@ -381,6 +383,31 @@ func (df *DataFrameMutator) Subset(index []int) error {
 	return nil
 }
 
+// Mask returns only the rows in which mask is true, in their original order.
+// mask is a boolean Series (e.g., the result of Series.Gt on a column), which is aligned with df using the labels in df as an anchor (as in Lookup).
+// Rows in which mask is null, or which do not align with any row in mask, are treated as false.
+// Returns a new DataFrame.
+func (df *DataFrame) Mask(mask *Series) *DataFrame {
+	df = df.Copy()
+	err := df.InPlace().Mask(mask)
+	if err != nil {
+		return dataFrameWithError(err)
+	}
+	return df
+}
+
+// Mask returns only the rows in which mask is true, in their original order.
+// mask is a boolean Series (e.g., the result of Series.Gt on a column), which is aligned with df using the labels in df as an anchor (as in Lookup).
+// Rows in which mask is null, or which do not align with any row in mask, are treated as false.
+// Modifies the underlying DataFrame in place.
+func (df *DataFrameMutator) Mask(mask *Series) error {
+	index, err := maskIndex(df.dataframe.labels, mask)
+	if err != nil {
+		return fmt.Errorf("masking rows: %v", err)
+	}
+	return df.Subset(index)
+}
+
 // SwapLabels swaps the label levels with names i and j.
 // Returns a new DataFrame.
 func (df *DataFrame) SwapLabels(i, j string) *DataFrame {
@@ -1874,6 +1901,72 @@ func (df *DataFrameMutator) Resample(how map[string]Resampler) error {
 	return nil
 }
 
+// Localize coerces values to time.Time and replaces the location of each value with a new location, keeping the same wall clock.
+// how is a map of container names (either column or label names) to the new location for that container.
+// A wall clock time that occurs twice in the new location (when clocks fall back) resolves to the earlier time,
+// and a wall clock time that does not occur (when clocks spring forward) is shifted forward by the length of the gap.
+// See Series.Localize for more details.
+//
+// Returns a new DataFrame.
+func (df *DataFrame) Localize(how map[string]*time.Location) *DataFrame {
+	df = df.Copy()
+	err := df.InPlace().Localize(how)
+	if err != nil {
+		return dataFrameWithError(err)
+	}
+	return df
+}
+
+// Localize coerces values to time.Time and replaces the location of each value with a new location, keeping the same wall clock.
+// how is a map of container names (either column or label names) to the new location for that container.
+// A wall clock time that occurs twice in the new location (when clocks fall back) resolves to the earlier time,
+// and a wall clock time that does not occur (when clocks spring forward) is shifted forward by the length of the gap.
+// See Series.Localize for more details.
+//
+// Modifies the underlying DataFrame in place.
+func (df *DataFrameMutator) Localize(how map[string]*time.Location) error {
+	mergedLabelsAndCols := append(df.dataframe.labels, df.dataframe.values...)
+	for name, loc := range how {
+		index, err := indexOfContainer(name, mergedLabelsAndCols)
+		if err != nil {
+			return fmt.Errorf("localize: %v", err)
+		}
+		mergedLabelsAndCols[index].localize(loc)
+	}
+	return nil
+}
+
+// ConvertTimeZone coerces values to time.Time and converts each value to the same instant in a new location.
+// how is a map of container names (either column or label names) to the new location for that container.
+// See Series.ConvertTimeZone for more details.
+//
+// Returns a new DataFrame.
+func (df *DataFrame) ConvertTimeZone(how map[string]*time.Location) *DataFrame {
+	df = df.Copy()
+	err := df.InPlace().ConvertTimeZone(how)
+	if err != nil {
+		return dataFrameWithError(err)
+	}
+	return df
+}
+
+// ConvertTimeZone coerces values to time.Time and converts each value to the same instant in a new location.
+// how is a map of container names (either column or label names) to the new location for that container.
+// See Series.ConvertTimeZone for more details.
+//
+// Modifies the underlying DataFrame in place.
+func (df *DataFrameMutator) ConvertTimeZone(how map[string]*time.Location) error {
+	mergedLabelsAndCols := append(df.dataframe.labels, df.dataframe.values...)
+	for name, loc := range how {
+		index, err := indexOfContainer(name, mergedLabelsAndCols)
+		if err != nil {
+			return fmt.Errorf("convert time zone: %v", err)
+		}
+		mergedLabelsAndCols[index].convertTimeZone(loc)
+	}
+	return nil
+}
+
 // -- ITERATORS
 
 // Iterator returns an iterator which may be used to access the values in each row as map[string]Element.
@@ -2041,3 +2134,211 @@ func (df *DataFrame) Reduce(name string, lambda ReduceFn) (*Series, error) {
 		labels: retLabels,
 	}, nil
 }
+
+// mathByColumn applies fn to every column (as a Series with labels) and returns a new DataFrame.
+// labels must not be shared with df.
+func (df *DataFrame) mathByColumn(name string, labels []*valueContainer, fn func(col *Series, k int) *Series) *DataFrame {
+	retVals := make([]*valueContainer, df.NumColumns())
+	for k := range df.values {
+		// copy the column, because coercion sets nulls in place
+		ret := fn(&Series{values: df.values[k].copy(), labels: labels}, k)
+		if ret.err != nil {
+			return dataFrameWithError(fmt.Errorf("%s: column %v: %v", name, df.values[k].name, ret.err))
+		}
+		retVals[k] = ret.values
+		retVals[k].name = df.values[k].name
+	}
+	colLevelNames := make([]string, len(df.colLevelNames))
+	copy(colLevelNames, df.colLevelNames)
+	return &DataFrame{
+		values:        retVals,
+		labels:        labels,
+		name:          df.name,
+		colLevelNames: colLevelNames,
+	}
+}
+
+// broadcastByLabels aligns other with the DataFrame labels once and applies fn to every column and the aligned Series.
+func (df *DataFrame) broadcastByLabels(name string, other *Series, fn func(col *Series, other *Series) *Series) *DataFrame {
+	if other == nil {
+		return dataFrameWithError(fmt.Errorf("%s: other cannot be nil", name))
+	}
+	aligned, err := alignToLabels(copyContainers(df.labels), other)
+	if err != nil {
+		return dataFrameWithError(fmt.Errorf("%s: %v", name, err))
+	}
+	// the aligned labels are a copy of the DataFrame labels
+	return df.mathByColumn(name, aligned.labels, func(col *Series, k int) *Series {
+		return fn(col, aligned)
+	})
+}
+
+// broadcastByColumn matches the labels of other with the column names
+// and applies fn to every column and a Series that repeats the matched value in every row.
+// If a column does not match any row in other, the repeated value is null.
+func (df *DataFrame) broadcastByColumn(name string, other *Series, fn func(col *Series, other *Series) *Series) *DataFrame {
+	if other == nil {
+		return dataFrameWithError(fmt.Errorf("%s: other cannot be nil", name))
+	}
+	stringifiedLabels := make([][]string, len(other.labels))
+	for j := range other.labels {
+		stringifiedLabels[j] = other.labels[j].string().slice
+	}
+	positions := make(map[string]int, other.Len())
+	for i := 0; i < other.Len(); i++ {
+		levels := make([]string, len(other.labels))
+		for j := range other.labels {
+			levels[j] = stringifiedLabels[j][i]
+		}
+		key := joinLevelsIntoName(levels)
+		if _, ok := positions[key]; !ok {
+			positions[key] = i
+		}
+	}
+	var matched bool
+	for k := range df.values {
+		if _, ok := positions[df.values[k].name]; ok {
+			matched = true
+			break
+		}
+	}
+	if !matched {
+		return dataFrameWithError(fmt.Errorf("%s: no matching keys between column names and labels in other", name))
+	}
+	return df.mathByColumn(name, copyContainers(df.labels), func(col *Series, k int) *Series {
+		index := make([]int, df.Len())
+		i, ok := positions[df.values[k].name]
+		for row := range index {
+			index[row] = i
+		}
+		// unmatched columns repeat the first value as a placeholder and set every row to null
+		repeated := other.values.copy()
+		repeated.subsetRows(index)
+		if !ok {
+			for row := range repeated.isNull {
+				repeated.isNull[row] = true
+			}
+		}
+		return fn(col, &Series{values: repeated, labels: col.labels})
+	})
+}
+
+// AddScalar coerces the values in each column to float64 and adds c to each value (see Series.AddScalar).
+// Returns a new DataFrame.
+func (df *DataFrame) AddScalar(c float64) *DataFrame {
+	return df.mathByColumn("add", copyContainers(df.labels), func(col *Series, k int) *Series { return col.AddScalar(c) })
+}
+
+// SubtractScalar coerces the values in each column to float64 and subtracts c from each value (see Series.SubtractScalar).
+// Returns a new DataFrame.
+func (df *DataFrame) SubtractScalar(c float64) *DataFrame {
+	return df.mathByColumn("subtract", copyContainers(df.labels), func(col *Series, k int) *Series { return col.SubtractScalar(c) })
+}
+
+// MultiplyScalar coerces the values in each column to float64 and multiplies each value by c (see Series.MultiplyScalar).
+// Returns a new DataFrame.
+func (df *DataFrame) MultiplyScalar(c float64) *DataFrame {
+	return df.mathByColumn("multiply", copyContainers(df.labels), func(col *Series, k int) *Series { return col.MultiplyScalar(c) })
+}
+
+// DivideScalar coerces the values in each column to float64 and divides each value by c (see Series.DivideScalar).
+// Returns a new DataFrame.
+func (df *DataFrame) DivideScalar(c float64) *DataFrame {
+	return df.mathByColumn("divide", copyContainers(df.labels), func(col *Series, k int) *Series { return col.DivideScalar(c) })
+}
+
+// Abs coerces the values in each column to float64 and returns the absolute value of each value (see Series.Abs).
+// Returns a new DataFrame.
+func (df *DataFrame) Abs() *DataFrame {
+	return df.mathByColumn("abs", copyContainers(df.labels), func(col *Series, k int) *Series { return col.Abs() })
+}
+
+// Log coerces the values in each column to float64 and returns the natural logarithm of each value (see Series.Log).
+// Returns a new DataFrame.
+func (df *DataFrame) Log() *DataFrame {
+	return df.mathByColumn("log", copyContainers(df.labels), func(col *Series, k int) *Series { return col.Log() })
+}
+
+// Exp coerces the values in each column to float64 and returns e raised to the power of each value (see Series.Exp).
+// Returns a new DataFrame.
+func (df *DataFrame) Exp() *DataFrame {
+	return df.mathByColumn("exp", copyContainers(df.labels), func(col *Series, k int) *Series { return col.Exp() })
+}
+
+// Sqrt coerces the values in each column to float64 and returns the square root of each value (see Series.Sqrt).
+// Returns a new DataFrame.
+func (df *DataFrame) Sqrt() *DataFrame {
+	return df.mathByColumn("sqrt", copyContainers(df.labels), func(col *Series, k int) *Series { return col.Sqrt() })
+}
+
+// Pow coerces the values in each column to float64 and raises each value to the power of exp (see Series.Pow).
+// Returns a new DataFrame.
+func (df *DataFrame) Pow(exp float64) *DataFrame {
+	return df.mathByColumn("pow", copyContainers(df.labels), func(col *Series, k int) *Series { return col.Pow(exp) })
+}
+
+// Round coerces the values in each column to float64 and rounds each value to the specified number of decimal places (see Series.Round).
+// Returns a new DataFrame.
+func (df *DataFrame) Round(decimals int) *DataFrame {
+	return df.mathByColumn("round", copyContainers(df.labels), func(col *Series, k int) *Series { return col.Round(decimals) })
+}
+
+// Add broadcasts other across the columns of df: other is aligned with the DataFrame labels (as in Lookup)
+// and added to every column (see Series.Add for how values are coerced and how nulls are handled).
+// Returns a new DataFrame.
+func (df *DataFrame) Add(other *Series, ignoreNulls bool) *DataFrame {
+	return df.broadcastByLabels("add", other, func(col *Series, other *Series) *Series { return col.Add(other, ignoreNulls) })
+}
+
+// Subtract broadcasts other across the columns of df: other is aligned with the DataFrame labels (as in Lookup)
+// and subtracted from every column (see Series.Subtract for how values are coerced and how nulls are handled).
+// Returns a new DataFrame.
+func (df *DataFrame) Subtract(other *Series, ignoreNulls bool) *DataFrame {
+	return df.broadcastByLabels("subtract", other, func(col *Series, other *Series) *Series { return col.Subtract(other, ignoreNulls) })
+}
+
+// Multiply broadcasts other across the columns of df: other is aligned with the DataFrame labels (as in Lookup)
+// and every column is multiplied by it (see Series.Multiply for how values are coerced and how nulls are handled).
+// Returns a new DataFrame.
+func (df *DataFrame) Multiply(other *Series, ignoreNulls bool) *DataFrame {
+	return df.broadcastByLabels("multiply", other, func(col *Series, other *Series) *Series { return col.Multiply(other, ignoreNulls) })
+}
+
+// Divide broadcasts other across the columns of df: other is aligned with the DataFrame labels (as in Lookup)
+// and every column is divided by it (see Series.Divide for how values are coerced and how nulls are handled).
+// Returns a new DataFrame.
+func (df *DataFrame) Divide(other *Series, ignoreNulls bool) *DataFrame {
+	return df.broadcastByLabels("divide", other, func(col *Series, other *Series) *Series { return col.Divide(other, ignoreNulls) })
+}
+
+// AddByColumn broadcasts other across the rows of df: the labels of other are matched with the column names
+// (e.g., the Series returned by df.Mean()), and the matched value is added to every row in that column.
+// Columns without a match are treated as if other were null (see Series.Add for how nulls are handled).
+// Returns a new DataFrame.
+func (df *DataFrame) AddByColumn(other *Series, ignoreNulls bool) *DataFrame {
+	return df.broadcastByColumn("add by column", other, func(col *Series, other *Series) *Series { return col.Add(other, ignoreNulls) })
+}
+
+// SubtractByColumn broadcasts other across the rows of df: the labels of other are matched with the column names
+// (e.g., the Series returned by df.Mean()), and the matched value is subtracted from every row in that column.
+// Columns without a match are treated as if other were null (see Series.Subtract for how nulls are handled).
+// Returns a new DataFrame.
+func (df *DataFrame) SubtractByColumn(other *Series, ignoreNulls bool) *DataFrame {
+	return df.broadcastByColumn("subtract by column", other, func(col *Series, other *Series) *Series { return col.Subtract(other, ignoreNulls) })
+}
+
+// MultiplyByColumn broadcasts other across the rows of df: the labels of other are matched with the column names
+// (e.g., the Series returned by df.Mean()), and every row in that column is multiplied by the matched value.
+// Columns without a match are treated as if other were null (see Series.Multiply for how nulls are handled).
+// Returns a new DataFrame.
+func (df *DataFrame) MultiplyByColumn(other *Series, ignoreNulls bool) *DataFrame {
+	return df.broadcastByColumn("multiply by column", other, func(col *Series, other *Series) *Series { return col.Multiply(other, ignoreNulls) })
+}
+
+// DivideByColumn broadcasts other across the rows of df: the labels of other are matched with the column names
+// (e.g., the Series returned by df.Mean()), and every row in that column is divided by the matched value.
+// Columns without a match are treated as if other were null (see Series.Divide for how nulls are handled).
+// Returns a new DataFrame.
+func (df *DataFrame) DivideByColumn(other *Series, ignoreNulls bool) *DataFrame {
+	return df.broadcastByColumn("divide by column", other, func(col *Series, other *Series) *Series { return col.Divide(other, ignoreNulls) })
+}
@@ -3,6 +3,7 @@ package tada
 import (
 	"errors"
 	"fmt"
+	"math/big"
 	"reflect"
 	"strings"
 	"testing"
@@ -1971,6 +1972,22 @@ func TestDataFrame_Sort(t *testing.T) {
 				colLevelNames: []string{"*0"},
 				name:          "baz"},
 		},
+		{"categorical - explicit order", fields{
+			values: []*valueContainer{
+				{slice: newCategories([]string{"high", "low", "medium"}, []bool{false, false, false}),
+					isNull: []bool{false, false, false}, id: mockID, name: "foo"}},
+			labels:        []*valueContainer{{slice: []int{0, 1, 2}, isNull: []bool{false, false, false}, id: mockID, name: "*0"}},
+			colLevelNames: []string{"*0"},
+			name:          "baz"},
+			args{[]Sorter{{Name: "foo", DType: Categorical, CategoryOrder: []string{"low", "medium", "high"}}}},
+			&DataFrame{
+				values: []*valueContainer{
+					{slice: newCategories([]string{"low", "medium", "high"}, []bool{false, false, false}),
+						isNull: []bool{false, false, false}, id: mockID, name: "foo"}},
+				labels:        []*valueContainer{{slice: []int{1, 2, 0}, isNull: []bool{false, false, false}, id: mockID, name: "*0"}},
+				colLevelNames: []string{"*0"},
+				name:          "baz"},
+		},
 		{"fail - no Sorters", fields{
 			values: []*valueContainer{
 				{slice: []float64{0, 2, 1}, isNull: []bool{false, false, false}, id: mockID, name: "foo"}},
@@ -2686,6 +2703,28 @@ func TestDataFrame_GroupBy(t *testing.T) {
 					}},
 			},
 		},
+		{"categorical - no cache", fields{
+			values: []*valueContainer{{slice: []float64{1, 2, 3}, isNull: []bool{false, false, false}}},
+			labels: []*valueContainer{
+				{slice: newCategories([]string{"foo", "bar", "foo"}, []bool{false, false, false}),
+					isNull: []bool{false, false, false}, id: mockID, name: "a"},
+			}},
+			args{nil},
+			&GroupedDataFrame{
+				orderedKeys: []string{"foo", "bar"},
+				rowIndices:  [][]int{{0, 2}, {1}},
+				labels: []*valueContainer{
+					{slice: newCategories([]string{"foo", "bar", "foo"}, []bool{false, false, false})[:2],
+						isNull: []bool{false, false}, id: mockID, name: "a"},
+				},
+				df: &DataFrame{
+					values: []*valueContainer{{slice: []float64{1, 2, 3}, isNull: []bool{false, false, false}}},
+					labels: []*valueContainer{
+						{slice: newCategories([]string{"foo", "bar", "foo"}, []bool{false, false, false}),
+							isNull: []bool{false, false, false}, id: mockID, name: "a"},
+					}},
+			},
+		},
 		{"fail - no matching column", fields{
 			values: []*valueContainer{{slice: []float64{1, 2}, isNull: []bool{false, false}}},
 			labels: []*valueContainer{
@@ -2878,6 +2917,27 @@ func TestDataFrame_PivotTable(t *testing.T) {
 				name:          "sum_foo"},
 			false,
 		},
+		{"sum - decimal", fields{
+			values: []*valueContainer{
+				{slice: []*big.Rat{mockDecimal("0.1"), mockDecimal("0.2"), mockDecimal("0.3"), mockDecimal("0.4")},
+					isNull: []bool{false, false, false, false}, id: mockID, name: "amount"},
+				{slice: []float64{2018, 2018, 2019, 2019}, isNull: []bool{false, false, false, false}, id: mockID, name: "year"},
+				{slice: []string{"A", "B", "B", "B"}, isNull: []bool{false, false, false, false}, id: mockID, name: "type"}},
+			labels: []*valueContainer{
+				{slice: []int{0, 1, 2, 3}, isNull: []bool{false, false, false, false}, id: mockID, name: "*0"}},
+			colLevelNames: []string{"*0"},
+			name:          "foo"},
+			args{labels: "type", columns: "year", values: "amount", aggFn: "sum"},
+			&DataFrame{values: []*valueContainer{
+				{slice: []*big.Rat{mockDecimal("0.1"), mockDecimal("0.2")}, isNull: []bool{false, false}, id: mockID, name: "2018"},
+				{slice: []*big.Rat{nil, mockDecimal("0.7")}, isNull: []bool{true, false}, id: mockID, name: "2019"},
+			},
+				labels: []*valueContainer{
+					{slice: []string{"A", "B"}, isNull: []bool{false, false}, id: mockID, name: "type"}},
+				colLevelNames: []string{"year"},
+				name:          "sum_foo"},
+			false,
+		},
 		{"mean", fields{
 			values: []*valueContainer{
 				{slice: []float64{1, 2, 3, 4}, isNull: []bool{false, false, false, false}, id: mockID, name: "amount"},
@@ -4534,6 +4594,186 @@ func TestDataFrame_Resample(t *testing.T) {
 	}
 }
 
+func TestDataFrame_Localize(t *testing.T) {
+	tz, err := time.LoadLocation("America/Los_Angeles")
+	if err != nil {
+		t.Fatal(err)
+	}
+	d := time.Date(2020, 2, 15, 12, 0, 0, 0, time.UTC)
+	type fields struct {
+		labels        []*valueContainer
+		values        []*valueContainer
+		name          string
+		err           error
+		colLevelNames []string
+	}
+	type args struct {
+		how map[string]*time.Location
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		want   *DataFrame
+	}{
+		{"pass", fields{
+			values: []*valueContainer{
+				{slice: []time.Time{d}, isNull: []bool{false}, id: mockID, name: "foo"},
+				{slice: []time.Time{d}, isNull: []bool{false}, id: mockID, name: "bar"}},
+			labels:        []*valueContainer{{slice: []int{0}, isNull: []bool{false}, id: mockID, name: "*0"}},
+			name:          "baz",
+			colLevelNames: []string{"*0"},
+		},
+			args{map[string]*time.Location{"foo": tz}},
+			&DataFrame{values: []*valueContainer{
+				{slice: []time.Time{time.Date(2020, 2, 15, 12, 0, 0, 0, tz)}, isNull: []bool{false}, id: mockID, name: "foo"},
+				{slice: []time.Time{d}, isNull: []bool{false}, id: mockID, name: "bar"}},
+				labels:        []*valueContainer{{slice: []int{0}, isNull: []bool{false}, id: mockID, name: "*0"}},
+				name:          "baz",
+				colLevelNames: []string{"*0"}},
+		},
+		{"fail - bad column", fields{
+			values:        []*valueContainer{{slice: []time.Time{d}, isNull: []bool{false}, id: mockID, name: "foo"}},
+			labels:        []*valueContainer{{slice: []int{0}, isNull: []bool{false}, id: mockID, name: "*0"}},
+			colLevelNames: []string{"*0"},
+		},
+			args{map[string]*time.Location{"corge": tz}},
+			&DataFrame{err: fmt.Errorf("localize: name (corge) not found")},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			df := &DataFrame{
+				labels:        tt.fields.labels,
+				values:        tt.fields.values,
+				name:          tt.fields.name,
+				err:           tt.fields.err,
+				colLevelNames: tt.fields.colLevelNames,
+			}
+			if got := df.Localize(tt.args.how); !EqualDataFrames(got, tt.want) {
+				t.Errorf("DataFrame.Localize() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDataFrame_ConvertTimeZone(t *testing.T) {
+	tz, err := time.LoadLocation("America/Los_Angeles")
+	if err != nil {
+		t.Fatal(err)
+	}
+	d := time.Date(2020, 2, 15, 12, 0, 0, 0, time.UTC)
+	type fields struct {
+		labels        []*valueContainer
+		values        []*valueContainer
+		name          string
+		err           error
+		colLevelNames []string
+	}
+	type args struct {
+		how map[string]*time.Location
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		want   *DataFrame
+	}{
+		{"pass - label level", fields{
+			values:        []*valueContainer{{slice: []float64{1}, isNull: []bool{false}, id: mockID, name: "foo"}},
+			labels:        []*valueContainer{{slice: []time.Time{d}, isNull: []bool{false}, id: mockID, name: "*0"}},
+			colLevelNames: []string{"*0"},
+		},
+			args{map[string]*time.Location{"*0": tz}},
+			&DataFrame{values: []*valueContainer{{slice: []float64{1}, isNull: []bool{false}, id: mockID, name: "foo"}},
+				labels:        []*valueContainer{{slice: []time.Time{d.In(tz)}, isNull: []bool{false}, id: mockID, name: "*0"}},
+				colLevelNames: []string{"*0"}},
+		},
+		{"fail - bad column", fields{
+			values:        []*valueContainer{{slice: []time.Time{d}, isNull: []bool{false}, id: mockID, name: "foo"}},
+			labels:        []*valueContainer{{slice: []int{0}, isNull: []bool{false}, id: mockID, name: "*0"}},
+			colLevelNames: []string{"*0"},
+		},
+			args{map[string]*time.Location{"corge": tz}},
+			&DataFrame{err: fmt.Errorf("convert time zone: name (corge) not found")},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			df := &DataFrame{
+				labels:        tt.fields.labels,
+				values:        tt.fields.values,
+				name:          tt.fields.name,
+				err:           tt.fields.err,
+				colLevelNames: tt.fields.colLevelNames,
+			}
+			if got := df.ConvertTimeZone(tt.args.how); !EqualDataFrames(got, tt.want) {
+				t.Errorf("DataFrame.ConvertTimeZone() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDataFrame_Mask(t *testing.T) {
+	type fields struct {
+		labels        []*valueContainer
+		values        []*valueContainer
+		name          string
+		err           error
+		colLevelNames []string
+	}
+	type args struct {
+		mask *Series
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		want   *DataFrame
+	}{
+		{"pass", fields{
+			values: []*valueContainer{
+				{slice: []float64{1, 2, 3}, isNull: []bool{false, false, false}, id: mockID, name: "foo"},
+				{slice: []string{"a", "b", "c"}, isNull: []bool{false, false, false}, id: mockID, name: "bar"}},
+			labels:        []*valueContainer{{slice: []int{0, 1, 2}, isNull: []bool{false, false, false}, id: mockID, name: "*0"}},
+			name:          "baz",
+			colLevelNames: []string{"*0"},
+		},
+			args{&Series{values: &valueContainer{slice: []bool{false, true, true}, isNull: []bool{false, false, true}, id: mockID, name: "foo"},
+				labels: []*valueContainer{{slice: []int{0, 1, 2}, isNull: []bool{false, false, false}, id: mockID, name: "*0"}}}},
+			&DataFrame{values: []*valueContainer{
+				{slice: []float64{2}, isNull: []bool{false}, id: mockID, name: "foo"},
+				{slice: []string{"b"}, isNull: []bool{false}, id: mockID, name: "bar"}},
+				labels:        []*valueContainer{{slice: []int{1}, isNull: []bool{false}, id: mockID, name: "*0"}},
+				name:          "baz",
+				colLevelNames: []string{"*0"}},
+		},
+		{"fail - no matching labels", fields{
+			values:        []*valueContainer{{slice: []float64{1}, isNull: []bool{false}, id: mockID, name: "foo"}},
+			labels:        []*valueContainer{{slice: []int{0}, isNull: []bool{false}, id: mockID, name: "*0"}},
+			colLevelNames: []string{"*0"},
+		},
+			args{&Series{values: &valueContainer{slice: []bool{true}, isNull: []bool{false}, id: mockID, name: "foo"},
+				labels: []*valueContainer{{slice: []int{0}, isNull: []bool{false}, id: mockID, name: "corge"}}}},
+			&DataFrame{err: fmt.Errorf("masking rows: mask: no matching keys between containers")},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			df := &DataFrame{
+				labels:        tt.fields.labels,
+				values:        tt.fields.values,
+				name:          tt.fields.name,
+				err:           tt.fields.err,
+				colLevelNames: tt.fields.colLevelNames,
+			}
+			if got := df.Mask(tt.args.mask); !EqualDataFrames(got, tt.want) {
+				t.Errorf("DataFrame.Mask() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
 func TestDataFrame_SetNulls(t *testing.T) {
 	type fields struct {
 		labels        []*valueContainer
@@ -5199,3 +5439,299 @@ func TestDataFrame_SetName(t *testing.T) {
 		})
 	}
 }
+
+func TestDataFrame_MultiplyScalar(t *testing.T) {
+	type fields struct {
+		labels        []*valueContainer
+		values        []*valueContainer
+		name          string
+		err           error
+		colLevelNames []string
+	}
+	type args struct {
+		c float64
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		want   *DataFrame
+	}{
+		{"pass", fields{
+			values: []*valueContainer{
+				{slice: []float64{1, 2}, isNull: []bool{false, true}, id: mockID, name: "foo"},
+				{slice: []*big.Rat{mockDecimal("0.1"), mockDecimal("0.2")}, isNull: []bool{false, false}, id: mockID, name: "bar"}},
+			labels:        []*valueContainer{{slice: []int{0, 1}, isNull: []bool{false, false}, id: mockID, name: "*0"}},
+			name:          "baz",
+			colLevelNames: []string{"*0"},
+		},
+			args{3},
+			&DataFrame{values: []*valueContainer{
+				{slice: []float64{3, 0}, isNull: []bool{false, true}, id: mockID, name: "foo"},
+				{slice: []*big.Rat{mockDecimal("0.3"), mockDecimal("0.6")}, isNull: []bool{false, false}, id: mockID, name: "bar"}},
+				labels:        []*valueContainer{{slice: []int{0, 1}, isNull: []bool{false, false}, id: mockID, name: "*0"}},
+				name:          "baz",
+				colLevelNames: []string{"*0"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			df := &DataFrame{
+				labels:        tt.fields.labels,
+				values:        tt.fields.values,
+				name:          tt.fields.name,
+				err:           tt.fields.err,
+				colLevelNames: tt.fields.colLevelNames,
+			}
+			if got := df.MultiplyScalar(tt.args.c); !EqualDataFrames(got, tt.want) {
+				t.Errorf("DataFrame.MultiplyScalar() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDataFrame_Round(t *testing.T) {
+	type fields struct {
+		labels        []*valueContainer
+		values        []*valueContainer
+		name          string
+		err           error
+		colLevelNames []string
+	}
+	type args struct {
+		decimals int
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		want   *DataFrame
+	}{
+		{"pass", fields{
+			values: []*valueContainer{
+				{slice: []float64{1.25, -2.5}, isNull: []bool{false, false}, id: mockID, name: "foo"},
+				{slice: []int{1, 2}, isNull: []bool{false, false}, id: mockID, name: "bar"}},
+			labels:        []*valueContainer{{slice: []int{0, 1}, isNull: []bool{false, false}, id: mockID, name: "*0"}},
+			colLevelNames: []string{"*0"},
+		},
+			args{0},
+			&DataFrame{values: []*valueContainer{
+				{slice: []float64{1, -3}, isNull: []bool{false, false}, id: mockID, name: "foo"},
+				{slice: []float64{1, 2}, isNull: []bool{false, false}, id: mockID, name: "bar"}},
+				labels:        []*valueContainer{{slice: []int{0, 1}, isNull: []bool{false, false}, id: mockID, name: "*0"}},
+				colLevelNames: []string{"*0"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			df := &DataFrame{
+				labels:        tt.fields.labels,
+				values:        tt.fields.values,
+				name:          tt.fields.name,
+				err:           tt.fields.err,
+				colLevelNames: tt.fields.colLevelNames,
+			}
+			if got := df.Round(tt.args.decimals); !EqualDataFrames(got, tt.want) {
+				t.Errorf("DataFrame.Round() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDataFrame_Add_receiverUnchanged(t *testing.T) {
+	newDF := func() *DataFrame {
+		return &DataFrame{
+			values: []*valueContainer{
+				{slice: []string{"a", "1"}, isNull: []bool{false, false}, id: mockID, name: "foo"}},
+			labels:        []*valueContainer{{slice: []int{0, 1}, isNull: []bool{false, false}, id: mockID, name: "*0"}},
+			colLevelNames: []string{"*0"},
+		}
+	}
+	other := &Series{values: &valueContainer{slice: []float64{1, 2}, isNull: []bool{false, false}, id: mockID, name: "foo"},
+		labels: []*valueContainer{{slice: []int{0, 1}, isNull: []bool{false, false}, id: mockID, name: "*0"}}}
+	otherByColumn := &Series{values: &valueContainer{slice: []float64{1}, isNull: []bool{false}, id: mockID, name: "bar"},
+		labels: []*valueContainer{{slice: []string{"foo"}, isNull: []bool{false}, id: mockID, name: "*0"}}}
+	for name, fn := range map[string]func(df *DataFrame) *DataFrame{
+		"Add":            func(df *DataFrame) *DataFrame { return df.Add(other, false) },
+		"AddScalar":      func(df *DataFrame) *DataFrame { return df.AddScalar(1) },
+		"AddByColumn":    func(df *DataFrame) *DataFrame { return df.AddByColumn(otherByColumn, false) },
+		"DivideByColumn": func(df *DataFrame) *DataFrame { return df.DivideByColumn(otherByColumn, false) },
+	} {
+		df := newDF()
+		if got := fn(df); got.err != nil {
+			t.Errorf("DataFrame.%s() error = %v", name, got.err)
+		}
+		if want := newDF(); !EqualDataFrames(df, want) {
+			t.Errorf("DataFrame.%s() modified receiver = %v, want %v", name, df, want)
+		}
+	}
+}
+
+func TestDataFrame_Subtract(t *testing.T) {
+	type fields struct {
+		labels        []*valueContainer
+		values        []*valueContainer
+		name          string
+		err           error
+		colLevelNames []string
+	}
+	type args struct {
+		other       *Series
+		ignoreNulls bool
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		want   *DataFrame
+	}{
+		{"aligned labels", fields{
+			values: []*valueContainer{
+				{slice: []float64{1, 2}, isNull: []bool{false, false}, id: mockID, name: "foo"},
+				{slice: []float64{3, 4}, isNull: []bool{false, false}, id: mockID, name: "bar"}},
+			labels:        []*valueContainer{{slice: []int{0, 1}, isNull: []bool{false, false}, id: mockID, name: "*0"}},
+			colLevelNames: []string{"*0"},
+		},
+			args{&Series{values: &valueContainer{slice: []float64{1, 0}, isNull: []bool{false, true}, id: mockID, name: "qux"},
+				labels: []*valueContainer{{slice: []int{0, 1}, isNull: []bool{false, false}, id: mockID, name: "*0"}}}, false},
+			&DataFrame{values: []*valueContainer{
+				{slice: []float64{0, 0}, isNull: []bool{false, true}, id: mockID, name: "foo"},
+				{slice: []float64{2, 0}, isNull: []bool{false, true}, id: mockID, name: "bar"}},
+				labels:        []*valueContainer{{slice: []int{0, 1}, isNull: []bool{false, false}, id: mockID, name: "*0"}},
+				colLevelNames: []string{"*0"}},
+		},
+		{"unaligned labels", fields{
+			values: []*valueContainer{
+				{slice: []float64{1, 2}, isNull: []bool{false, false}, id: mockID, name: "foo"},
+				{slice: []float64{3, 4}, isNull: []bool{false, false}, id: mockID, name: "bar"}},
+			labels:        []*valueContainer{{slice: []int{0, 1}, isNull: []bool{false, false}, id: mockID, name: "*0"}},
+			colLevelNames: []string{"*0"},
+		},
+			args{&Series{values: &valueContainer{slice: []float64{1, 2}, isNull: []bool{false, false}, id: mockID, name: "qux"},
+				labels: []*valueContainer{{slice: []int{1, 2}, isNull: []bool{false, false}, id: mockID, name: "*0"}}}, false},
+			&DataFrame{values: []*valueContainer{
+				{slice: []float64{0, 1}, isNull: []bool{true, false}, id: mockID, name: "foo"},
+				{slice: []float64{0, 3}, isNull: []bool{true, false}, id: mockID, name: "bar"}},
+				labels: []*valueContainer{{slice: []int{0, 1}, isNull: []bool{false, false},
+					cache: []string{"0", "1"}, id: mockID, name: "*0"}},
+				colLevelNames: []string{"*0"}},
+		},
+		{"fail - no matching labels", fields{
+			values:        []*valueContainer{{slice: []float64{1}, isNull: []bool{false}, id: mockID, name: "foo"}},
+			labels:        []*valueContainer{{slice: []int{0}, isNull: []bool{false}, id: mockID, name: "*0"}},
+			colLevelNames: []string{"*0"},
+		},
+			args{&Series{values: &valueContainer{slice: []float64{1}, isNull: []bool{false}, id: mockID, name: "qux"},
+				labels: []*valueContainer{{slice: []int{0}, isNull: []bool{false}, id: mockID, name: "corge"}}}, false},
+			&DataFrame{err: fmt.Errorf("subtract: no matching keys between containers")},
+		},
+		{"fail - nil", fields{
+			values:        []*valueContainer{{slice: []float64{1}, isNull: []bool{false}, id: mockID, name: "foo"}},
+			labels:        []*valueContainer{{slice: []int{0}, isNull: []bool{false}, id: mockID, name: "*0"}},
+			colLevelNames: []string{"*0"},
+		},
+			args{nil, false},
+			&DataFrame{err: fmt.Errorf("subtract: other cannot be nil")},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			df := &DataFrame{
+				labels:        tt.fields.labels,
+				values:        tt.fields.values,
+				name:          tt.fields.name,
+				err:           tt.fields.err,
+				colLevelNames: tt.fields.colLevelNames,
+			}
+			got := df.Subtract(tt.args.other, tt.args.ignoreNulls)
+			if !EqualDataFrames(got, tt.want) {
+				t.Errorf("DataFrame.Subtract() = %v, want %v", got, tt.want)
+			}
+			if got.err != nil && got.err.Error() != tt.want.err.Error() {
+				t.Errorf("DataFrame.Subtract() error = %v, want %v", got.err, tt.want.err)
+			}
+		})
+	}
+}
+
+func TestDataFrame_SubtractByColumn(t *testing.T) {
+	type fields struct {
+		labels        []*valueContainer
+		values        []*valueContainer
+		name          string
+		err           error
+		colLevelNames []string
+	}
+	type args struct {
+		other       *Series
+		ignoreNulls bool
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		want   *DataFrame
+	}{
+		{"pass", fields{
+			values: []*valueContainer{
+				{slice: []float64{1, 2}, isNull: []bool{false, false}, id: mockID, name: "foo"},
+				{slice: []float64{3, 4}, isNull: []bool{false, true}, id: mockID, name: "bar"},
+				{slice: []float64{5, 6}, isNull: []bool{false, false}, id: mockID, name: "qux"}},
+			labels:        []*valueContainer{{slice: []int{0, 1}, isNull: []bool{false, false}, id: mockID, name: "*0"}},
+			name:          "baz",
+			colLevelNames: []string{"*0"},
+		},
+			args{&Series{values: &valueContainer{slice: []float64{1.5, 3}, isNull: []bool{false, false}, id: mockID, name: "mean"},
+				labels: []*valueContainer{{slice: []string{"foo", "bar"}, isNull: []bool{false, false}, id: mockID, name: "*0"}}}, false},
+			&DataFrame{values: []*valueContainer{
+				{slice: []float64{-0.5, 0.5}, isNull: []bool{false, false}, id: mockID, name: "foo"},
+				{slice: []float64{0, 0}, isNull: []bool{false, true}, id: mockID, name: "bar"},
+				{slice: []float64{0, 0}, isNull: []bool{true, true}, id: mockID, name: "qux"}},
+				labels:        []*valueContainer{{slice: []int{0, 1}, isNull: []bool{false, false}, id: mockID, name: "*0"}},
+				name:          "baz",
+				colLevelNames: []string{"*0"}},
+		},
+		{"ignore nulls", fields{
+			values: []*valueContainer{
+				{slice: []float64{1, 2}, isNull: []bool{false, false}, id: mockID, name: "foo"},
+				{slice: []float64{5, 6}, isNull: []bool{false, false}, id: mockID, name: "qux"}},
+			labels:        []*valueContainer{{slice: []int{0, 1}, isNull: []bool{false, false}, id: mockID, name: "*0"}},
+			colLevelNames: []string{"*0"},
+		},
+			args{&Series{values: &valueContainer{slice: []float64{1}, isNull: []bool{false}, id: mockID, name: "mean"},
+				labels: []*valueContainer{{slice: []string{"foo"}, isNull: []bool{false}, id: mockID, name: "*0"}}}, true},
+			&DataFrame{values: []*valueContainer{
+				{slice: []float64{0, 1}, isNull: []bool{false, false}, id: mockID, name: "foo"},
+				{slice: []float64{5, 6}, isNull: []bool{false, false}, id: mockID, name: "qux"}},
+				labels:        []*valueContainer{{slice: []int{0, 1}, isNull: []bool{false, false}, id: mockID, name: "*0"}},
+				colLevelNames: []string{"*0"}},
+		},
+		{"fail - no matching columns", fields{
+			values:        []*valueContainer{{slice: []float64{1}, isNull: []bool{false}, id: mockID, name: "foo"}},
+			labels:        []*valueContainer{{slice: []int{0}, isNull: []bool{false}, id: mockID, name: "*0"}},
+			colLevelNames: []string{"*0"},
+		},
+			args{&Series{values: &valueContainer{slice: []float64{1}, isNull: []bool{false}, id: mockID, name: "mean"},
+				labels: []*valueContainer{{slice: []string{"corge"}, isNull: []bool{false}, id: mockID, name: "*0"}}}, false},
+			&DataFrame{err: fmt.Errorf("subtract by column: no matching keys between column names and labels in other")},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			df := &DataFrame{
+				labels:        tt.fields.labels,
+				values:        tt.fields.values,
+				name:          tt.fields.name,
+				err:           tt.fields.err,
+				colLevelNames: tt.fields.colLevelNames,
+			}
+			got := df.SubtractByColumn(tt.args.other, tt.args.ignoreNulls)
+			if !EqualDataFrames(got, tt.want) {
+				t.Errorf("DataFrame.SubtractByColumn() = %v, want %v", got, tt.want)
+			}
+			if got.err != nil && got.err.Error() != tt.want.err.Error() {
+				t.Errorf("DataFrame.SubtractByColumn() error = %v, want %v", got.err, tt.want.err)
+			}
+		})
+	}
+}
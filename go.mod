@@ -4,7 +4,11 @@ go 1.14
 
 require (
 	cloud.google.com/go v0.56.0
+	github.com/apache/arrow/go/arrow v0.0.0-20200923215132-ac86123a3f01
 	github.com/d4l3k/messagediff v1.2.1
+	github.com/dsnet/compress v0.0.1
+	github.com/fraugster/parquet-go v0.3.0
+	github.com/klauspost/compress v1.11.0
 	github.com/ptiger10/tablediff v0.3.0
 	github.com/ptiger10/tablewriter v0.3.2
 )
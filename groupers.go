@@ -2,6 +2,7 @@ package tada
 
 import (
 	"fmt"
+	"math/big"
 	"reflect"
 	"sort"
 	"time"
@@ -135,17 +136,41 @@ func (g *GroupedSeries) Reduce(name string, lambda ReduceFn) *Series {
 }
 
 // Sum coerces values to float64 and calculates the sum of each group.
+// If the values are []int64, the sum is calculated exactly and returned as []int64 (a group whose sum overflows is null).
+// If the values are []*big.Rat (Decimal), the sum is calculated exactly and returned as []*big.Rat.
+// If the values are []time.Duration (Duration), the sum is returned as []time.Duration.
 func (g *GroupedSeries) Sum() *Series {
+	if g.series.values.isInt64() {
+		return g.int64ReduceFunc("sum", sumInt64)
+	}
+	if g.series.values.isDecimal() {
+		return g.decimalReduceFunc("sum", sumDecimal)
+	}
+	if g.series.values.isDuration() {
+		return g.durationReduceFunc("sum", sumDuration)
+	}
 	return g.float64ReduceFunc("sum", sum)
 }
 
 // Mean coerces values to float64 and calculates the mean of each group.
+// If the values are []*big.Rat (Decimal), the mean is calculated exactly and returned as []*big.Rat.
+// If the values are []time.Duration (Duration), the mean is returned as []time.Duration.
 func (g *GroupedSeries) Mean() *Series {
+	if g.series.values.isDecimal() {
+		return g.decimalReduceFunc("mean", meanDecimal)
+	}
+	if g.series.values.isDuration() {
+		return g.durationReduceFunc("mean", meanDuration)
+	}
 	return g.float64ReduceFunc("mean", mean)
 }
 
 // Median coerces values to float64 and calculates the median of each group.
+// If the values are []time.Duration (Duration), the median is returned as []time.Duration.
 func (g *GroupedSeries) Median() *Series {
+	if g.series.values.isDuration() {
+		return g.durationReduceFunc("median", medianDuration)
+	}
 	return g.float64ReduceFunc("median", median)
 }
 
@@ -165,12 +190,32 @@ func (g *GroupedSeries) NUnique() *Series {
 }
 
 // Min coerces values to float64 and calculates the minimum of each group.
+// If the values are []int64, []*big.Rat (Decimal), or []time.Duration (Duration), the minimum is returned as the same type.
 func (g *GroupedSeries) Min() *Series {
+	if g.series.values.isInt64() {
+		return g.int64ReduceFunc("min", minInt64)
+	}
+	if g.series.values.isDecimal() {
+		return g.decimalReduceFunc("min", minDecimal)
+	}
+	if g.series.values.isDuration() {
+		return g.durationReduceFunc("min", minDuration)
+	}
 	return g.float64ReduceFunc("min", min)
 }
 
 // Max coerces values to float64 and calculates the maximum of each group.
+// If the values are []int64, []*big.Rat (Decimal), or []time.Duration (Duration), the maximum is returned as the same type.
 func (g *GroupedSeries) Max() *Series {
+	if g.series.values.isInt64() {
+		return g.int64ReduceFunc("max", maxInt64)
+	}
+	if g.series.values.isDecimal() {
+		return g.decimalReduceFunc("max", maxDecimal)
+	}
+	if g.series.values.isDuration() {
+		return g.durationReduceFunc("max", maxDuration)
+	}
 	return g.float64ReduceFunc("max", max)
 }
 
@@ -478,6 +523,49 @@ func (g *GroupedDataFrame) countReduceFunc(name string, cols []string, fn func(i
 	}
 }
 
+// numericReduceFunc reduces []int64 columns with int64Fn (if not nil), []*big.Rat columns with decimalFn,
+// and all other columns (coerced to float64) with fn.
+func (g *GroupedDataFrame) numericReduceFunc(
+	name string, cols []string,
+	fn func([]float64, []bool, []int) (float64, bool), int64Fn func([]int64, []bool, []int) (int64, bool),
+	decimalFn func([]*big.Rat, []bool, []int) (*big.Rat, bool),
+	durationFn func([]time.Duration, []bool, []int) (time.Duration, bool)) *DataFrame {
+	if len(cols) == 0 {
+		cols = g.df.ListColNames()
+	}
+	adjustedColNames := make([]string, len(cols))
+	for k := range cols {
+		adjustedColNames[k] = fmt.Sprintf("%v_%v", name, cols[k])
+	}
+	retVals := make([]*valueContainer, len(cols))
+	for k, colName := range cols {
+		index, _ := indexOfContainer(colName, g.df.values)
+		if int64Fn != nil && g.df.values[index].isInt64() {
+			retVals[k] = groupedInt64ReduceFunc(
+				g.df.values[index].int64().slice, g.df.values[index].isNull, adjustedColNames[k], g.aligned, g.rowIndices, int64Fn)
+		} else if decimalFn != nil && g.df.values[index].isDecimal() {
+			retVals[k] = groupedDecimalReduceFunc(
+				g.df.values[index].decimal().slice, g.df.values[index].isNull, adjustedColNames[k], g.aligned, g.rowIndices, decimalFn)
+		} else if durationFn != nil && g.df.values[index].isDuration() {
+			retVals[k] = groupedDurationReduceFunc(
+				g.df.values[index].duration().slice, g.df.values[index].isNull, adjustedColNames[k], g.aligned, g.rowIndices, durationFn)
+		} else {
+			retVals[k] = groupedFloat64ReduceFunc(
+				g.df.values[index].float64().slice, g.df.values[index].isNull, adjustedColNames[k], g.aligned, g.rowIndices, fn)
+		}
+	}
+	if g.df.name != "" {
+		name = fmt.Sprintf("%v_%v", name, g.df.name)
+	}
+
+	return &DataFrame{
+		values:        retVals,
+		labels:        g.labels,
+		colLevelNames: []string{"*0"},
+		name:          name,
+	}
+}
+
 // GetGroup returns the grouped rows sharing the same group key as a new DataFrame.
 func (g *GroupedDataFrame) GetGroup(group string) *DataFrame {
 	for i, key := range g.orderedKeys {
@@ -489,18 +577,24 @@ func (g *GroupedDataFrame) GetGroup(group string) *DataFrame {
 }
 
 // Sum coerces the column values in colNames to float64 and calculates the sum of each group.
+// Sums of []int64 columns are calculated exactly and returned as []int64 (a group whose sum overflows is null),
+// sums of []*big.Rat (Decimal) columns are calculated exactly and returned as []*big.Rat,
+// and sums of []time.Duration (Duration) columns are returned as []time.Duration.
 func (g *GroupedDataFrame) Sum(colNames ...string) *DataFrame {
-	return g.float64ReduceFunc("sum", colNames, sum)
+	return g.numericReduceFunc("sum", colNames, sum, sumInt64, sumDecimal, sumDuration)
 }
 
 // Mean coerces the column values in colNames to float64 and calculates the mean of each group.
+// Means of []*big.Rat (Decimal) columns are calculated exactly and returned as []*big.Rat,
+// and means of []time.Duration (Duration) columns are returned as []time.Duration.
 func (g *GroupedDataFrame) Mean(colNames ...string) *DataFrame {
-	return g.float64ReduceFunc("mean", colNames, mean)
+	return g.numericReduceFunc("mean", colNames, mean, nil, meanDecimal, meanDuration)
 }
 
 // Median coerces the column values in colNames to float64 and calculates the median of each group.
+// Medians of []time.Duration (Duration) columns are returned as []time.Duration.
 func (g *GroupedDataFrame) Median(colNames ...string) *DataFrame {
-	return g.float64ReduceFunc("median", colNames, median)
+	return g.numericReduceFunc("median", colNames, median, nil, nil, medianDuration)
 }
 
 // StdDev coerces the column values in colNames to float64 and calculates the standard deviation of each group.
@@ -509,13 +603,15 @@ func (g *GroupedDataFrame) StdDev(colNames ...string) *DataFrame {
 }
 
 // Min coerces the column values in colNames to float64 and calculates the minimum of each group.
+// Minimums of []int64, []*big.Rat (Decimal), and []time.Duration (Duration) columns are returned as the same type.
 func (g *GroupedDataFrame) Min(colNames ...string) *DataFrame {
-	return g.float64ReduceFunc("min", colNames, min)
+	return g.numericReduceFunc("min", colNames, min, minInt64, minDecimal, minDuration)
 }
 
 // Max coerces the column values in colNames to float64 and calculates the maximum of each group.
+// Maximums of []int64, []*big.Rat (Decimal), and []time.Duration (Duration) columns are returned as the same type.
 func (g *GroupedDataFrame) Max(colNames ...string) *DataFrame {
-	return g.float64ReduceFunc("max", colNames, max)
+	return g.numericReduceFunc("max", colNames, max, maxInt64, maxDecimal, maxDuration)
 }
 
 // Count returns the number of non-null values in each group for the columns in colNames.
@@ -6,6 +6,7 @@ package tada
 
 import (
 	"fmt"
+	"math/big"
 	"time"
 )
 
@@ -248,3 +249,162 @@ func (g *GroupedDataFrame) dateTimeReduceFunc(
 		name:          name,
 	}
 }
+
+func groupedInt64ReduceFunc(
+	slice []int64,
+	nulls []bool,
+	name string,
+	aligned bool,
+	rowIndices [][]int,
+	fn func([]int64, []bool, []int) (int64, bool)) *valueContainer {
+	// default: return length is equal to the number of groups
+	retLength := len(rowIndices)
+	if aligned {
+		// if aligned: return length is overwritten to equal the length of original data
+		retLength = len(slice)
+	}
+	retVals := make([]int64, retLength)
+	retNulls := make([]bool, retLength)
+	for i, rowIndex := range rowIndices {
+		output, isNull := fn(slice, nulls, rowIndex)
+		if !aligned {
+			// default: write each output once and in sequential order into retVals
+			retVals[i] = output
+			retNulls[i] = isNull
+		} else {
+			// if aligned: write each output multiple times and out of order into retVals
+			for _, index := range rowIndex {
+				retVals[index] = output
+				retNulls[index] = isNull
+			}
+		}
+	}
+	return newValueContainer(retVals, retNulls, name)
+}
+
+func (g *GroupedSeries) int64ReduceFunc(name string, fn func(slice []int64, isNull []bool, index []int) (int64, bool)) *Series {
+	var sharedData bool
+	if g.series.values.name != "" {
+		name = fmt.Sprintf("%v_%v", name, g.series.values.name)
+	}
+	retVals := groupedInt64ReduceFunc(
+		g.series.values.int64().slice, g.series.values.isNull, name, g.aligned, g.rowIndices, fn)
+	// default: grouped labels
+	retLabels := g.labels
+	if g.aligned {
+		// if aligned: all labels
+		retLabels = g.series.labels
+		sharedData = true
+	}
+	return &Series{
+		values:     retVals,
+		labels:     retLabels,
+		sharedData: sharedData,
+	}
+}
+
+func groupedDecimalReduceFunc(
+	slice []*big.Rat,
+	nulls []bool,
+	name string,
+	aligned bool,
+	rowIndices [][]int,
+	fn func([]*big.Rat, []bool, []int) (*big.Rat, bool)) *valueContainer {
+	// default: return length is equal to the number of groups
+	retLength := len(rowIndices)
+	if aligned {
+		// if aligned: return length is overwritten to equal the length of original data
+		retLength = len(slice)
+	}
+	retVals := make([]*big.Rat, retLength)
+	retNulls := make([]bool, retLength)
+	for i, rowIndex := range rowIndices {
+		output, isNull := fn(slice, nulls, rowIndex)
+		if !aligned {
+			// default: write each output once and in sequential order into retVals
+			retVals[i] = output
+			retNulls[i] = isNull
+		} else {
+			// if aligned: write each output multiple times and out of order into retVals
+			for _, index := range rowIndex {
+				retVals[index] = output
+				retNulls[index] = isNull
+			}
+		}
+	}
+	return newValueContainer(retVals, retNulls, name)
+}
+
+func (g *GroupedSeries) decimalReduceFunc(name string, fn func(slice []*big.Rat, isNull []bool, index []int) (*big.Rat, bool)) *Series {
+	var sharedData bool
+	if g.series.values.name != "" {
+		name = fmt.Sprintf("%v_%v", name, g.series.values.name)
+	}
+	retVals := groupedDecimalReduceFunc(
+		g.series.values.decimal().slice, g.series.values.isNull, name, g.aligned, g.rowIndices, fn)
+	// default: grouped labels
+	retLabels := g.labels
+	if g.aligned {
+		// if aligned: all labels
+		retLabels = g.series.labels
+		sharedData = true
+	}
+	return &Series{
+		values:     retVals,
+		labels:     retLabels,
+		sharedData: sharedData,
+	}
+}
+
+func groupedDurationReduceFunc(
+	slice []time.Duration,
+	nulls []bool,
+	name string,
+	aligned bool,
+	rowIndices [][]int,
+	fn func([]time.Duration, []bool, []int) (time.Duration, bool)) *valueContainer {
+	// default: return length is equal to the number of groups
+	retLength := len(rowIndices)
+	if aligned {
+		// if aligned: return length is overwritten to equal the length of original data
+		retLength = len(slice)
+	}
+	retVals := make([]time.Duration, retLength)
+	retNulls := make([]bool, retLength)
+	for i, rowIndex := range rowIndices {
+		output, isNull := fn(slice, nulls, rowIndex)
+		if !aligned {
+			// default: write each output once and in sequential order into retVals
+			retVals[i] = output
+			retNulls[i] = isNull
+		} else {
+			// if aligned: write each output multiple times and out of order into retVals
+			for _, index := range rowIndex {
+				retVals[index] = output
+				retNulls[index] = isNull
+			}
+		}
+	}
+	return newValueContainer(retVals, retNulls, name)
+}
+
+func (g *GroupedSeries) durationReduceFunc(name string, fn func(slice []time.Duration, isNull []bool, index []int) (time.Duration, bool)) *Series {
+	var sharedData bool
+	if g.series.values.name != "" {
+		name = fmt.Sprintf("%v_%v", name, g.series.values.name)
+	}
+	retVals := groupedDurationReduceFunc(
+		g.series.values.duration().slice, g.series.values.isNull, name, g.aligned, g.rowIndices, fn)
+	// default: grouped labels
+	retLabels := g.labels
+	if g.aligned {
+		// if aligned: all labels
+		retLabels = g.series.labels
+		sharedData = true
+	}
+	return &Series{
+		values:     retVals,
+		labels:     retLabels,
+		sharedData: sharedData,
+	}
+}
@@ -3,6 +3,7 @@ package tada
 import (
 	"errors"
 	"fmt"
+	"math/big"
 	"reflect"
 	"strings"
 	"testing"
@@ -587,6 +588,43 @@ func TestGroupedSeries_Sum(t *testing.T) {
 						{slice: []string{"foo", "foo", "bar", "bar"}, isNull: []bool{false, false, false, false}, id: mockID, name: "*0"}}}},
 			want: &Series{values: &valueContainer{slice: []float64{3, 7}, isNull: []bool{false, false}, id: mockID, name: "sum"},
 				labels: []*valueContainer{{slice: []string{"foo", "bar"}, isNull: []bool{false, false}, id: mockID, name: "*0"}}}},
+		{
+			name: "int64 - exact sum and null group",
+			fields: fields{
+				orderedKeys: []string{"foo", "bar"},
+				rowIndices:  [][]int{{0, 1}, {2, 3}},
+				labels:      []*valueContainer{{slice: []string{"foo", "bar"}, isNull: []bool{false, false}, id: mockID, name: "*0"}},
+				series: &Series{values: &valueContainer{slice: []int64{9007199254740992, 1, 3, 4}, isNull: []bool{false, false, true, true}},
+					labels: []*valueContainer{
+						{slice: []string{"foo", "foo", "bar", "bar"}, isNull: []bool{false, false, false, false}, id: mockID, name: "*0"}}}},
+			want: &Series{values: &valueContainer{slice: []int64{9007199254740993, 0}, isNull: []bool{false, true}, id: mockID, name: "sum"},
+				labels: []*valueContainer{{slice: []string{"foo", "bar"}, isNull: []bool{false, false}, id: mockID, name: "*0"}}}},
+		{
+			name: "duration",
+			fields: fields{
+				orderedKeys: []string{"foo", "bar"},
+				rowIndices:  [][]int{{0, 1}, {2, 3}},
+				labels:      []*valueContainer{{slice: []string{"foo", "bar"}, isNull: []bool{false, false}, id: mockID, name: "*0"}},
+				series: &Series{values: &valueContainer{
+					slice:  []time.Duration{time.Hour, time.Minute, time.Second, time.Second},
+					isNull: []bool{false, false, true, true}},
+					labels: []*valueContainer{
+						{slice: []string{"foo", "foo", "bar", "bar"}, isNull: []bool{false, false, false, false}, id: mockID, name: "*0"}}}},
+			want: &Series{values: &valueContainer{slice: []time.Duration{time.Hour + time.Minute, 0}, isNull: []bool{false, true}, id: mockID, name: "sum"},
+				labels: []*valueContainer{{slice: []string{"foo", "bar"}, isNull: []bool{false, false}, id: mockID, name: "*0"}}}},
+		{
+			name: "decimal - exact sum",
+			fields: fields{
+				orderedKeys: []string{"foo", "bar"},
+				rowIndices:  [][]int{{0, 1}, {2, 3}},
+				labels:      []*valueContainer{{slice: []string{"foo", "bar"}, isNull: []bool{false, false}, id: mockID, name: "*0"}},
+				series: &Series{values: &valueContainer{
+					slice:  []*big.Rat{mockDecimal("0.1"), mockDecimal("0.2"), mockDecimal("19.99"), mockDecimal("0.01")},
+					isNull: []bool{false, false, false, false}},
+					labels: []*valueContainer{
+						{slice: []string{"foo", "foo", "bar", "bar"}, isNull: []bool{false, false, false, false}, id: mockID, name: "*0"}}}},
+			want: &Series{values: &valueContainer{slice: []*big.Rat{mockDecimal("0.3"), mockDecimal("20")}, isNull: []bool{false, false}, id: mockID, name: "sum"},
+				labels: []*valueContainer{{slice: []string{"foo", "bar"}, isNull: []bool{false, false}, id: mockID, name: "*0"}}}},
 		{
 			name: "single level - aligned",
 			fields: fields{
@@ -1786,6 +1824,32 @@ func TestGroupedDataFrame_Sum(t *testing.T) {
 				colLevelNames: []string{"*0"},
 				name:          "sum_qux",
 			}},
+		{
+			name: "int64 and float64 columns",
+			fields: fields{
+				orderedKeys: []string{"foo", "bar"},
+				rowIndices:  [][]int{{0, 1}, {2, 3}},
+				labels:      []*valueContainer{{slice: []string{"foo", "bar"}, isNull: []bool{false, false}, id: mockID, name: "baz"}},
+				df: &DataFrame{
+					values: []*valueContainer{
+						{slice: []int64{9007199254740992, 1, 3, 4}, isNull: []bool{false, false, false, true}, id: mockID, name: "corge"},
+						{slice: []float64{5, 6, 7, 8}, isNull: []bool{false, false, false, false}, id: mockID, name: "waldo"},
+					},
+					labels: []*valueContainer{
+						{slice: []string{"foo", "foo", "bar", "bar"}, isNull: []bool{false, false, false, false}, id: mockID, name: "baz"}},
+					colLevelNames: []string{"*0"},
+					name:          "qux"}},
+			args: args{nil},
+			want: &DataFrame{
+				values: []*valueContainer{
+					{slice: []int64{9007199254740993, 3}, isNull: []bool{false, false}, id: mockID, name: "sum_corge"},
+					{slice: []float64{11, 15}, isNull: []bool{false, false}, id: mockID, name: "sum_waldo"},
+				},
+				labels: []*valueContainer{
+					{slice: []string{"foo", "bar"}, isNull: []bool{false, false}, id: mockID, name: "baz"}},
+				colLevelNames: []string{"*0"},
+				name:          "sum_qux",
+			}},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -1846,6 +1910,30 @@ func TestGroupedDataFrame_Mean(t *testing.T) {
 				colLevelNames: []string{"*0"},
 				name:          "mean_qux",
 			}},
+		{
+			name: "decimal - exact mean",
+			fields: fields{
+				orderedKeys: []string{"foo", "bar"},
+				rowIndices:  [][]int{{0, 1}, {2, 3}},
+				labels:      []*valueContainer{{slice: []string{"foo", "bar"}, isNull: []bool{false, false}, id: mockID, name: "baz"}},
+				df: &DataFrame{
+					values: []*valueContainer{
+						{slice: []*big.Rat{mockDecimal("0.1"), mockDecimal("0.2"), mockDecimal("1"), new(big.Rat)},
+							isNull: []bool{false, false, false, true}, id: mockID, name: "corge"},
+					},
+					labels: []*valueContainer{
+						{slice: []string{"foo", "foo", "bar", "bar"}, isNull: []bool{false, false, false, false}, id: mockID, name: "baz"}},
+					colLevelNames: []string{"*0"}}},
+			args: args{nil},
+			want: &DataFrame{
+				values: []*valueContainer{
+					{slice: []*big.Rat{mockDecimal("0.15"), mockDecimal("1")}, isNull: []bool{false, false}, id: mockID, name: "mean_corge"},
+				},
+				labels: []*valueContainer{
+					{slice: []string{"foo", "bar"}, isNull: []bool{false, false}, id: mockID, name: "baz"}},
+				colLevelNames: []string{"*0"},
+				name:          "mean",
+			}},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -1906,6 +1994,29 @@ func TestGroupedDataFrame_Median(t *testing.T) {
 				colLevelNames: []string{"*0"},
 				name:          "median_qux",
 			}},
+		{
+			name: "duration column returns duration",
+			fields: fields{
+				orderedKeys: []string{"foo", "bar"},
+				rowIndices:  [][]int{{0, 1}, {2, 3}},
+				labels:      []*valueContainer{{slice: []string{"foo", "bar"}, isNull: []bool{false, false}, id: mockID, name: "baz"}},
+				df: &DataFrame{
+					values: []*valueContainer{
+						{slice: []time.Duration{time.Hour, 2 * time.Hour, 3 * time.Hour, 4 * time.Hour}, isNull: []bool{false, false, false, true}, id: mockID, name: "corge"},
+					},
+					labels: []*valueContainer{
+						{slice: []string{"foo", "foo", "bar", "bar"}, isNull: []bool{false, false, false, false}, id: mockID, name: "baz"}},
+					colLevelNames: []string{"*0"}}},
+			args: args{nil},
+			want: &DataFrame{
+				values: []*valueContainer{
+					{slice: []time.Duration{90 * time.Minute, 3 * time.Hour}, isNull: []bool{false, false}, id: mockID, name: "median_corge"},
+				},
+				labels: []*valueContainer{
+					{slice: []string{"foo", "bar"}, isNull: []bool{false, false}, id: mockID, name: "baz"}},
+				colLevelNames: []string{"*0"},
+				name:          "median",
+			}},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
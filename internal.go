@@ -1,20 +1,47 @@
 package tada
 
 import (
+	"archive/tar"
+	"archive/zip"
+	"bufio"
 	"bytes"
+	"compress/bzip2"
+	"compress/gzip"
+	"database/sql"
+	"encoding/binary"
 	"encoding/csv"
+	"encoding/gob"
+	"encoding/json"
+	"encoding/xml"
 	"fmt"
+	"html"
+	"io"
+	"io/ioutil"
 	"log"
 	"math"
+	"math/big"
 	"math/rand"
+	"os"
+	"path/filepath"
 	"reflect"
 	"regexp"
 	"sort"
 	"strconv"
 	"strings"
 	"time"
+	"unicode"
+	"unicode/utf8"
 
 	"cloud.google.com/go/civil"
+	"github.com/apache/arrow/go/arrow"
+	"github.com/apache/arrow/go/arrow/array"
+	"github.com/apache/arrow/go/arrow/decimal128"
+	"github.com/apache/arrow/go/arrow/memory"
+	dsnetbzip2 "github.com/dsnet/compress/bzip2"
+	goparquet "github.com/fraugster/parquet-go"
+	"github.com/fraugster/parquet-go/parquet"
+	"github.com/fraugster/parquet-go/parquetschema"
+	"github.com/klauspost/compress/zstd"
 )
 
 func newValueContainer(slice interface{}, isNull []bool, name string, opts ...string) *valueContainer {
@@ -544,6 +571,196 @@ func readRecords(records [][]string, byColumns bool, numHeaders int) ([]*valueCo
 	return ret, nil
 }
 
+// setBlankStringsAsNull sets every "" value in []string containers to null.
+func setBlankStringsAsNull(containers []*valueContainer) {
+	for k := range containers {
+		arr, ok := containers[k].slice.([]string)
+		if !ok {
+			continue
+		}
+		for i := range arr {
+			if arr[i] == "" {
+				containers[k].isNull[i] = true
+			}
+		}
+	}
+}
+
+// applySchema parses each []string container named in schema as its schema dtype,
+// and returns the positions of the containers that were parsed.
+// rowOffset is added to row numbers in errors.
+// loc is the location of times parsed without a time zone in columns whose schema does not set Location.
+func applySchema(containers []*valueContainer, schema map[string]ColumnSchema, rowOffset int, loc *time.Location) (map[int]bool, error) {
+	ret := make(map[int]bool, len(schema))
+	if len(schema) == 0 {
+		return ret, nil
+	}
+	for name, colSchema := range schema {
+		if _, err := indexOfContainer(name, containers); err != nil && colSchema.Required {
+			return nil, fmt.Errorf("schema: required column (%v) not found", name)
+		}
+	}
+	for k := range containers {
+		colSchema, ok := schema[containers[k].name]
+		if !ok {
+			continue
+		}
+		if colSchema.Location == nil {
+			colSchema.Location = loc
+		}
+		err := containers[k].parseStrings(colSchema, rowOffset)
+		if err != nil {
+			return nil, fmt.Errorf("schema: %v", err)
+		}
+		ret[k] = true
+	}
+	return ret, nil
+}
+
+// parseStrings converts a []string container to schema.DType, returning an error if any non-null value does not parse.
+func (vc *valueContainer) parseStrings(schema ColumnSchema, rowOffset int) error {
+	arr := vc.slice.([]string)
+	nullStrings := make(map[string]bool, len(schema.NullStrings))
+	for _, s := range schema.NullStrings {
+		nullStrings[s] = true
+	}
+	for i := range arr {
+		if nullStrings[arr[i]] {
+			vc.isNull[i] = true
+		}
+	}
+	loc := schema.Location
+	if loc == nil {
+		loc = time.UTC
+	}
+	parseTime := func(s string) (time.Time, error) {
+		if schema.TimeLayout != "" {
+			return parseTimeIn(schema.TimeLayout, s, loc)
+		}
+		if schema.Location != nil {
+			if t, null := convertStringToDateTimeIn(s, loc); !null {
+				return t, nil
+			}
+		} else if t, null := convertStringToDateTime(s); !null {
+			return t, nil
+		}
+		return time.Time{}, fmt.Errorf("does not match any time format")
+	}
+	parseErr := func(i int, err error) error {
+		return fmt.Errorf("row %d, column %s: cannot parse %q as %v: %v", rowOffset+i, vc.name, arr[i], schema.DType, err)
+	}
+	switch schema.DType {
+	case String:
+		return nil
+	case Float64:
+		ret := make([]float64, len(arr))
+		for i := range arr {
+			if vc.isNull[i] {
+				continue
+			}
+			var err error
+			ret[i], err = strconv.ParseFloat(arr[i], 64)
+			if err != nil {
+				return parseErr(i, err)
+			}
+		}
+		vc.slice = ret
+	case DateTime, Date, Time:
+		ret := make([]time.Time, len(arr))
+		for i := range arr {
+			if vc.isNull[i] {
+				continue
+			}
+			var err error
+			ret[i], err = parseTime(arr[i])
+			if err != nil {
+				return parseErr(i, err)
+			}
+		}
+		vc.slice = ret
+		if schema.DType == Date {
+			vc.cast(Date)
+		} else if schema.DType == Time {
+			vc.cast(Time)
+		}
+	case Int64:
+		ret := make([]int64, len(arr))
+		for i := range arr {
+			if vc.isNull[i] {
+				continue
+			}
+			var err error
+			ret[i], err = strconv.ParseInt(arr[i], 10, 64)
+			if err != nil {
+				return parseErr(i, err)
+			}
+		}
+		vc.slice = ret
+	case Categorical:
+		vc.cast(Categorical)
+	case Duration:
+		ret := make([]time.Duration, len(arr))
+		for i := range arr {
+			if vc.isNull[i] {
+				continue
+			}
+			var null bool
+			ret[i], null = convertStringToDuration(arr[i], false)
+			if null {
+				return parseErr(i, fmt.Errorf("invalid duration"))
+			}
+		}
+		vc.slice = ret
+	case Decimal:
+		ret := make([]*big.Rat, len(arr))
+		for i := range arr {
+			ret[i] = new(big.Rat)
+			if vc.isNull[i] {
+				continue
+			}
+			_, ok := ret[i].SetString(arr[i])
+			if !ok {
+				return parseErr(i, fmt.Errorf("invalid decimal"))
+			}
+		}
+		vc.slice = ret
+	case Bool:
+		ret := make([]bool, len(arr))
+		for i := range arr {
+			if vc.isNull[i] {
+				continue
+			}
+			var err error
+			ret[i], err = strconv.ParseBool(arr[i])
+			if err != nil {
+				return parseErr(i, err)
+			}
+		}
+		vc.slice = ret
+	default:
+		return fmt.Errorf("column %s: unsupported dtype (%v)", vc.name, schema.DType)
+	}
+	return nil
+}
+
+// castStrings casts vc to dtype as in castIn, returning an error if any non-null, non-blank []string value does not parse.
+// Blank values become null. rowOffset is added to row numbers in errors.
+func (vc *valueContainer) castStrings(dtype DType, loc *time.Location, rowOffset int) error {
+	arr, ok := vc.slice.([]string)
+	if !ok {
+		vc.castIn(dtype, loc)
+		return nil
+	}
+	wasNull := copyNulls(vc.isNull)
+	vc.castIn(dtype, loc)
+	for i := range arr {
+		if !wasNull[i] && vc.isNull[i] && arr[i] != "" {
+			return fmt.Errorf("row %d, column %s: cannot parse %q as %v", rowOffset+i, vc.name, arr[i], dtype)
+		}
+	}
+	return nil
+}
+
 // major dimension: columns
 func popNRecords(records [][]string, n int) [][]string {
 	ret := make([][]string, len(records))
@@ -639,7 +856,237 @@ func setColLevelNames(numHeaderRows int, columns []*valueContainer) ([]string, [
 	return ret, columns
 }
 
-func writeStructSlice(containers []*valueContainer, slice interface{}, noUnmatchedCols bool) ([][]bool, error) {
+// structField describes how an exported struct field (or field nested within an exported struct field) maps to a container.
+type structField struct {
+	index      []int
+	fieldName  string
+	name       string
+	isLabel    bool
+	timeLayout string
+}
+
+// structFields returns the exported struct fields that map to containers, in field order.
+// A field is named by its "tada" tag, then its "json" tag, then its field name, and skipped if either tag is "-".
+// The "tada" tag may be followed by the options "label" and "layout=" (which must be last, because layouts may contain commas).
+//
+// Struct fields (other than time.Time, civil.Date, civil.Time, and sql.Null* types) are flattened:
+// their fields are named by the outer and inner names joined by the level separator (e.g., Outer|Inner),
+// except for the fields of untagged embedded structs, which are promoted without a prefix.
+func structFields(protoStruct reflect.Type) ([]structField, error) {
+	return appendStructFields(nil, protoStruct, nil, nil, false)
+}
+
+func appendStructFields(ret []structField, protoStruct reflect.Type, index []int, prefix []string, isLabel bool) ([]structField, error) {
+	for k := 0; k < protoStruct.NumField(); k++ {
+		field := protoStruct.Field(k)
+		if field.PkgPath != "" && !(field.Anonymous && field.Type.Kind() == reflect.Struct) {
+			// exclude unexported fields from column count, but promote the exported fields of unexported embedded structs
+			continue
+		}
+		sf := structField{
+			index:     append(append([]int{}, index...), k),
+			fieldName: field.Name,
+			name:      field.Name,
+			isLabel:   isLabel,
+		}
+		var tagged bool
+		if js, ok := field.Tag.Lookup("json"); ok {
+			js = strings.Split(js, ",")[0]
+			if js == "-" {
+				continue
+			}
+			if js != "" {
+				sf.name = js
+				tagged = true
+			}
+		}
+		if tag, ok := field.Tag.Lookup("tada"); ok {
+			if tag == "-" {
+				continue
+			}
+			options := strings.Split(tag, ",")
+			if options[0] != "" {
+				sf.name = options[0]
+				tagged = true
+			}
+			for i := 1; i < len(options); i++ {
+				switch {
+				case options[i] == "label":
+					sf.isLabel = true
+				case strings.HasPrefix(options[i], "layout="):
+					sf.timeLayout = strings.TrimPrefix(strings.Join(options[i:], ","), "layout=")
+					i = len(options)
+				default:
+					return nil, fmt.Errorf("field %s: unsupported tada tag option (%s)", field.Name, options[i])
+				}
+			}
+		}
+		if isNestedStruct(field.Type) {
+			levels := prefix
+			if !field.Anonymous || tagged {
+				levels = append(append([]string{}, prefix...), sf.name)
+			}
+			var err error
+			ret, err = appendStructFields(ret, field.Type, sf.index, levels, sf.isLabel)
+			if err != nil {
+				return nil, err
+			}
+			continue
+		}
+		if field.PkgPath != "" {
+			continue
+		}
+		sf.name = joinLevelsIntoName(append(append([]string{}, prefix...), sf.name))
+		ret = append(ret, sf)
+	}
+	return ret, nil
+}
+
+// isNestedStruct returns true if t is a struct whose fields should be flattened into separate containers.
+func isNestedStruct(t reflect.Type) bool {
+	if t.Kind() != reflect.Struct {
+		return false
+	}
+	if _, ok := sqlNullTypes[t]; ok {
+		return false
+	}
+	switch t {
+	case timeType, reflect.TypeOf(civil.Date{}), reflect.TypeOf(civil.Time{}):
+		return false
+	}
+	return true
+}
+
+// sqlNullTypes maps each supported sql.Null* type to the type of its value.
+var sqlNullTypes = map[reflect.Type]reflect.Type{
+	sqlNullFloat64Type: reflect.TypeOf(float64(0)),
+	sqlNullInt64Type:   reflect.TypeOf(int64(0)),
+	sqlNullInt32Type:   reflect.TypeOf(int32(0)),
+	sqlNullBoolType:    reflect.TypeOf(false),
+	sqlNullTimeType:    timeType,
+	sqlNullStringType:  reflect.TypeOf(""),
+}
+
+// nullableElem returns the type of the value held by a pointer or sql.Null* type,
+// or t itself if t is not nullable.
+func nullableElem(t reflect.Type) (reflect.Type, bool) {
+	if t.Kind() == reflect.Ptr {
+		return t.Elem(), true
+	}
+	if elem, ok := sqlNullTypes[t]; ok {
+		return elem, true
+	}
+	return t, false
+}
+
+// readNullable returns the value held by v (a pointer or sql.Null* type), and whether v is nil or not valid.
+func readNullable(v reflect.Value) (reflect.Value, bool) {
+	if v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return reflect.Zero(v.Type().Elem()), true
+		}
+		return v.Elem(), false
+	}
+	return v.Field(0), !v.FieldByName("Valid").Bool()
+}
+
+// writeNullable sets v (a pointer or sql.Null* type) to hold val.
+func writeNullable(v reflect.Value, val reflect.Value) {
+	if v.Kind() == reflect.Ptr {
+		ptr := reflect.New(v.Type().Elem())
+		ptr.Elem().Set(val)
+		v.Set(ptr)
+		return
+	}
+	v.Field(0).Set(val)
+	v.FieldByName("Valid").SetBool(true)
+}
+
+func isNumericKind(kind reflect.Kind) bool {
+	switch kind {
+	case reflect.Float32, reflect.Float64,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return true
+	}
+	return false
+}
+
+// convertStructValue converts src to type to. Numeric types are converted if no precision is lost,
+// strings are parsed as numbers or as times (using timeLayout, if not empty), and any value may be converted to a string
+// (with times formatted using timeLayout, or RFC3339 by default).
+func convertStructValue(src reflect.Value, to reflect.Type, timeLayout string) (reflect.Value, error) {
+	if src.Kind() == reflect.Interface {
+		if src.IsNil() {
+			return reflect.Zero(to), nil
+		}
+		src = src.Elem()
+	}
+	if src.Type() == timeType && to.Kind() == reflect.String {
+		if timeLayout == "" {
+			timeLayout = time.RFC3339
+		}
+		return reflect.ValueOf(src.Interface().(time.Time).Format(timeLayout)).Convert(to), nil
+	}
+	if src.Type().AssignableTo(to) {
+		return src, nil
+	}
+	if src.Kind() == reflect.String && isNumericKind(to.Kind()) {
+		f, err := strconv.ParseFloat(src.String(), 64)
+		if err != nil {
+			return reflect.Value{}, fmt.Errorf("cannot parse %q as %v", src.String(), to)
+		}
+		src = reflect.ValueOf(f)
+	}
+	switch {
+	case isNumericKind(src.Kind()) && isNumericKind(to.Kind()):
+		ret := src.Convert(to)
+		if ret.Convert(src.Type()).Interface() != src.Interface() {
+			return reflect.Value{}, fmt.Errorf("cannot convert %v to %v without losing precision", src.Interface(), to)
+		}
+		return ret, nil
+	case src.Kind() == reflect.String && to == timeType:
+		if timeLayout != "" {
+			t, err := time.Parse(timeLayout, src.String())
+			if err != nil {
+				return reflect.Value{}, fmt.Errorf("cannot parse %q as time: %v", src.String(), err)
+			}
+			return reflect.ValueOf(t), nil
+		}
+		t, isNull := convertStringToDateTime(src.String())
+		if isNull {
+			return reflect.Value{}, fmt.Errorf("cannot parse %q as time", src.String())
+		}
+		return reflect.ValueOf(t), nil
+	case to.Kind() == reflect.String:
+		return reflect.ValueOf(fmt.Sprint(src.Interface())).Convert(to), nil
+	}
+	return reflect.Value{}, fmt.Errorf("cannot convert %v to %v", src.Type(), to)
+}
+
+// indexOfStructField returns the position of the container named name.
+// Empty trailing levels in container names (e.g., from padded multi-level column names) are ignored.
+func indexOfStructField(name string, containers []*valueContainer) (int, error) {
+	k, err := indexOfContainer(name, containers)
+	if err == nil {
+		return k, nil
+	}
+	for k := range containers {
+		levels := splitNameIntoLevels(containers[k].name)
+		for len(levels) > 1 && levels[len(levels)-1] == "" {
+			levels = levels[:len(levels)-1]
+		}
+		if joinLevelsIntoName(levels) == name {
+			return k, nil
+		}
+	}
+	return 0, err
+}
+
+// writeStructSlice writes containers into slice, which must be a pointer to a slice of structs.
+// Fields tagged as labels are matched only to labels; all other fields are matched to containers.
+// Returns the null status of every matched field (by row, then exported field).
+func writeStructSlice(labels []*valueContainer, containers []*valueContainer, slice interface{}, noUnmatchedCols bool) ([][]bool, error) {
 	if reflect.TypeOf(slice).Kind() != reflect.Ptr ||
 		reflect.TypeOf(slice).Elem().Kind() != reflect.Slice ||
 		reflect.TypeOf(slice).Elem().Elem().Kind() != reflect.Struct {
@@ -651,72 +1098,67 @@ func writeStructSlice(containers []*valueContainer, slice interface{}, noUnmatch
 	t := reflect.TypeOf(slice)
 	protoStruct := t.Elem().Elem()
 
-	exportedFields := make([]int, 0, 7)
-	for k := 0; k < protoStruct.NumField(); k++ {
-		if protoStruct.Field(k).PkgPath == "" { // exclude unexported fields from column count
-			exportedFields = append(exportedFields, k)
-		}
+	fields, err := structFields(protoStruct)
+	if err != nil {
+		return nil, fmt.Errorf("writing to slice of structs: %v", err)
 	}
-	// m: fields exported by the struct and the DataFrame {structFieldIndex: containerIndex}
-	m := make(map[int]int, len(exportedFields))
-	for _, f := range exportedFields {
-		var name string
-		if js := protoStruct.Field(f).Tag.Get("json"); js != "" {
-			name = js
-		} else {
-			name = protoStruct.Field(f).Name
+	// matches: container matching each field, or nil if none
+	matches := make([]*valueContainer, len(fields))
+	var matchedContainers int
+	for j, field := range fields {
+		candidates := containers
+		if field.isLabel {
+			candidates = labels
 		}
-		k, err := indexOfContainer(name, containers)
+		k, err := indexOfStructField(field.name, candidates)
 		if err == nil {
-			m[f] = k
-		}
-	}
-
-	// check for type match
-	for key, value := range m {
-		if reflect.TypeOf(containers[value].slice).Elem() != protoStruct.Field(key).Type {
-			return nil, fmt.Errorf("writing to slice of structs: container[%d] (%s) must be same type as matching field (%v != %v)",
-				value, nameOfContainer(containers, value), reflect.TypeOf(containers[value].slice).Elem(), protoStruct.Field(key).Type)
+			matches[j] = candidates[k]
+			if !field.isLabel {
+				matchedContainers++
+			}
 		}
 	}
 
 	if noUnmatchedCols {
-		if len(containers) > len(m) {
+		if len(containers) > matchedContainers {
 			return nil, fmt.Errorf("writing to slice of structs: DataFrame has unmatched containers")
 		}
 	}
 	numRows := containers[0].len()
 
-	// copy values from containers into slice of struct
-	v := reflect.ValueOf(slice)
-	v.Elem().Set(reflect.MakeSlice(reflect.SliceOf(protoStruct), numRows, numRows))
-	for i := 0; i < numRows; i++ {
-		s := reflect.New(protoStruct)
-		for key, value := range m {
-			dst := s.Elem().Field(key)
-			dst.Set(reflect.ValueOf(containers[value].slice).Index(i))
-		}
-		v.Elem().Index(i).Set(s.Elem())
-	}
-	// set nulls
-	nulls := make([][]bool, len(exportedFields))
-	fieldOrder := make([]int, 0, 7)
-	for k := range m {
-		fieldOrder = append(fieldOrder, k)
-	}
-	sort.Ints(fieldOrder) // sort so that null columns align with field ordering
-
-	var counter int
-	for incrementor, f := range exportedFields {
-		if counter < len(fieldOrder) && f == fieldOrder[counter] {
-			k := m[f]
-			nulls[incrementor] = containers[k].isNull
-			counter++
-		} else {
+	// copy values from containers into slice of struct, converting to the field type if necessary
+	ret := reflect.MakeSlice(reflect.SliceOf(protoStruct), numRows, numRows)
+	nulls := make([][]bool, len(fields))
+	for j, field := range fields {
+		nulls[j] = make([]bool, numRows)
+		if matches[j] == nil {
 			// if field is not exported by DataFrame, set all nulls to false
-			nulls[incrementor] = make([]bool, numRows)
+			continue
+		}
+		copy(nulls[j], matches[j].isNull)
+		fieldType, nullable := nullableElem(protoStruct.FieldByIndex(field.index).Type)
+		src := reflect.ValueOf(matches[j].slice)
+		for i := 0; i < numRows; i++ {
+			if nulls[j][i] && nullable {
+				continue
+			}
+			val, err := convertStructValue(src.Index(i), fieldType, field.timeLayout)
+			if err != nil {
+				if nulls[j][i] {
+					continue
+				}
+				return nil, fmt.Errorf("writing to slice of structs: row %d: field %s: %v",
+					i, field.fieldName, err)
+			}
+			dst := ret.Index(i).FieldByIndex(field.index)
+			if nullable {
+				writeNullable(dst, val)
+			} else {
+				dst.Set(val)
+			}
 		}
 	}
+	reflect.ValueOf(slice).Elem().Set(ret)
 
 	// transpose nulls
 	nulls, _ = transposeNestedNulls(nulls) // ducks error because constructing nulls is controlled
@@ -727,47 +1169,57 @@ func writeStructSlice(containers []*valueContainer, slice interface{}, noUnmatch
 
 // each struct becomes a different row
 // each field becomes a different column
-func readStructSlice(slice interface{}, isNull [][]bool) ([]*valueContainer, error) {
+// fields tagged as labels are returned first, followed by the other fields. Also returns the number of label fields.
+func readStructSlice(slice interface{}, isNull [][]bool) ([]*valueContainer, int, error) {
 	if !isSlice(slice) || reflect.TypeOf(slice).Elem().Kind() != reflect.Struct {
-		return nil, fmt.Errorf("unsupported input type (%v), must be []struct", reflect.TypeOf(slice))
+		return nil, 0, fmt.Errorf("unsupported input type (%v), must be []struct", reflect.TypeOf(slice))
 	}
 	v := reflect.ValueOf(slice)
 	if v.Len() == 0 {
-		return nil, fmt.Errorf("slice must contain at least one struct")
+		return nil, 0, fmt.Errorf("slice must contain at least one struct")
 	}
 	protoStruct := reflect.TypeOf(slice).Elem()
-	exportedFields := make([]int, 0, 7)
-	for k := 0; k < protoStruct.NumField(); k++ {
-		if protoStruct.Field(k).PkgPath == "" { // exclude unexported fields from column count
-			exportedFields = append(exportedFields, k)
-		}
+	fields, err := structFields(protoStruct)
+	if err != nil {
+		return nil, 0, err
 	}
-	if len(exportedFields) == 0 {
-		return nil, fmt.Errorf("struct must contain at least one exported field")
+	if len(fields) == 0 {
+		return nil, 0, fmt.Errorf("struct must contain at least one exported field")
 	}
-	retValues := make([]interface{}, len(exportedFields))
-	retNames := make([]string, len(exportedFields))
-	for incrementor, k := range exportedFields {
-		field := protoStruct.Field(k)
-		colType := field.Type
-		colValues := reflect.MakeSlice(reflect.SliceOf(colType), v.Len(), v.Len())
-		// setting container name
-		var name string
-		if js := field.Tag.Get("json"); js != "" {
-			name = js
-		} else {
-			name = field.Name
+	retValues := make([]interface{}, len(fields))
+	explicitNulls := make([][]bool, len(fields))
+	for j, field := range fields {
+		fieldType, nullable := nullableElem(protoStruct.FieldByIndex(field.index).Type)
+		parseTime := field.timeLayout != "" && fieldType.Kind() == reflect.String
+		colType := fieldType
+		if parseTime {
+			colType = timeType
 		}
-		retNames[incrementor] = name
+		colValues := reflect.MakeSlice(reflect.SliceOf(colType), v.Len(), v.Len())
+		explicitNulls[j] = make([]bool, v.Len())
 		for i := 0; i < v.Len(); i++ {
-			dst := colValues.Index(i)
-			src := v.Index(i).Field(k)
-			dst.Set(src)
+			src := v.Index(i).FieldByIndex(field.index)
+			if nullable {
+				src, explicitNulls[j][i] = readNullable(src)
+			}
+			if parseTime {
+				if src.String() == "" {
+					explicitNulls[j][i] = true
+					continue
+				}
+				t, err := time.Parse(field.timeLayout, src.String())
+				if err != nil {
+					return nil, 0, fmt.Errorf("row %d: field %s: cannot parse %q as time: %v",
+						i, field.fieldName, src.String(), err)
+				}
+				src = reflect.ValueOf(t)
+			}
+			colValues.Index(i).Set(src)
 		}
-		retValues[incrementor] = colValues.Interface()
+		retValues[j] = colValues.Interface()
 	}
 	// transfer to final container
-	ret := make([]*valueContainer, len(exportedFields))
+	ret := make([]*valueContainer, len(fields))
 
 	// set null values
 	if isNull == nil {
@@ -777,21 +1229,101 @@ func readStructSlice(slice interface{}, isNull [][]bool) ([]*valueContainer, err
 		}
 	} else {
 		if len(ret) != len(isNull[0]) {
-			return nil, fmt.Errorf("setting null values: number of columns in [][]bool (%d) does not match number of exported fields (%d)",
+			return nil, 0, fmt.Errorf("setting null values: number of columns in [][]bool (%d) does not match number of exported fields (%d)",
 				len(ret), len(isNull[0]))
 		}
 		if v.Len() != len(isNull) {
-			return nil, fmt.Errorf("setting null values: number of rows in [][]bool (%d) does not match number of structs in slice (%d)",
+			return nil, 0, fmt.Errorf("setting null values: number of rows in [][]bool (%d) does not match number of structs in slice (%d)",
 				v.Len(), len(isNull))
 		}
 		var err error
 		isNull, err = transposeNestedNulls(isNull)
 		if err != nil {
-			return nil, fmt.Errorf("reading slice of structs: setting null values: %v", err)
+			return nil, 0, fmt.Errorf("reading slice of structs: setting null values: %v", err)
+		}
+	}
+	for k := range ret {
+		for i := range isNull[k] {
+			isNull[k][i] = isNull[k][i] || explicitNulls[k][i]
 		}
+		ret[k] = newValueContainer(retValues[k], isNull[k], fields[k].name)
 	}
+	// move labels to the front
+	var labels, columns []*valueContainer
 	for k := range ret {
-		ret[k] = newValueContainer(retValues[k], isNull[k], retNames[k])
+		if fields[k].isLabel {
+			labels = append(labels, ret[k])
+		} else {
+			columns = append(columns, ret[k])
+		}
+	}
+	return append(labels, columns...), len(labels), nil
+}
+
+// flattenMap calls fn with each value nested within m, named by prefix and its nested keys joined by the level separator.
+func flattenMap(m map[string]interface{}, prefix []string, fn func(name string, value interface{})) {
+	for key, value := range m {
+		levels := append(append([]string{}, prefix...), key)
+		if nested, ok := value.(map[string]interface{}); ok {
+			flattenMap(nested, levels, fn)
+			continue
+		}
+		fn(joinLevelsIntoName(levels), value)
+	}
+}
+
+// readMapSlice reads one row per map and returns one container per flattened key, sorted by name.
+// Each container has the type shared by all its non-nil values, or []interface{} if the types differ.
+func readMapSlice(maps []map[string]interface{}) ([]*valueContainer, error) {
+	if len(maps) == 0 {
+		return nil, fmt.Errorf("slice must contain at least one map")
+	}
+	rows := make([]map[string]interface{}, len(maps))
+	types := make(map[string]reflect.Type)
+	for i := range maps {
+		rows[i] = make(map[string]interface{})
+		flattenMap(maps[i], nil, func(name string, value interface{}) {
+			rows[i][name] = value
+			t, ok := types[name]
+			if !ok || t == nil {
+				types[name] = reflect.TypeOf(value)
+			} else if value != nil && reflect.TypeOf(value) != t {
+				types[name] = reflect.TypeOf([]interface{}{}).Elem()
+			}
+		})
+	}
+	if len(types) == 0 {
+		return nil, fmt.Errorf("maps must contain at least one key")
+	}
+	names := make([]string, 0, len(types))
+	for name := range types {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	ret := make([]*valueContainer, len(names))
+	for k, name := range names {
+		t := types[name]
+		if t == nil {
+			t = reflect.TypeOf([]interface{}{}).Elem()
+		}
+		slice := reflect.MakeSlice(reflect.SliceOf(t), len(rows), len(rows))
+		missing := make([]bool, len(rows))
+		for i := range rows {
+			value, ok := rows[i][name]
+			if !ok || value == nil {
+				missing[i] = true
+				continue
+			}
+			slice.Index(i).Set(reflect.ValueOf(value))
+		}
+		isNull, err := setNullsFromInterface(slice.Interface())
+		if err != nil {
+			return nil, fmt.Errorf("key %s: %v", name, err)
+		}
+		for i := range isNull {
+			isNull[i] = isNull[i] || missing[i]
+		}
+		ret[k] = newValueContainer(slice.Interface(), isNull, name)
 	}
 	return ret, nil
 }
@@ -842,6 +1374,20 @@ func transposeNestedNulls(isNull [][]bool) ([][]bool, error) {
 }
 
 func inferType(input string) DType {
+	return inferTypeIn(input, nil)
+}
+
+// inferTypeIn infers the type of input as in inferType.
+// If loc is not nil, values that match naiveDateTimeFormats are also inferred as DateTime.
+func inferTypeIn(input string, loc *time.Location) DType {
+	if optionInferIntAndBool {
+		if _, err := strconv.ParseInt(input, 10, 64); err == nil {
+			return Int64
+		}
+		if input == "true" || input == "false" || input == "TRUE" || input == "FALSE" || input == "True" || input == "False" {
+			return Bool
+		}
+	}
 	if _, err := strconv.ParseFloat(input, 64); err == nil {
 		return Float64
 	}
@@ -854,20 +1400,33 @@ func inferType(input string) DType {
 		}
 		return DateTime
 	}
+	if loc != nil {
+		for _, format := range naiveDateTimeFormats {
+			if _, err := time.Parse(format, input); err == nil {
+				return DateTime
+			}
+		}
+	}
 	return String
 }
 
-// cast valueContainers in place
-func castToInferredTypes(containers []*valueContainer) {
+// cast valueContainers in place. DateTime values without a time zone are parsed in loc (default: UTC).
+func castToInferredTypes(containers []*valueContainer, loc *time.Location) {
 	for k := range containers {
-		dtype := containers[k].inferType()
-		containers[k].cast(dtype)
+		dtype := containers[k].inferTypeIn(loc)
+		containers[k].castIn(dtype, loc)
 	}
 	return
 }
 
 // expects vc.slice to be []string
 func (vc *valueContainer) inferType() DType {
+	return vc.inferTypeIn(nil)
+}
+
+// inferTypeIn infers the type of vc as in inferType, but recognizes date times without a time zone if loc is not nil.
+// expects vc.slice to be []string
+func (vc *valueContainer) inferTypeIn(loc *time.Location) DType {
 	s := vc.slice.([]string)
 	sampleSize := 10
 	if len(s) < sampleSize {
@@ -876,9 +1435,14 @@ func (vc *valueContainer) inferType() DType {
 	inferredTypes := make(map[DType]int)
 	sample := s[:sampleSize]
 	for i := range sample {
-		dtype := inferType(sample[i])
+		dtype := inferTypeIn(sample[i], loc)
 		inferredTypes[dtype]++
 	}
+	// integers are a subset of floats, so a mix of integers and floats is inferred as floats
+	if inferredTypes[Int64] > 0 && inferredTypes[Float64] > 0 {
+		inferredTypes[Float64] += inferredTypes[Int64]
+		delete(inferredTypes, Int64)
+	}
 	var highestCount int
 	var dtype DType
 	for key, v := range inferredTypes {
@@ -888,6 +1452,14 @@ func (vc *valueContainer) inferType() DType {
 			highestCount = v
 		}
 	}
+	// a float after the sample would become null if cast to an integer
+	if dtype == Int64 {
+		for i := sampleSize; i < len(s); i++ {
+			if inferTypeIn(s[i], loc) == Float64 {
+				return Float64
+			}
+		}
+	}
 	return dtype
 }
 
@@ -922,6 +1494,14 @@ func mockString(dtype DType, nullPct float64) (string, bool) {
 		options = []string{"2019-12-31", "2020-01-01", "2020-01-02", "2020-02-01", "2020-02-02"}
 	case Time:
 		options = []string{"10:00am", "11:00am", "1:00pm", "2:00pm", "3:30pm"}
+	case Int64:
+		options = []string{"1", "2", "3", "4", "5"}
+	case Bool:
+		options = []string{"true", "false"}
+	case Decimal:
+		options = []string{"0.01", "1.10", "2.50", "10.99", "100.00"}
+	case Duration:
+		options = []string{"30s", "5m0s", "1h0m0s", "1h30m0s", "24h0m0s"}
 	}
 	rand.Seed(clock.now().UnixNano())
 	f := rand.Float64()
@@ -1254,7 +1834,8 @@ func (vc *valueContainer) len() int {
 	return reflect.ValueOf(vc.slice).Len()
 }
 
-func (vc *valueContainer) sort(dtype DType, ascending bool, index []int) []int {
+// if dtype is Categorical, values are sorted in categoryOrder, then in ascending order.
+func (vc *valueContainer) sort(dtype DType, categoryOrder []string, ascending bool, index []int) []int {
 	var srt sort.Interface
 	nulls := make([]int, vc.len())
 	notNulls := make([]int, vc.len())
@@ -1293,23 +1874,89 @@ func (vc *valueContainer) sort(dtype DType, ascending bool, index []int) []int {
 		sort.Stable(srt)
 		sortedIsNull = d.isNull
 		sortedIndex = d.index
-	}
-	// iterate over each sorted row and check whether it is null or not
-	var nullCounter, validCounter int
-	for i := range sortedIsNull {
-		if sortedIsNull[i] {
-			nulls[nullCounter] = sortedIndex[i]
-			nullCounter++
-		} else {
-			notNulls[validCounter] = sortedIndex[i]
-			validCounter++
+
+	case Int64:
+		d := vc.int64()
+		d.index = index
+		srt = d
+		if !ascending {
+			srt = sort.Reverse(srt)
 		}
-	}
-	// move all null values to the bottom
-	return append(notNulls[:validCounter], nulls[:nullCounter]...)
-}
+		sort.Stable(srt)
+		sortedIsNull = d.isNull
+		sortedIndex = d.index
 
-func sortContainers(containers []*valueContainer, sorters []Sorter) ([]int, error) {
+	case Duration:
+		d := vc.duration()
+		d.index = index
+		srt = d
+		if !ascending {
+			srt = sort.Reverse(srt)
+		}
+		sort.Stable(srt)
+		sortedIsNull = d.isNull
+		sortedIndex = d.index
+
+	case Decimal:
+		d := vc.decimal()
+		d.index = index
+		srt = d
+		if !ascending {
+			srt = sort.Reverse(srt)
+		}
+		sort.Stable(srt)
+		sortedIsNull = d.isNull
+		sortedIndex = d.index
+
+	case Bool:
+		d := vc.bool()
+		d.index = index
+		srt = d
+		if !ascending {
+			srt = sort.Reverse(srt)
+		}
+		sort.Stable(srt)
+		sortedIsNull = d.isNull
+		sortedIndex = d.index
+
+	case Categorical:
+		d := categoryValueContainer{slice: vc.string().slice, isNull: vc.isNull, index: index}
+		ranks := make(map[string]int, len(categoryOrder))
+		for i := range categoryOrder {
+			ranks[categoryOrder[i]] = i
+		}
+		d.rank = make([]int, len(d.slice))
+		for i := range d.slice {
+			rank, ok := ranks[d.slice[i]]
+			if !ok {
+				rank = len(categoryOrder)
+			}
+			d.rank[i] = rank
+		}
+		srt = d
+		if !ascending {
+			srt = sort.Reverse(srt)
+		}
+		sort.Stable(srt)
+		sortedIsNull = d.isNull
+		sortedIndex = d.index
+	}
+	// iterate over each sorted row and check whether it is null or not
+	var nullCounter, validCounter int
+	for i := range sortedIsNull {
+		if sortedIsNull[i] {
+			nulls[nullCounter] = sortedIndex[i]
+			nullCounter++
+		} else {
+			notNulls[validCounter] = sortedIndex[i]
+			validCounter++
+		}
+	}
+	// move all null values to the bottom
+	return append(notNulls[:validCounter], nulls[:nullCounter]...)
+}
+
+func sortContainers(containers []*valueContainer, sorters []Sorter) ([]int, error) {
 	// initialize original index
 	length := reflect.ValueOf(containers[0].slice).Len()
 	originalIndex := makeIntRange(0, length)
@@ -1323,7 +1970,7 @@ func sortContainers(containers []*valueContainer, sorters []Sorter) ([]int, erro
 		vals.subsetRows(originalIndex)
 		ascending := !sorters[i].Descending
 		// pass in prior originalIndex to create new originalIndex
-		originalIndex = vals.sort(sorters[i].DType, ascending, originalIndex)
+		originalIndex = vals.sort(sorters[i].DType, sorters[i].CategoryOrder, ascending, originalIndex)
 	}
 	// rearranging the original data by referencing these original row positions (in sequential order) will sort the series
 	return originalIndex, nil
@@ -1345,15 +1992,22 @@ func indexOfContainers(names []string, containers []*valueContainer) ([]int, err
 
 // concatenateLabelsToStringsBytes reduces all container rows to a single slice of concatenated strings, one per row
 func concatenateLabelsToStringsBytes(labels []*valueContainer) []string {
+	keys := make([][]string, len(labels))
 	for j := range labels {
+		// categorical values are read directly from the dictionary instead of being cached
+		if arr, ok := labels[j].slice.([]Category); ok {
+			keys[j] = categoryStrings(arr)
+			continue
+		}
 		labels[j].setCache()
+		keys[j] = labels[j].cache
 	}
 	// is only label?
 	if len(labels) == 1 {
 		numRows := labels[0].len()
 		ret := make([]string, numRows)
 		for i := 0; i < numRows; i++ {
-			ret[i] = labels[0].cache[i]
+			ret[i] = keys[0][i]
 		}
 		return ret
 	}
@@ -1364,7 +2018,7 @@ func concatenateLabelsToStringsBytes(labels []*valueContainer) []string {
 	for i := 0; i < numRows; i++ {
 		b.Reset()
 		for j := range labels {
-			b.WriteString(labels[j].cache[i])
+			b.WriteString(keys[j][i])
 			if j != len(labels)-1 {
 				b.WriteString(optionLevelSeparator)
 			}
@@ -1383,6 +2037,11 @@ func reduceContainers(containers []*valueContainer) (
 	newContainers []*valueContainer,
 	originalRowIndices [][]int,
 	orderedKeys []string) {
+	if len(containers) == 1 {
+		if newContainer, rowIndices, keys, ok := reduceCategories(containers[0]); ok {
+			return []*valueContainer{newContainer}, rowIndices, keys
+		}
+	}
 	// coerce all label levels to string for use as map keys
 	stringifiedLabels := concatenateLabelsToStringsBytes(containers)
 	// create receiver for unique labels of same type as original levels
@@ -1430,6 +2089,61 @@ func reduceContainers(containers []*valueContainer) (
 	return
 }
 
+// reduceCategories reduces a single []Category container as in reduceContainers, but groups rows by dictionary code,
+// so that only the unique values are stringified. Returns false if vc is not categorical or its values have more than one dictionary.
+func reduceCategories(vc *valueContainer) (
+	newContainer *valueContainer,
+	originalRowIndices [][]int,
+	orderedKeys []string,
+	ok bool) {
+	arr, ok := vc.slice.([]Category)
+	if !ok {
+		return nil, nil, nil, false
+	}
+	var dict *categoryDictionary
+	for i := range arr {
+		if arr[i].code < 0 {
+			continue
+		}
+		if arr[i].dict == nil || (dict != nil && arr[i].dict != dict) {
+			return nil, nil, nil, false
+		}
+		dict = arr[i].dict
+	}
+	// the group position of each code, with null values (code -1) in the last slot
+	var numCodes int
+	if dict != nil {
+		numCodes = len(dict.values)
+	}
+	groups := make([]int, numCodes+1)
+	for k := range groups {
+		groups[k] = -1
+	}
+	nullSlot := numCodes
+	if code, ok := dict.codeOf(""); ok {
+		// null values share a group with "", as they would if stringified
+		nullSlot = int(code)
+	}
+	newSlice := make([]Category, 0)
+	newIsNull := make([]bool, 0)
+	orderedKeys = make([]string, 0)
+	for i := range arr {
+		slot := int(arr[i].code)
+		if slot < 0 {
+			slot = nullSlot
+		}
+		if groups[slot] == -1 {
+			groups[slot] = len(orderedKeys)
+			orderedKeys = append(orderedKeys, arr[i].String())
+			originalRowIndices = append(originalRowIndices, nil)
+			newSlice = append(newSlice, arr[i])
+			newIsNull = append(newIsNull, vc.isNull[i])
+		}
+		originalRowIndices[groups[slot]] = append(originalRowIndices[groups[slot]], i)
+	}
+	return newValueContainer(newSlice, newIsNull, vc.name, vc.id), originalRowIndices, orderedKeys, true
+}
+
 // returns 1) new grouped labels as []*valueContainer, and
 // 2) a map[int]int that maps each original row index to its row index in the new containers
 func reduceContainersForPromote(containers []*valueContainer) (
@@ -1557,6 +2271,489 @@ func (s *Series) combineMath(other *Series, ignoreNulls bool, fn func(v1 float64
 		labels: copyContainers(s.labels)}
 }
 
+// comparisonDType returns the type to which vc1 and vc2 are coerced before their values are compared:
+// DateTime if either has time values, Duration if either has time.Duration values, Decimal if either has *big.Rat values,
+// Int64 if both have integer values, Float64 if either has numeric or bool values, and String otherwise.
+func comparisonDType(vc1, vc2 *valueContainer) DType {
+	switch {
+	case vc1.isTime() || vc2.isTime():
+		return DateTime
+	case vc1.isDuration() || vc2.isDuration():
+		return Duration
+	case vc1.isDecimal() || vc2.isDecimal():
+		return Decimal
+	case vc1.isInteger() && vc2.isInteger():
+		return Int64
+	case vc1.isNumeric() || vc2.isNumeric():
+		return Float64
+	}
+	return String
+}
+
+// compare coerces vc and other to the same type (see comparisonDType) and compares the values in each row,
+// returning -1 if the value in vc is less than the value in other, 0 if they are equal, and +1 if it is greater.
+// A row is null if either value is null or cannot be coerced.
+// expects vc and other to have the same length
+func (vc *valueContainer) compare(other *valueContainer) ([]int, []bool) {
+	// copy only the nulls, which are modified by coercion
+	vc1 := &valueContainer{slice: vc.slice, isNull: copyNulls(vc.isNull)}
+	vc2 := &valueContainer{slice: other.slice, isNull: copyNulls(other.isNull)}
+	ret := make([]int, vc.len())
+	switch comparisonDType(vc1, vc2) {
+	case DateTime:
+		vals1, vals2 := vc1.dateTime().slice, vc2.dateTime().slice
+		for i := range ret {
+			if vals1[i].Before(vals2[i]) {
+				ret[i] = -1
+			} else if vals1[i].After(vals2[i]) {
+				ret[i] = 1
+			}
+		}
+	case Duration:
+		vals1, vals2 := vc1.duration().slice, vc2.duration().slice
+		for i := range ret {
+			if vals1[i] < vals2[i] {
+				ret[i] = -1
+			} else if vals1[i] > vals2[i] {
+				ret[i] = 1
+			}
+		}
+	case Decimal:
+		vals1, vals2 := vc1.decimal().slice, vc2.decimal().slice
+		for i := range ret {
+			ret[i] = vals1[i].Cmp(vals2[i])
+		}
+	case Int64:
+		vals1, vals2 := vc1.int64().slice, vc2.int64().slice
+		for i := range ret {
+			if vals1[i] < vals2[i] {
+				ret[i] = -1
+			} else if vals1[i] > vals2[i] {
+				ret[i] = 1
+			}
+		}
+	case Float64:
+		vals1, vals2 := vc1.float64().slice, vc2.float64().slice
+		for i := range ret {
+			if vals1[i] < vals2[i] {
+				ret[i] = -1
+			} else if vals1[i] > vals2[i] {
+				ret[i] = 1
+			}
+		}
+	default:
+		vals1, vals2 := vc1.string().slice, vc2.string().slice
+		for i := range ret {
+			ret[i] = strings.Compare(vals1[i], vals2[i])
+		}
+	}
+	isNull := make([]bool, len(ret))
+	for i := range isNull {
+		if vc1.isNull[i] || vc2.isNull[i] {
+			ret[i] = 0
+			isNull[i] = true
+		}
+	}
+	return ret, isNull
+}
+
+// isIn coerces vc and values to the same type (see comparisonDType) and returns whether each value in vc is equal to any non-null value in values.
+// A row is null if the value in vc is null or cannot be coerced.
+func (vc *valueContainer) isIn(values *valueContainer) ([]bool, []bool) {
+	vc1 := &valueContainer{slice: vc.slice, isNull: copyNulls(vc.isNull)}
+	vc2 := &valueContainer{slice: values.slice, isNull: copyNulls(values.isNull)}
+	// keys are normalized so that equal values have equal keys
+	var keys1, keys2 []interface{}
+	toKeys := func(n int, key func(int) interface{}) []interface{} {
+		ret := make([]interface{}, n)
+		for i := range ret {
+			ret[i] = key(i)
+		}
+		return ret
+	}
+	switch comparisonDType(vc1, vc2) {
+	case DateTime:
+		vals1, vals2 := vc1.dateTime().slice, vc2.dateTime().slice
+		keys1 = toKeys(len(vals1), func(i int) interface{} { return vals1[i].UTC() })
+		keys2 = toKeys(len(vals2), func(i int) interface{} { return vals2[i].UTC() })
+	case Duration:
+		vals1, vals2 := vc1.duration().slice, vc2.duration().slice
+		keys1 = toKeys(len(vals1), func(i int) interface{} { return vals1[i] })
+		keys2 = toKeys(len(vals2), func(i int) interface{} { return vals2[i] })
+	case Decimal:
+		vals1, vals2 := vc1.decimal().slice, vc2.decimal().slice
+		keys1 = toKeys(len(vals1), func(i int) interface{} { return vals1[i].RatString() })
+		keys2 = toKeys(len(vals2), func(i int) interface{} { return vals2[i].RatString() })
+	case Int64:
+		vals1, vals2 := vc1.int64().slice, vc2.int64().slice
+		keys1 = toKeys(len(vals1), func(i int) interface{} { return vals1[i] })
+		keys2 = toKeys(len(vals2), func(i int) interface{} { return vals2[i] })
+	case Float64:
+		vals1, vals2 := vc1.float64().slice, vc2.float64().slice
+		keys1 = toKeys(len(vals1), func(i int) interface{} { return vals1[i] })
+		keys2 = toKeys(len(vals2), func(i int) interface{} { return vals2[i] })
+	default:
+		vals1, vals2 := vc1.string().slice, vc2.string().slice
+		keys1 = toKeys(len(vals1), func(i int) interface{} { return vals1[i] })
+		keys2 = toKeys(len(vals2), func(i int) interface{} { return vals2[i] })
+	}
+	set := make(map[interface{}]bool, len(keys2))
+	for i := range keys2 {
+		if !vc2.isNull[i] {
+			set[keys2[i]] = true
+		}
+	}
+	ret := make([]bool, len(keys1))
+	for i := range keys1 {
+		if !vc1.isNull[i] {
+			ret[i] = set[keys1[i]]
+		}
+	}
+	return ret, vc1.isNull
+}
+
+// compareFunc compares s with other (either a scalar or a Series aligned with s) and returns a boolean Series
+// with the result of fn applied to each row comparison (see valueContainer.compare).
+func (s *Series) compareFunc(other interface{}, fn func(cmp int) bool) (*Series, error) {
+	operand, err := s.alignOperand(other)
+	if err != nil {
+		return nil, err
+	}
+	cmp, isNull := s.values.compare(operand)
+	retVals := make([]bool, len(cmp))
+	for i := range cmp {
+		if !isNull[i] {
+			retVals[i] = fn(cmp[i])
+		}
+	}
+	return &Series{
+		values: newValueContainer(retVals, isNull, s.values.name),
+		labels: copyContainers(s.labels)}, nil
+}
+
+// alignOperand returns the values in other aligned with the rows in s.
+// If other is a Series, it is aligned using the labels in s as an anchor, and rows in s that do not align with any row in other are null.
+// Otherwise, other is treated as a scalar and repeated in every row.
+func (s *Series) alignOperand(other interface{}) (*valueContainer, error) {
+	switch other.(type) {
+	case nil:
+		return nil, fmt.Errorf("other cannot be nil")
+	case *Series:
+		otherSeries := other.(*Series)
+		if otherSeries.err != nil {
+			return nil, fmt.Errorf("other: %v", otherSeries.err)
+		}
+		aligned, err := s.Lookup(otherSeries)
+		if err != nil {
+			return nil, err
+		}
+		return aligned.values, nil
+	}
+	switch reflect.TypeOf(other).Kind() {
+	case reflect.Slice, reflect.Array, reflect.Map:
+		return nil, fmt.Errorf("other must be a scalar or *Series, not %T", other)
+	}
+	vals := reflect.MakeSlice(reflect.SliceOf(reflect.TypeOf(other)), s.Len(), s.Len())
+	src := reflect.ValueOf(other)
+	for i := 0; i < s.Len(); i++ {
+		vals.Index(i).Set(src)
+	}
+	return &valueContainer{slice: vals.Interface(), isNull: make([]bool, s.Len())}, nil
+}
+
+// combineLogic aligns other with s using the labels in s as an anchor, coerces the values in both to bool,
+// and combines the values in each row with fn, which receives and returns both a value and whether it is null.
+// Rows in s that do not align with any row in other are null.
+func (s *Series) combineLogic(other *Series, fn func(v1, null1, v2, null2 bool) (bool, bool)) (*Series, error) {
+	if other.err != nil {
+		return nil, fmt.Errorf("other: %v", other.err)
+	}
+	aligned, err := s.Lookup(other)
+	if err != nil {
+		return nil, err
+	}
+	vals1 := s.values.copy().bool()
+	vals2 := aligned.values.bool()
+	retVals := make([]bool, s.Len())
+	retIsNull := make([]bool, s.Len())
+	for i := range retVals {
+		retVals[i], retIsNull[i] = fn(vals1.slice[i], vals1.isNull[i], vals2.slice[i], vals2.isNull[i])
+	}
+	return &Series{
+		values: newValueContainer(retVals, retIsNull, s.values.name),
+		labels: copyContainers(s.labels)}, nil
+}
+
+// maskIndex aligns mask with labels using labels as an anchor, and returns the index positions at which mask is true.
+// Null or missing mask values are treated as false.
+func maskIndex(labels []*valueContainer, mask *Series) ([]int, error) {
+	if mask.err != nil {
+		return nil, fmt.Errorf("mask: %v", mask.err)
+	}
+	leftKeys, rightKeys, err := findMatchingKeysBetweenTwoContainers(labels, mask.labels)
+	if err != nil {
+		return nil, fmt.Errorf("mask: %v", err)
+	}
+	aligned := lookupWithAnchor(mask.values.name, labels, leftKeys, mask.values, mask.labels, rightKeys)
+	vals := aligned.values.bool()
+	index := make([]int, 0)
+	for i := range vals.slice {
+		if vals.slice[i] && !vals.isNull[i] {
+			index = append(index, i)
+		}
+	}
+	return index, nil
+}
+
+// combineTimeMath adds (or, if subtract is true, subtracts) the aligned values of other to s,
+// where s or other has time.Time or time.Duration values, using the labels in s as an anchor.
+// time.Time - time.Time returns time.Duration values, time.Time +/- time.Duration and time.Duration + time.Time return time.Time values,
+// and all other combinations are coerced to time.Duration and return time.Duration values.
+// If ignoreNulls is true, then missing or null time.Duration values are treated as 0, but missing or null time.Time values are always null.
+func (s *Series) combineTimeMath(other *Series, ignoreNulls bool, subtract bool) (*Series, error) {
+	lookupVals, _ := s.Lookup(other)
+	original := s.values.copy()
+	sIsTime, otherIsTime := s.values.isDateTime(), lookupVals.values.isDateTime()
+	if sIsTime && otherIsTime && !subtract {
+		return nil, fmt.Errorf("cannot add two DateTime values")
+	}
+	if !sIsTime && otherIsTime && subtract {
+		return nil, fmt.Errorf("cannot subtract a DateTime value from a Duration value")
+	}
+	retIsNull := make([]bool, s.Len())
+	// validTime returns whether row i can be combined, and whether the value in s (or other) should be treated as 0
+	validTime := func(i int, sIsDuration, otherIsDuration bool) (ok bool, sZero bool, otherZero bool) {
+		sNull, otherNull := original.isNull[i], lookupVals.values.isNull[i]
+		if !sNull && !otherNull {
+			return true, false, false
+		}
+		if !ignoreNulls || (sNull && !sIsDuration) || (otherNull && !otherIsDuration) || (sNull && otherNull) {
+			return false, false, false
+		}
+		return true, sNull, otherNull
+	}
+	sign := time.Duration(1)
+	if subtract {
+		sign = -1
+	}
+	var retVals interface{}
+	switch {
+	case sIsTime && otherIsTime:
+		times1, times2 := original.dateTime().slice, lookupVals.values.dateTime().slice
+		vals := make([]time.Duration, s.Len())
+		for i := range vals {
+			ok, _, _ := validTime(i, false, false)
+			if !ok {
+				retIsNull[i] = true
+				continue
+			}
+			vals[i] = times1[i].Sub(times2[i])
+		}
+		retVals = vals
+	case sIsTime || otherIsTime:
+		// coerce each container only once, because coercion sets nulls in place
+		var times []time.Time
+		var durations []time.Duration
+		if otherIsTime {
+			times, durations = lookupVals.values.dateTime().slice, original.duration().slice
+		} else {
+			times, durations = original.dateTime().slice, lookupVals.values.duration().slice
+		}
+		vals := make([]time.Time, s.Len())
+		for i := range vals {
+			ok, sZero, otherZero := validTime(i, !sIsTime, !otherIsTime)
+			if !ok {
+				retIsNull[i] = true
+				continue
+			}
+			if sZero || otherZero {
+				vals[i] = times[i]
+				continue
+			}
+			vals[i] = times[i].Add(sign * durations[i])
+		}
+		retVals = vals
+	default:
+		durations1, durations2 := original.duration().slice, lookupVals.values.duration().slice
+		vals := make([]time.Duration, s.Len())
+		for i := range vals {
+			ok, sZero, otherZero := validTime(i, true, true)
+			if !ok {
+				retIsNull[i] = true
+				continue
+			}
+			if sZero {
+				vals[i] = sign * durations2[i]
+			} else if otherZero {
+				vals[i] = durations1[i]
+			} else {
+				vals[i] = durations1[i] + sign*durations2[i]
+			}
+		}
+		retVals = vals
+	}
+	// copy the labels to avoid sharing data with derivative Series
+	return &Series{
+		values: newValueContainer(retVals, retIsNull, s.values.name),
+		labels: copyContainers(s.labels)}, nil
+}
+
+// combineDecimalMath is the exact counterpart of combineMath. fn returns nil if the result is undefined (e.g., division by 0),
+// in which case the resulting value is null.
+func (s *Series) combineDecimalMath(other *Series, ignoreNulls bool, fn func(v1 *big.Rat, v2 *big.Rat) *big.Rat) *Series {
+	retDecimal := make([]*big.Rat, s.Len())
+	retIsNull := make([]bool, s.Len())
+	original := s.values.copy()
+	originalDecimal := original.decimal().slice
+	originalNulls := original.isNull
+	lookupVals, _ := s.Lookup(other)
+	otherDecimal := lookupVals.values.decimal().slice
+	otherNulls := lookupVals.values.isNull
+
+	for i := range originalDecimal {
+		// handle null lookup
+		if (otherNulls[i] || originalNulls[i]) && !ignoreNulls {
+			retDecimal[i] = new(big.Rat)
+			retIsNull[i] = true
+			continue
+		}
+		if otherNulls[i] {
+			retDecimal[i] = originalDecimal[i]
+			retIsNull[i] = originalNulls[i]
+			continue
+		} else if originalNulls[i] {
+			retDecimal[i] = otherDecimal[i]
+			retIsNull[i] = otherNulls[i]
+			continue
+		}
+		// actual combination logic
+		combined := fn(originalDecimal[i], otherDecimal[i])
+		if combined == nil {
+			retDecimal[i] = new(big.Rat)
+			retIsNull[i] = true
+		} else {
+			retDecimal[i] = combined
+			retIsNull[i] = originalNulls[i]
+		}
+	}
+	// copy the labels to avoid sharing data with derivative Series
+	return &Series{
+		values: newValueContainer(retDecimal, retIsNull, s.values.name),
+		labels: copyContainers(s.labels)}
+}
+
+// floatMath coerces the values to float64 and applies fn to each non-null value.
+// Results that are NaN or infinite are null. Returns a new container.
+func (vc *valueContainer) floatMath(fn func(v float64) float64) *valueContainer {
+	floats := (&valueContainer{slice: vc.slice, isNull: copyNulls(vc.isNull)}).float64()
+	retFloat := make([]float64, len(floats.slice))
+	retIsNull := floats.isNull
+	for i := range floats.slice {
+		if retIsNull[i] {
+			continue
+		}
+		retFloat[i] = fn(floats.slice[i])
+		if math.IsNaN(retFloat[i]) || math.IsInf(retFloat[i], 0) {
+			retFloat[i] = 0
+			retIsNull[i] = true
+		}
+	}
+	return newValueContainer(retFloat, retIsNull, vc.name)
+}
+
+// decimalMath coerces the values to *big.Rat and applies fn to each non-null value.
+// fn returns nil if the result is undefined, in which case the resulting value is null. Returns a new container.
+func (vc *valueContainer) decimalMath(fn func(v *big.Rat) *big.Rat) *valueContainer {
+	decimals := (&valueContainer{slice: vc.slice, isNull: copyNulls(vc.isNull)}).decimal()
+	retDecimal := make([]*big.Rat, len(decimals.slice))
+	retIsNull := decimals.isNull
+	for i := range decimals.slice {
+		if !retIsNull[i] {
+			retDecimal[i] = fn(decimals.slice[i])
+		}
+		if retDecimal[i] == nil {
+			retDecimal[i] = new(big.Rat)
+			retIsNull[i] = true
+		}
+	}
+	return newValueContainer(retDecimal, retIsNull, vc.name)
+}
+
+// scalarMath combines each value in s with c using fn, or using decimalFn if s has []*big.Rat (Decimal) values.
+func (s *Series) scalarMath(c float64, fn func(v float64, c float64) float64, decimalFn func(v *big.Rat, c *big.Rat) *big.Rat) *Series {
+	var ret *valueContainer
+	if s.values.isDecimal() {
+		decimalC, isNull := convertFloatToDecimal(c, 64, false)
+		ret = s.values.decimalMath(func(v *big.Rat) *big.Rat {
+			if isNull {
+				return nil
+			}
+			return decimalFn(v, decimalC)
+		})
+	} else {
+		ret = s.values.floatMath(func(v float64) float64 {
+			return fn(v, c)
+		})
+	}
+	// copy the labels to avoid sharing data with derivative Series
+	return &Series{
+		values: ret,
+		labels: copyContainers(s.labels)}
+}
+
+// roundFloat rounds v to the nearest multiple of 10^-decimals, rounding half away from zero.
+func roundFloat(v float64, decimals int) float64 {
+	// every finite float64 is less than half of 10^309
+	if decimals < -308 {
+		return 0
+	}
+	pow := math.Pow10(decimals)
+	scaled := v * pow
+	// v has no digits beyond this decimal place that a float64 can represent
+	if math.IsInf(pow, 0) || math.IsInf(scaled, 0) || math.Abs(scaled) >= 1<<52 {
+		return v
+	}
+	return math.Round(scaled) / pow
+}
+
+// roundDecimal rounds v to the nearest multiple of 10^-decimals, rounding half away from zero.
+func roundDecimal(v *big.Rat, decimals int) *big.Rat {
+	exp := decimals
+	if exp < 0 {
+		exp = -exp
+	}
+	pow := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(exp)), nil)
+	scaled := new(big.Rat).Set(v)
+	if decimals >= 0 {
+		scaled.Mul(scaled, new(big.Rat).SetInt(pow))
+	} else {
+		scaled.Quo(scaled, new(big.Rat).SetInt(pow))
+	}
+	// scaled = num / denom; round |num| / denom half away from zero
+	num := new(big.Int).Abs(scaled.Num())
+	q, r := new(big.Int).QuoRem(num, scaled.Denom(), new(big.Int))
+	if new(big.Int).Mul(r, big.NewInt(2)).Cmp(scaled.Denom()) >= 0 {
+		q.Add(q, big.NewInt(1))
+	}
+	if scaled.Sign() < 0 {
+		q.Neg(q)
+	}
+	ret := new(big.Rat).SetInt(q)
+	if decimals >= 0 {
+		return ret.Quo(ret, new(big.Rat).SetInt(pow))
+	}
+	return ret.Mul(ret, new(big.Rat).SetInt(pow))
+}
+
+// alignToLabels aligns other with labels (as in Lookup), using labels as an anchor.
+func alignToLabels(labels []*valueContainer, other *Series) (*Series, error) {
+	leftKeys, rightKeys, err := findMatchingKeysBetweenTwoContainers(labels, other.labels)
+	if err != nil {
+		return nil, err
+	}
+	return lookupWithAnchor(other.values.name, labels, leftKeys, other.values, other.labels, rightKeys), nil
+}
+
 func lookup(how string,
 	values1 *valueContainer, labels1 []*valueContainer, leftOn []int,
 	values2 *valueContainer, labels2 []*valueContainer, rightOn []int) (*Series, error) {
@@ -1785,6 +2982,21 @@ func copyInterface(i interface{}) interface{} {
 		vals := make([]int, l)
 		copy(vals, i.([]int))
 		return vals
+	case []*big.Rat:
+		// copies each value so that modifying the copy does not modify the original
+		arr := i.([]*big.Rat)
+		vals := make([]*big.Rat, l)
+		for i := range arr {
+			if arr[i] == nil {
+				continue
+			}
+			// zero values are copied as the zero Rat (the representation of null values)
+			vals[i] = new(big.Rat)
+			if arr[i].Sign() != 0 {
+				vals[i].Set(arr[i])
+			}
+		}
+		return vals
 	default:
 		vals := reflect.MakeSlice(v.Type(), l, l)
 		for i := 0; i < v.Len(); i++ {
@@ -1914,6 +3126,18 @@ func setNullsFromInterface(input interface{}) ([]bool, error) {
 				ret[i] = false
 			}
 		}
+	case []Category:
+		vals := input.([]Category)
+		ret = make([]bool, len(vals))
+		for i := range ret {
+			ret[i] = vals[i].Code() < 0
+		}
+	case []*big.Rat:
+		vals := input.([]*big.Rat)
+		ret = make([]bool, len(vals))
+		for i := range ret {
+			ret[i] = vals[i] == nil
+		}
 	default:
 		// all other types are considered non-null
 		l := reflect.ValueOf(input).Len()
@@ -2131,15 +3355,250 @@ func max(vals []float64, isNull []bool, index []int) (float64, bool) {
 	return max, false
 }
 
-// earliest returns the earliest of the non-null values at the index positions in vals.
+// sumInt64 sums the non-null values at the index positions in vals.
+// If all values are null, or if the sum overflows int64, the final result is null.
 // Compatible with Grouped calculations as well as Series
-func earliest(vals []time.Time, isNull []bool, index []int) (time.Time, bool) {
-	min := time.Date(10000, 1, 1, 0, 0, 0, 0, time.UTC)
+func sumInt64(vals []int64, isNull []bool, index []int) (int64, bool) {
+	var sum int64
 	var atLeastOneValid bool
 	for _, i := range index {
 		if !isNull[i] {
-			if vals[i].Before(min) {
-				min = vals[i]
+			next := sum + vals[i]
+			if (vals[i] > 0 && next < sum) || (vals[i] < 0 && next > sum) {
+				return 0, true
+			}
+			sum = next
+			atLeastOneValid = true
+		}
+	}
+	if !atLeastOneValid {
+		return 0, true
+	}
+	return sum, false
+}
+
+// minInt64 returns the min of the non-null values at the index positions in vals.
+// Compatible with Grouped calculations as well as Series
+func minInt64(vals []int64, isNull []bool, index []int) (int64, bool) {
+	var min int64 = math.MaxInt64
+	var atLeastOneValid bool
+	for _, i := range index {
+		if !isNull[i] {
+			if vals[i] < min {
+				min = vals[i]
+			}
+			atLeastOneValid = true
+		}
+	}
+	if !atLeastOneValid {
+		return 0, true
+	}
+	return min, false
+}
+
+// maxInt64 returns the max of the non-null values at the index positions in vals.
+// Compatible with Grouped calculations as well as Series
+func maxInt64(vals []int64, isNull []bool, index []int) (int64, bool) {
+	var max int64 = math.MinInt64
+	var atLeastOneValid bool
+	for _, i := range index {
+		if !isNull[i] {
+			if vals[i] > max {
+				max = vals[i]
+			}
+			atLeastOneValid = true
+		}
+	}
+	if !atLeastOneValid {
+		return 0, true
+	}
+	return max, false
+}
+
+// sumDuration returns the sum of the non-null values at the index positions in vals.
+// If the sum overflows time.Duration, the result is null.
+// Compatible with Grouped calculations as well as Series
+func sumDuration(vals []time.Duration, isNull []bool, index []int) (time.Duration, bool) {
+	var sum time.Duration
+	var atLeastOneValid bool
+	for _, i := range index {
+		if !isNull[i] {
+			next := sum + vals[i]
+			if (vals[i] > 0 && next < sum) || (vals[i] < 0 && next > sum) {
+				return 0, true
+			}
+			sum = next
+			atLeastOneValid = true
+		}
+	}
+	if !atLeastOneValid {
+		return 0, true
+	}
+	return sum, false
+}
+
+// meanDuration returns the mean of the non-null values at the index positions in vals, rounded to the nearest nanosecond.
+// Compatible with Grouped calculations as well as Series
+func meanDuration(vals []time.Duration, isNull []bool, index []int) (time.Duration, bool) {
+	// sum as *big.Int to avoid overflow before dividing
+	sum := new(big.Int)
+	var counter int64
+	for _, i := range index {
+		if !isNull[i] {
+			sum.Add(sum, big.NewInt(int64(vals[i])))
+			counter++
+		}
+	}
+	if counter == 0 {
+		return 0, true
+	}
+	n := big.NewInt(counter)
+	mean, rem := new(big.Int).QuoRem(sum, n, new(big.Int))
+	// round half away from zero
+	if rem.Abs(rem).Lsh(rem, 1).Cmp(n) >= 0 {
+		mean.Add(mean, big.NewInt(int64(sum.Sign())))
+	}
+	return time.Duration(mean.Int64()), false
+}
+
+// medianDuration returns the median of the non-null values at the index positions in vals.
+// If there is an even number of values, the median is the mean of the two middle values, rounded to the nearest nanosecond.
+// Compatible with Grouped calculations as well as Series
+func medianDuration(vals []time.Duration, isNull []bool, index []int) (time.Duration, bool) {
+	data := make([]time.Duration, 0, len(index))
+	for _, i := range index {
+		if !isNull[i] {
+			data = append(data, vals[i])
+		}
+	}
+	if len(data) == 0 {
+		return 0, true
+	}
+	sort.Slice(data, func(i, j int) bool { return data[i] < data[j] })
+	mid := len(data) / 2
+	if len(data)%2 == 1 {
+		return data[mid], false
+	}
+	lower, upper := data[mid-1], data[mid]
+	// avoid overflow of lower + upper
+	return lower + time.Duration(math.Round(float64(upper-lower)/2)), false
+}
+
+// minDuration returns the min of the non-null values at the index positions in vals.
+// Compatible with Grouped calculations as well as Series
+func minDuration(vals []time.Duration, isNull []bool, index []int) (time.Duration, bool) {
+	var min time.Duration = math.MaxInt64
+	var atLeastOneValid bool
+	for _, i := range index {
+		if !isNull[i] {
+			if vals[i] < min {
+				min = vals[i]
+			}
+			atLeastOneValid = true
+		}
+	}
+	if !atLeastOneValid {
+		return 0, true
+	}
+	return min, false
+}
+
+// maxDuration returns the max of the non-null values at the index positions in vals.
+// Compatible with Grouped calculations as well as Series
+func maxDuration(vals []time.Duration, isNull []bool, index []int) (time.Duration, bool) {
+	var max time.Duration = math.MinInt64
+	var atLeastOneValid bool
+	for _, i := range index {
+		if !isNull[i] {
+			if vals[i] > max {
+				max = vals[i]
+			}
+			atLeastOneValid = true
+		}
+	}
+	if !atLeastOneValid {
+		return 0, true
+	}
+	return max, false
+}
+
+// sumDecimal returns the exact sum of the non-null values at the index positions in vals.
+// Compatible with Grouped calculations as well as Series
+func sumDecimal(vals []*big.Rat, isNull []bool, index []int) (*big.Rat, bool) {
+	sum := new(big.Rat)
+	var atLeastOneValid bool
+	for _, i := range index {
+		if !isNull[i] {
+			sum.Add(sum, vals[i])
+			atLeastOneValid = true
+		}
+	}
+	if !atLeastOneValid {
+		return new(big.Rat), true
+	}
+	return sum, false
+}
+
+// meanDecimal returns the exact mean of the non-null values at the index positions in vals.
+// Compatible with Grouped calculations as well as Series
+func meanDecimal(vals []*big.Rat, isNull []bool, index []int) (*big.Rat, bool) {
+	sum := new(big.Rat)
+	var counter int64
+	for _, i := range index {
+		if !isNull[i] {
+			sum.Add(sum, vals[i])
+			counter++
+		}
+	}
+	if counter == 0 {
+		return new(big.Rat), true
+	}
+	return sum.Quo(sum, new(big.Rat).SetInt64(counter)), false
+}
+
+// minDecimal returns the min of the non-null values at the index positions in vals.
+// Compatible with Grouped calculations as well as Series
+func minDecimal(vals []*big.Rat, isNull []bool, index []int) (*big.Rat, bool) {
+	var min *big.Rat
+	for _, i := range index {
+		if !isNull[i] {
+			if min == nil || vals[i].Cmp(min) < 0 {
+				min = vals[i]
+			}
+		}
+	}
+	if min == nil {
+		return new(big.Rat), true
+	}
+	return new(big.Rat).Set(min), false
+}
+
+// maxDecimal returns the max of the non-null values at the index positions in vals.
+// Compatible with Grouped calculations as well as Series
+func maxDecimal(vals []*big.Rat, isNull []bool, index []int) (*big.Rat, bool) {
+	var max *big.Rat
+	for _, i := range index {
+		if !isNull[i] {
+			if max == nil || vals[i].Cmp(max) > 0 {
+				max = vals[i]
+			}
+		}
+	}
+	if max == nil {
+		return new(big.Rat), true
+	}
+	return new(big.Rat).Set(max), false
+}
+
+// earliest returns the earliest of the non-null values at the index positions in vals.
+// Compatible with Grouped calculations as well as Series
+func earliest(vals []time.Time, isNull []bool, index []int) (time.Time, bool) {
+	min := time.Date(10000, 1, 1, 0, 0, 0, 0, time.UTC)
+	var atLeastOneValid bool
+	for _, i := range index {
+		if !isNull[i] {
+			if vals[i].Before(min) {
+				min = vals[i]
 			}
 			atLeastOneValid = true
 		}
@@ -2204,6 +3663,21 @@ func (lambda ReduceFn) validate() error {
 }
 
 // left-exclusive, right-inclusive by default
+// durationBinLabels returns the default labels for bins, with the same interval rules as cut().
+func durationBinLabels(bins []time.Duration, includeLess, includeMore bool) []string {
+	labels := make([]string, len(bins)-1)
+	for i := 0; i < len(bins)-1; i++ {
+		labels[i] = fmt.Sprintf("%v-%v", bins[i], bins[i+1])
+	}
+	if includeLess {
+		labels = append([]string{fmt.Sprintf("<=%v", bins[0])}, labels...)
+	}
+	if includeMore {
+		labels = append(labels, fmt.Sprintf(">%v", bins[len(bins)-1]))
+	}
+	return labels
+}
+
 // expects vals and isNull to be same length
 func cut(vals []float64, isNull []bool,
 	bins []float64, leftInclusive bool, rightExclusive bool,
@@ -2441,7 +3915,162 @@ func resample(t time.Time, by Resampler) time.Time {
 	}
 }
 
+// localizeTime returns the time in loc with the same wall clock as t.
+// A wall clock that occurs twice in loc (when clocks fall back) resolves to the earlier time,
+// and a wall clock that does not occur in loc (when clocks spring forward) is shifted forward by the length of the gap.
+func localizeTime(t time.Time, loc *time.Location) time.Time {
+	wall := time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), time.UTC)
+	// assumes that loc has at most one offset transition within a day of t
+	_, offsetBefore := wall.Add(-24 * time.Hour).In(loc).Zone()
+	_, offsetAfter := wall.Add(24 * time.Hour).In(loc).Zone()
+	var ret time.Time
+	var found bool
+	for _, offset := range []int{offsetBefore, offsetAfter} {
+		candidate := wall.Add(-time.Duration(offset) * time.Second).In(loc)
+		candidateWall := time.Date(candidate.Year(), candidate.Month(), candidate.Day(),
+			candidate.Hour(), candidate.Minute(), candidate.Second(), candidate.Nanosecond(), time.UTC)
+		if candidateWall.Equal(wall) && (!found || candidate.Before(ret)) {
+			ret = candidate
+			found = true
+		}
+	}
+	if !found {
+		// the offset before the gap places the time after the gap
+		ret = wall.Add(-time.Duration(offsetBefore) * time.Second).In(loc)
+	}
+	return ret
+}
+
+// localize coerces vc to []time.Time and replaces the location of each value with loc, keeping the same wall clock.
+func (vc *valueContainer) localize(loc *time.Location) {
+	if loc == nil {
+		loc = time.UTC
+	}
+	// strings may have no time zone
+	vc.castIn(DateTime, loc)
+	vals := vc.dateTime().slice
+	retVals := make([]time.Time, len(vals))
+	for i := range vals {
+		if !vc.isNull[i] {
+			retVals[i] = localizeTime(vals[i], loc)
+		}
+	}
+	vc.slice = retVals
+	vc.resetCache()
+}
+
+// convertTimeZone coerces vc to []time.Time and converts each value to the same instant in loc.
+func (vc *valueContainer) convertTimeZone(loc *time.Location) {
+	if loc == nil {
+		loc = time.UTC
+	}
+	vals := vc.dateTime().slice
+	retVals := make([]time.Time, len(vals))
+	for i := range vals {
+		if !vc.isNull[i] {
+			retVals[i] = vals[i].In(loc)
+		}
+	}
+	vc.slice = retVals
+	vc.resetCache()
+}
+
+// sharedLocation returns the location of the non-null values in times,
+// or nil if there are no non-null values or the values are in more than one location.
+func sharedLocation(times []time.Time, isNull []bool) *time.Location {
+	var ret *time.Location
+	for i := range times {
+		if isNull[i] {
+			continue
+		}
+		loc := times[i].Location()
+		if ret == nil {
+			ret = loc
+		} else if loc.String() != ret.String() {
+			return nil
+		}
+	}
+	return ret
+}
+
+// restoreLocation converts the non-null values in times to the location named name (as recorded from sharedLocation).
+func restoreLocation(times []time.Time, isNull []bool, name string) error {
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return fmt.Errorf("loading location: %v", err)
+	}
+	for i := range times {
+		if !isNull[i] {
+			times[i] = times[i].In(loc)
+		}
+	}
+	return nil
+}
+
+// resampleDuration truncates d by the fixed-length logic in by, and returns false if by does not have a fixed length.
+func resampleDuration(d time.Duration, by Resampler) (time.Duration, bool) {
+	if by.ByYear || by.ByMonth {
+		return 0, false
+	} else if by.ByDay {
+		return d.Truncate(24 * time.Hour), true
+	} else if by.ByWeek {
+		return d.Truncate(7 * 24 * time.Hour), true
+	}
+	return d.Truncate(by.ByDuration), true
+}
+
+// parseISODuration parses an ISO 8601 duration with week, day, hour, minute, and second components (e.g., "P1DT2H30M" or "-PT1.5S").
+// Years and months are not supported because they do not have a fixed length.
+func parseISODuration(s string) (time.Duration, error) {
+	matches := isoDurationPattern.FindStringSubmatch(s)
+	if matches == nil || strings.HasSuffix(s, "P") || strings.HasSuffix(s, "T") {
+		return 0, fmt.Errorf("invalid ISO 8601 duration (%s)", s)
+	}
+	units := []time.Duration{7 * 24 * time.Hour, 24 * time.Hour, time.Hour, time.Minute, time.Second}
+	var ret time.Duration
+	for k, unit := range units {
+		component := strings.Replace(matches[k+2], ",", ".", 1)
+		if component == "" {
+			continue
+		}
+		// parse integer and fractional parts separately to avoid losing precision
+		parts := strings.SplitN(component, ".", 2)
+		n, err := strconv.ParseInt(parts[0], 10, 64)
+		if err != nil || n > math.MaxInt64/int64(unit) {
+			return 0, fmt.Errorf("invalid ISO 8601 duration (%s)", s)
+		}
+		ret += time.Duration(n) * unit
+		if len(parts) == 2 {
+			frac, _ := strconv.ParseFloat("0."+parts[1], 64)
+			ret += time.Duration(math.Round(frac * float64(unit)))
+		}
+		if ret < 0 {
+			return 0, fmt.Errorf("invalid ISO 8601 duration (%s): overflows time.Duration", s)
+		}
+	}
+	if matches[1] == "-" {
+		ret = -ret
+	}
+	return ret, nil
+}
+
+var isoDurationPattern = regexp.MustCompile(
+	`^([-+]?)P(?:(\d+(?:[.,]\d+)?)W)?(?:(\d+(?:[.,]\d+)?)D)?(?:T(?:(\d+(?:[.,]\d+)?)H)?(?:(\d+(?:[.,]\d+)?)M)?(?:(\d+(?:[.,]\d+)?)S)?)?$`)
+
 func (vc *valueContainer) resample(by Resampler) {
+	if arr, ok := vc.slice.([]time.Duration); ok {
+		retVals := make([]time.Duration, len(arr))
+		for i := range arr {
+			var fixed bool
+			retVals[i], fixed = resampleDuration(arr[i], by)
+			if !fixed {
+				vc.isNull[i] = true
+			}
+		}
+		vc.slice = retVals
+		vc.resetCache()
+		return
+	}
 	var isCivilDate, isCivilTime bool
 	switch vc.slice.(type) {
 	case []civil.Date:
@@ -2479,6 +4108,9 @@ func (vc *valueContainer) resample(by Resampler) {
 }
 
 func (vc *valueContainer) valueCounts() map[string]int {
+	if arr, ok := vc.slice.([]Category); ok {
+		return categoryCounts(arr, vc.isNull)
+	}
 	v := vc.string().slice
 	m := make(map[string]int)
 	for i := range v {
@@ -2495,6 +4127,26 @@ func (vc *valueContainer) valueCounts() map[string]int {
 	return m
 }
 
+// categoryCounts counts the non-null values in arr by code, then looks up each code in its dictionary.
+func categoryCounts(arr []Category, isNull []bool) map[string]int {
+	type key struct {
+		dict *categoryDictionary
+		code int32
+	}
+	counts := make(map[key]int)
+	for i := range arr {
+		if isNull[i] || arr[i].Code() < 0 {
+			continue
+		}
+		counts[key{arr[i].dict, arr[i].code}]++
+	}
+	ret := make(map[string]int, len(counts))
+	for k, n := range counts {
+		ret[k.dict.values[k.code]] += n
+	}
+	return ret
+}
+
 func deduplicateContainerNames(containers []*valueContainer) {
 	m := make(map[string]int)
 	for k := range containers {
@@ -2906,3 +4558,3604 @@ func writeInterfaceRecords(containers []*valueContainer, byColumn bool, numColLe
 	}
 	return ret
 }
+
+// keys used to store DataFrame metadata in the schema of a columnar file format
+const (
+	metadataLabelLevels   = "tada:labelLevels"
+	metadataColLevelNames = "tada:colLevelNames"
+	metadataName          = "tada:name"
+	metadataFields        = "tada:fields"
+)
+
+// fieldMetadata records the slice type of a container (and the location shared by its []time.Time values, if any),
+// which the types of a columnar file format do not always preserve.
+type fieldMetadata struct {
+	Type     string `json:"type"`
+	Location string `json:"location,omitempty"`
+}
+
+// integerSliceTypes maps the name of every integer slice type to its type.
+var integerSliceTypes = map[string]reflect.Type{}
+
+func init() {
+	for _, slice := range []interface{}{
+		[]int{}, []int8{}, []int16{}, []int32{}, []int64{},
+		[]uint{}, []uint8{}, []uint16{}, []uint32{}, []uint64{},
+	} {
+		integerSliceTypes[reflect.TypeOf(slice).String()] = reflect.TypeOf(slice)
+	}
+}
+
+// metadata returns the label levels, column level names, name, and field metadata of df as key-value pairs
+// that can be stored in the schema of a columnar file format.
+func (df *DataFrame) metadata() (map[string]string, error) {
+	colLevelNames, err := json.Marshal(df.colLevelNames)
+	if err != nil {
+		return nil, fmt.Errorf("encoding column level names: %v", err)
+	}
+	fields := make([]fieldMetadata, 0, len(df.labels)+len(df.values))
+	for _, containers := range [][]*valueContainer{df.labels, df.values} {
+		for _, vc := range containers {
+			field := fieldMetadata{Type: reflect.TypeOf(vc.slice).String()}
+			if times, ok := vc.slice.([]time.Time); ok {
+				if loc := sharedLocation(times, vc.isNull); loc != nil {
+					field.Location = loc.String()
+				}
+			}
+			fields = append(fields, field)
+		}
+	}
+	fieldsJSON, err := json.Marshal(fields)
+	if err != nil {
+		return nil, fmt.Errorf("encoding field metadata: %v", err)
+	}
+	return map[string]string{
+		metadataLabelLevels:   strconv.Itoa(len(df.labels)),
+		metadataColLevelNames: string(colLevelNames),
+		metadataName:          df.name,
+		metadataFields:        string(fieldsJSON),
+	}, nil
+}
+
+// readFieldMetadata returns the field metadata for numFields fields, or nil if metadata does not include field metadata.
+func readFieldMetadata(metadata map[string]string, numFields int) ([]fieldMetadata, error) {
+	v, ok := metadata[metadataFields]
+	if !ok {
+		return nil, nil
+	}
+	var ret []fieldMetadata
+	err := json.Unmarshal([]byte(v), &ret)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s metadata: %v", metadataFields, err)
+	}
+	if len(ret) != numFields {
+		return nil, fmt.Errorf("invalid %s metadata: number of fields (%d) does not match schema (%d)",
+			metadataFields, len(ret), numFields)
+	}
+	return ret, nil
+}
+
+// restoreType converts vc to the slice type and location recorded in field, if vc was read as a different type.
+// Integers are converted to the recorded integer type or read as nanoseconds of []time.Duration,
+// and strings are parsed as []time.Duration or []*big.Rat.
+// Other types are left unchanged.
+func (vc *valueContainer) restoreType(field fieldMetadata) error {
+	if times, ok := vc.slice.([]time.Time); ok && field.Location != "" {
+		return restoreLocation(times, vc.isNull, field.Location)
+	}
+	v := reflect.ValueOf(vc.slice)
+	if v.Type().String() == field.Type {
+		return nil
+	}
+	switch field.Type {
+	case reflect.TypeOf([]time.Duration{}).String():
+		vc.cast(Duration)
+	case reflect.TypeOf([]*big.Rat{}).String():
+		vc.cast(Decimal)
+	default:
+		sliceType, ok := integerSliceTypes[field.Type]
+		if !ok {
+			return nil
+		}
+		if _, ok := integerSliceTypes[v.Type().String()]; !ok {
+			return nil
+		}
+		ret := reflect.MakeSlice(sliceType, v.Len(), v.Len())
+		for i := 0; i < v.Len(); i++ {
+			ret.Index(i).Set(v.Index(i).Convert(sliceType.Elem()))
+		}
+		vc.slice = ret.Interface()
+	}
+	vc.resetCache()
+	return nil
+}
+
+// containersToDFFromMetadata converts containers to a DataFrame
+// using the label levels, column level names, and name stored in metadata.
+// If metadata does not include the number of label levels, the first labelLevels containers become label levels.
+func containersToDFFromMetadata(containers []*valueContainer, metadata map[string]string, labelLevels int) (*DataFrame, error) {
+	v, ok := metadata[metadataLabelLevels]
+	if !ok {
+		if labelLevels > len(containers) {
+			return nil, fmt.Errorf("number of label levels (%d) exceeds number of fields (%d)", labelLevels, len(containers))
+		}
+		return containersToDF(containers, 1, labelLevels, ""), nil
+	}
+	numLabels, err := strconv.Atoi(v)
+	if err != nil || numLabels < 0 || numLabels > len(containers) {
+		return nil, fmt.Errorf("invalid %s metadata (%s)", metadataLabelLevels, v)
+	}
+	labels := containers[:numLabels]
+	if numLabels == 0 {
+		labels = []*valueContainer{makeDefaultLabels(0, containers[0].len(), true)}
+	}
+	df := &DataFrame{
+		labels:        labels,
+		values:        containers[numLabels:],
+		colLevelNames: []string{"*0"},
+		name:          metadata[metadataName],
+	}
+	if v, ok := metadata[metadataColLevelNames]; ok {
+		err := json.Unmarshal([]byte(v), &df.colLevelNames)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s metadata: %v", metadataColLevelNames, err)
+		}
+	}
+	return df, nil
+}
+
+// -- Apache Arrow
+
+var arrowEpoch = civil.Date{Year: 1970, Month: 1, Day: 1}
+
+// positionWriter satisfies io.WriteSeeker for a writer that can only report its current position,
+// which is the only seek required by the Arrow IPC file writer.
+type positionWriter struct {
+	w   io.Writer
+	pos int64
+}
+
+func (w *positionWriter) Write(p []byte) (int, error) {
+	n, err := w.w.Write(p)
+	w.pos += int64(n)
+	return n, err
+}
+
+func (w *positionWriter) Seek(offset int64, whence int) (int64, error) {
+	if offset != 0 || whence != io.SeekCurrent {
+		return 0, fmt.Errorf("seeking: only the current position is supported")
+	}
+	return w.pos, nil
+}
+
+// writeArrowRecord converts the labels and columns of df into a single Arrow record,
+// with label and column metadata stored in the schema.
+// The caller is responsible for releasing the record.
+func writeArrowRecord(df *DataFrame) (array.Record, error) {
+	containers := make([]*valueContainer, 0, len(df.labels)+len(df.values))
+	containers = append(containers, df.labels...)
+	containers = append(containers, df.values...)
+	mem := memory.NewGoAllocator()
+	fields := make([]arrow.Field, len(containers))
+	cols := make([]array.Interface, len(containers))
+	defer func() {
+		for k := range cols {
+			if cols[k] != nil {
+				cols[k].Release()
+			}
+		}
+	}()
+	for k := range containers {
+		cols[k] = containers[k].arrowArray(mem)
+		fields[k] = arrow.Field{Name: containers[k].name, Type: cols[k].DataType(), Nullable: true}
+	}
+	metadata, err := df.metadata()
+	if err != nil {
+		return nil, err
+	}
+	keys := []string{metadataLabelLevels, metadataColLevelNames, metadataName, metadataFields}
+	values := make([]string, len(keys))
+	for i := range keys {
+		values[i] = metadata[keys[i]]
+	}
+	md := arrow.NewMetadata(keys, values)
+	schema := arrow.NewSchema(fields, &md)
+	return array.NewRecord(schema, cols, int64(df.Len())), nil
+}
+
+// arrowArray converts vc into an Arrow array, with vc.isNull as the validity bitmap.
+// The caller is responsible for releasing the array.
+func (vc *valueContainer) arrowArray(mem memory.Allocator) array.Interface {
+	valid := make([]bool, len(vc.isNull))
+	for i := range vc.isNull {
+		valid[i] = !vc.isNull[i]
+	}
+	switch vc.slice.(type) {
+	case []float64:
+		b := array.NewFloat64Builder(mem)
+		defer b.Release()
+		b.AppendValues(vc.slice.([]float64), valid)
+		return b.NewArray()
+	case []string:
+		b := array.NewStringBuilder(mem)
+		defer b.Release()
+		b.AppendValues(vc.slice.([]string), valid)
+		return b.NewArray()
+	case []bool:
+		b := array.NewBooleanBuilder(mem)
+		defer b.Release()
+		b.AppendValues(vc.slice.([]bool), valid)
+		return b.NewArray()
+	case []time.Time:
+		arr := vc.slice.([]time.Time)
+		// the time zone is the shared location of the values, or UTC if they do not share a named location
+		timeZone := "UTC"
+		if loc := sharedLocation(arr, vc.isNull); loc != nil && loc != time.Local {
+			timeZone = loc.String()
+		}
+		b := array.NewTimestampBuilder(mem, &arrow.TimestampType{Unit: arrow.Nanosecond, TimeZone: timeZone})
+		defer b.Release()
+		for i := range arr {
+			if vc.isNull[i] {
+				b.AppendNull()
+			} else {
+				b.Append(arrow.Timestamp(arr[i].UnixNano()))
+			}
+		}
+		return b.NewArray()
+	case []civil.Date:
+		arr := vc.slice.([]civil.Date)
+		b := array.NewDate32Builder(mem)
+		defer b.Release()
+		for i := range arr {
+			if vc.isNull[i] {
+				b.AppendNull()
+			} else {
+				b.Append(arrow.Date32(arr[i].DaysSince(arrowEpoch)))
+			}
+		}
+		return b.NewArray()
+	case []civil.Time:
+		arr := vc.slice.([]civil.Time)
+		vals := make([]arrow.Time64, len(arr))
+		for i := range arr {
+			vals[i] = arrow.Time64(int64(arr[i].Hour)*int64(time.Hour) + int64(arr[i].Minute)*int64(time.Minute) +
+				int64(arr[i].Second)*int64(time.Second) + int64(arr[i].Nanosecond))
+		}
+		b := array.NewTime64Builder(mem, arrow.FixedWidthTypes.Time64ns.(*arrow.Time64Type))
+		defer b.Release()
+		b.AppendValues(vals, valid)
+		return b.NewArray()
+	case []time.Duration:
+		arr := vc.slice.([]time.Duration)
+		vals := make([]arrow.Duration, len(arr))
+		for i := range arr {
+			vals[i] = arrow.Duration(arr[i])
+		}
+		b := array.NewDurationBuilder(mem, arrow.FixedWidthTypes.Duration_ns.(*arrow.DurationType))
+		defer b.Release()
+		b.AppendValues(vals, valid)
+		return b.NewArray()
+	case []int, []int8, []int16, []int32, []int64, []uint, []uint8, []uint16, []uint32, []uint64:
+		v := reflect.ValueOf(vc.slice)
+		vals := make([]int64, v.Len())
+		for i := range vals {
+			if v.Index(i).Kind() >= reflect.Uint && v.Index(i).Kind() <= reflect.Uint64 {
+				vals[i] = int64(v.Index(i).Uint())
+			} else {
+				vals[i] = v.Index(i).Int()
+			}
+		}
+		b := array.NewInt64Builder(mem)
+		defer b.Release()
+		b.AppendValues(vals, valid)
+		return b.NewArray()
+	case []*big.Rat:
+		if ret, ok := arrowDecimalArray(mem, vc.slice.([]*big.Rat), valid); ok {
+			return ret
+		}
+		// values that need more than arrowDecimalPrecision digits are written as strings
+		b := array.NewStringBuilder(mem)
+		defer b.Release()
+		b.AppendValues(vc.string().slice, valid)
+		return b.NewArray()
+	default:
+		b := array.NewStringBuilder(mem)
+		defer b.Release()
+		b.AppendValues(vc.string().slice, valid)
+		return b.NewArray()
+	}
+}
+
+// arrowDecimalPrecision is the maximum number of digits in an Arrow decimal128 value.
+const arrowDecimalPrecision = 38
+
+// decimal128Values scales vals to integers, with the scale of the most precise valid value (as formatted by formatDecimal).
+// Returns false if any valid value needs more than arrowDecimalPrecision digits.
+func decimal128Values(vals []*big.Rat, valid []bool) ([]decimal128.Num, int, bool) {
+	var scale int
+	for i := range vals {
+		if valid[i] && vals[i] != nil {
+			if places := decimalPlaces(vals[i]); places > scale {
+				scale = places
+			}
+		}
+	}
+	if scale > arrowDecimalPrecision {
+		return nil, 0, false
+	}
+	pow := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(scale)), nil)
+	limit := new(big.Int).Exp(big.NewInt(10), big.NewInt(arrowDecimalPrecision), nil)
+	nums := make([]decimal128.Num, len(vals))
+	for i := range vals {
+		if !valid[i] || vals[i] == nil {
+			continue
+		}
+		// exact after rounding to scale
+		rounded := roundDecimal(vals[i], scale)
+		n := new(big.Int).Mul(rounded.Num(), pow)
+		n.Quo(n, rounded.Denom())
+		if new(big.Int).Abs(n).Cmp(limit) >= 0 {
+			return nil, 0, false
+		}
+		nums[i] = decimal128FromBigInt(n)
+	}
+	return nums, scale, true
+}
+
+// arrowDecimalArray writes vals as decimal128 values (see decimal128Values).
+// Returns false if any valid value needs more than arrowDecimalPrecision digits.
+func arrowDecimalArray(mem memory.Allocator, vals []*big.Rat, valid []bool) (array.Interface, bool) {
+	nums, scale, ok := decimal128Values(vals, valid)
+	if !ok {
+		return nil, false
+	}
+	b := array.NewDecimal128Builder(mem, &arrow.Decimal128Type{Precision: arrowDecimalPrecision, Scale: int32(scale)})
+	defer b.Release()
+	b.AppendValues(nums, valid)
+	return b.NewArray(), true
+}
+
+// decimal128FromBigInt converts n (which must fit in 128 bits) to its two's complement representation.
+func decimal128FromBigInt(n *big.Int) decimal128.Num {
+	u := new(big.Int).Set(n)
+	if u.Sign() < 0 {
+		u.Add(u, new(big.Int).Lsh(big.NewInt(1), 128))
+	}
+	lo := new(big.Int).And(u, new(big.Int).SetUint64(math.MaxUint64)).Uint64()
+	hi := new(big.Int).Rsh(u, 64).Uint64()
+	return decimal128.New(int64(hi), lo)
+}
+
+// bigIntFromDecimal128 is the inverse of decimal128FromBigInt.
+func bigIntFromDecimal128(n decimal128.Num) *big.Int {
+	ret := new(big.Int).Lsh(big.NewInt(n.HighBits()), 64)
+	return ret.Add(ret, new(big.Int).SetUint64(n.LowBits()))
+}
+
+// readArrowRecords converts one or more Arrow records sharing the same schema into a DataFrame.
+// If the schema has tada metadata, it is used to restore the labels, column level names, and name.
+// Otherwise, the first labelLevels fields become label levels.
+func readArrowRecords(schema *arrow.Schema, records []array.Record, labelLevels int) (*DataFrame, error) {
+	fields := schema.Fields()
+	if len(fields) == 0 {
+		return nil, fmt.Errorf("schema must contain at least one field")
+	}
+	containers := make([]*valueContainer, len(fields))
+	for k := range fields {
+		var slice interface{}
+		var isNull []bool
+		for n := range records {
+			s, nulls, err := arrowArrayToSlice(records[n].Column(k))
+			if err != nil {
+				return nil, fmt.Errorf("field %d (%s): %v", k, fields[k].Name, err)
+			}
+			if slice == nil {
+				slice = s
+			} else {
+				slice = reflect.AppendSlice(reflect.ValueOf(slice), reflect.ValueOf(s)).Interface()
+			}
+			isNull = append(isNull, nulls...)
+		}
+		if slice == nil {
+			s, _, err := arrowArrayToSlice(array.MakeFromData(array.NewData(fields[k].Type, 0, nil, nil, 0, 0)))
+			if err != nil {
+				return nil, fmt.Errorf("field %d (%s): %v", k, fields[k].Name, err)
+			}
+			slice = s
+			isNull = []bool{}
+		}
+		containers[k] = newValueContainer(slice, isNull, fields[k].Name)
+	}
+
+	md := schema.Metadata()
+	metadata := make(map[string]string, md.Len())
+	for i, key := range md.Keys() {
+		metadata[key] = md.Values()[i]
+	}
+	fieldMeta, err := readFieldMetadata(metadata, len(containers))
+	if err != nil {
+		return nil, err
+	}
+	for k := range fieldMeta {
+		err := containers[k].restoreType(fieldMeta[k])
+		if err != nil {
+			return nil, fmt.Errorf("field %d (%s): %v", k, fields[k].Name, err)
+		}
+	}
+	return containersToDFFromMetadata(containers, metadata, labelLevels)
+}
+
+// arrowUnitNanos returns the number of nanoseconds in one Arrow time unit.
+func arrowUnitNanos(unit arrow.TimeUnit) int64 {
+	switch unit {
+	case arrow.Second:
+		return int64(time.Second)
+	case arrow.Millisecond:
+		return int64(time.Millisecond)
+	case arrow.Microsecond:
+		return int64(time.Microsecond)
+	default:
+		return 1
+	}
+}
+
+// arrowArrayToSlice converts an Arrow array into a slice and the null status of each value.
+func arrowArrayToSlice(arr array.Interface) (interface{}, []bool, error) {
+	l := arr.Len()
+	isNull := make([]bool, l)
+	for i := range isNull {
+		isNull[i] = arr.IsNull(i)
+	}
+	switch a := arr.(type) {
+	case *array.Float64:
+		vals := make([]float64, l)
+		copy(vals, a.Float64Values())
+		return vals, isNull, nil
+	case *array.Float32:
+		vals := make([]float64, l)
+		for i := range vals {
+			vals[i] = float64(a.Value(i))
+		}
+		return vals, isNull, nil
+	case *array.String:
+		vals := make([]string, l)
+		for i := range vals {
+			vals[i] = a.Value(i)
+		}
+		return vals, isNull, nil
+	case *array.Binary:
+		vals := make([]string, l)
+		for i := range vals {
+			vals[i] = string(a.Value(i))
+		}
+		return vals, isNull, nil
+	case *array.Boolean:
+		vals := make([]bool, l)
+		for i := range vals {
+			vals[i] = a.Value(i)
+		}
+		return vals, isNull, nil
+	case *array.Int64:
+		vals := make([]int64, l)
+		copy(vals, a.Int64Values())
+		return vals, isNull, nil
+	case *array.Int8, *array.Int16, *array.Int32,
+		*array.Uint8, *array.Uint16, *array.Uint32, *array.Uint64:
+		vals := make([]int, l)
+		// every integer array has a Value(int) method returning its concrete integer type
+		v := reflect.ValueOf(a).MethodByName("Value")
+		for i := range vals {
+			n := v.Call([]reflect.Value{reflect.ValueOf(i)})[0]
+			if n.Kind() >= reflect.Uint && n.Kind() <= reflect.Uint64 {
+				vals[i] = int(n.Uint())
+			} else {
+				vals[i] = int(n.Int())
+			}
+		}
+		return vals, isNull, nil
+	case *array.Timestamp:
+		dtype := a.DataType().(*arrow.TimestampType)
+		loc := time.UTC
+		if dtype.TimeZone != "" {
+			var err error
+			loc, err = time.LoadLocation(dtype.TimeZone)
+			if err != nil {
+				return nil, nil, fmt.Errorf("loading timestamp location: %v", err)
+			}
+		}
+		unit := arrowUnitNanos(dtype.Unit)
+		vals := make([]time.Time, l)
+		for i := range vals {
+			if !isNull[i] {
+				vals[i] = time.Unix(0, int64(a.Value(i))*unit).In(loc)
+			}
+		}
+		return vals, isNull, nil
+	case *array.Date32:
+		vals := make([]civil.Date, l)
+		for i := range vals {
+			if !isNull[i] {
+				vals[i] = arrowEpoch.AddDays(int(a.Value(i)))
+			}
+		}
+		return vals, isNull, nil
+	case *array.Date64:
+		vals := make([]civil.Date, l)
+		for i := range vals {
+			if !isNull[i] {
+				vals[i] = civil.DateOf(time.Unix(0, int64(a.Value(i))*int64(time.Millisecond)).UTC())
+			}
+		}
+		return vals, isNull, nil
+	case *array.Time32:
+		unit := arrowUnitNanos(a.DataType().(*arrow.Time32Type).Unit)
+		vals := make([]civil.Time, l)
+		for i := range vals {
+			vals[i] = civil.TimeOf(time.Unix(0, int64(a.Value(i))*unit).UTC())
+		}
+		return vals, isNull, nil
+	case *array.Duration:
+		unit := arrowUnitNanos(a.DataType().(*arrow.DurationType).Unit)
+		vals := make([]time.Duration, l)
+		for i := range vals {
+			if !isNull[i] {
+				vals[i] = time.Duration(int64(a.Value(i)) * unit)
+			}
+		}
+		return vals, isNull, nil
+	case *array.Decimal128:
+		scale := a.DataType().(*arrow.Decimal128Type).Scale
+		pow := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(scale)), nil)
+		if scale < 0 {
+			pow.Exp(big.NewInt(10), big.NewInt(int64(-scale)), nil)
+		}
+		vals := make([]*big.Rat, l)
+		for i := range vals {
+			if isNull[i] {
+				vals[i] = new(big.Rat)
+				continue
+			}
+			vals[i] = new(big.Rat).SetInt(bigIntFromDecimal128(a.Value(i)))
+			if scale < 0 {
+				vals[i].Mul(vals[i], new(big.Rat).SetInt(pow))
+			} else {
+				vals[i].Quo(vals[i], new(big.Rat).SetInt(pow))
+			}
+		}
+		return vals, isNull, nil
+	case *array.Time64:
+		unit := arrowUnitNanos(a.DataType().(*arrow.Time64Type).Unit)
+		vals := make([]civil.Time, l)
+		for i := range vals {
+			vals[i] = civil.TimeOf(time.Unix(0, int64(a.Value(i))*unit).UTC())
+		}
+		return vals, isNull, nil
+	default:
+		return nil, nil, fmt.Errorf("unsupported arrow type (%v)", arr.DataType())
+	}
+}
+
+// -- Apache Parquet
+
+// writeParquet writes the labels and columns of df as optional columns in a single row group,
+// with DataFrame metadata stored in the file metadata.
+func writeParquet(w io.Writer, df *DataFrame) error {
+	containers := make([]*valueContainer, 0, len(df.labels)+len(df.values))
+	containers = append(containers, df.labels...)
+	containers = append(containers, df.values...)
+	root := &parquetschema.ColumnDefinition{
+		SchemaElement: &parquet.SchemaElement{Name: "tada"},
+	}
+	// values[k] holds one Parquet-typed value per row, or nil if the row is null
+	values := make([][]interface{}, len(containers))
+	for k := range containers {
+		var elem *parquet.SchemaElement
+		elem, values[k] = containers[k].parquetValues()
+		root.Children = append(root.Children, &parquetschema.ColumnDefinition{SchemaElement: elem})
+	}
+	metadata, err := df.metadata()
+	if err != nil {
+		return err
+	}
+	fw := goparquet.NewFileWriter(w,
+		goparquet.WithSchemaDefinition(parquetschema.SchemaDefinitionFromColumnDefinition(root)),
+		goparquet.WithMetaData(metadata),
+		goparquet.WithCreator("tada"),
+	)
+	for i := 0; i < df.Len(); i++ {
+		row := make(map[string]interface{}, len(containers))
+		for k := range containers {
+			if values[k][i] != nil {
+				row[containers[k].name] = values[k][i]
+			}
+		}
+		err := fw.AddData(row)
+		if err != nil {
+			return fmt.Errorf("row %d: %v", i, err)
+		}
+	}
+	return fw.Close()
+}
+
+// parquetValues returns an optional Parquet schema element matching the type of vc,
+// and the values of vc converted to the Go type expected by that element (nil if null).
+func (vc *valueContainer) parquetValues() (*parquet.SchemaElement, []interface{}) {
+	optional := parquet.FieldRepetitionType_OPTIONAL
+	elem := &parquet.SchemaElement{Name: vc.name, RepetitionType: &optional}
+	nanos := &parquet.TimeUnit{NANOS: parquet.NewNanoSeconds()}
+	ret := make([]interface{}, len(vc.isNull))
+	setType := func(t parquet.Type) {
+		elem.Type = &t
+	}
+	setStringType := func() {
+		setType(parquet.Type_BYTE_ARRAY)
+		convertedType := parquet.ConvertedType_UTF8
+		elem.ConvertedType = &convertedType
+		elem.LogicalType = &parquet.LogicalType{STRING: parquet.NewStringType()}
+		arr := vc.string().slice
+		for i := range arr {
+			ret[i] = []byte(arr[i])
+		}
+	}
+	switch vc.slice.(type) {
+	case []float64:
+		setType(parquet.Type_DOUBLE)
+		arr := vc.slice.([]float64)
+		for i := range arr {
+			ret[i] = arr[i]
+		}
+	case []bool:
+		setType(parquet.Type_BOOLEAN)
+		arr := vc.slice.([]bool)
+		for i := range arr {
+			ret[i] = arr[i]
+		}
+	case []time.Time:
+		setType(parquet.Type_INT64)
+		elem.LogicalType = &parquet.LogicalType{TIMESTAMP: &parquet.TimestampType{IsAdjustedToUTC: true, Unit: nanos}}
+		arr := vc.slice.([]time.Time)
+		for i := range arr {
+			ret[i] = arr[i].UnixNano()
+		}
+	case []civil.Date:
+		setType(parquet.Type_INT32)
+		convertedType := parquet.ConvertedType_DATE
+		elem.ConvertedType = &convertedType
+		elem.LogicalType = &parquet.LogicalType{DATE: parquet.NewDateType()}
+		arr := vc.slice.([]civil.Date)
+		for i := range arr {
+			ret[i] = int32(arr[i].DaysSince(arrowEpoch))
+		}
+	case []civil.Time:
+		setType(parquet.Type_INT64)
+		elem.LogicalType = &parquet.LogicalType{TIME: &parquet.TimeType{IsAdjustedToUTC: false, Unit: nanos}}
+		arr := vc.slice.([]civil.Time)
+		for i := range arr {
+			ret[i] = int64(arr[i].Hour)*int64(time.Hour) + int64(arr[i].Minute)*int64(time.Minute) +
+				int64(arr[i].Second)*int64(time.Second) + int64(arr[i].Nanosecond)
+		}
+	case []*big.Rat:
+		valid := make([]bool, len(vc.isNull))
+		for i := range vc.isNull {
+			valid[i] = !vc.isNull[i]
+		}
+		nums, scale, ok := decimal128Values(vc.slice.([]*big.Rat), valid)
+		if !ok {
+			// values that need more than arrowDecimalPrecision digits are written as strings
+			setStringType()
+			break
+		}
+		setType(parquet.Type_FIXED_LEN_BYTE_ARRAY)
+		typeLength, precision, scale32 := int32(16), int32(arrowDecimalPrecision), int32(scale)
+		elem.TypeLength = &typeLength
+		elem.Precision = &precision
+		elem.Scale = &scale32
+		convertedType := parquet.ConvertedType_DECIMAL
+		elem.ConvertedType = &convertedType
+		elem.LogicalType = &parquet.LogicalType{DECIMAL: &parquet.DecimalType{Precision: precision, Scale: scale32}}
+		for i := range nums {
+			// big-endian two's complement
+			b := make([]byte, 16)
+			binary.BigEndian.PutUint64(b[:8], uint64(nums[i].HighBits()))
+			binary.BigEndian.PutUint64(b[8:], nums[i].LowBits())
+			ret[i] = b
+		}
+	case []time.Duration:
+		// Parquet has no duration type, so durations are restored from the slice type in the file metadata
+		setType(parquet.Type_INT64)
+		arr := vc.slice.([]time.Duration)
+		for i := range arr {
+			ret[i] = int64(arr[i])
+		}
+	case []int, []int8, []int16, []int32, []int64, []uint, []uint8, []uint16, []uint32, []uint64:
+		setType(parquet.Type_INT64)
+		v := reflect.ValueOf(vc.slice)
+		for i := range ret {
+			if v.Index(i).Kind() >= reflect.Uint && v.Index(i).Kind() <= reflect.Uint64 {
+				ret[i] = int64(v.Index(i).Uint())
+			} else {
+				ret[i] = v.Index(i).Int()
+			}
+		}
+	default:
+		setStringType()
+	}
+	for i := range ret {
+		if vc.isNull[i] {
+			ret[i] = nil
+		}
+	}
+	return elem, ret
+}
+
+// readParquet reads the flat columns selected by columns (default: all) from every row group in fr.
+func readParquet(fr *goparquet.FileReader, columns []string, labelLevels int) (*DataFrame, error) {
+	schemaColumns := fr.Columns()
+	selected := make(map[string]bool, len(columns))
+	for _, name := range columns {
+		selected[name] = true
+	}
+	// copy the metadata so that the label levels can be updated after projection
+	metadata := make(map[string]string)
+	for k, v := range fr.MetaData() {
+		metadata[k] = v
+	}
+	numLabels, err := strconv.Atoi(metadata[metadataLabelLevels])
+	_, hasLabelLevels := metadata[metadataLabelLevels]
+	if hasLabelLevels && err != nil {
+		return nil, fmt.Errorf("invalid %s metadata (%s)", metadataLabelLevels, metadata[metadataLabelLevels])
+	}
+	fieldMeta, err := readFieldMetadata(metadata, len(schemaColumns))
+	if err != nil {
+		return nil, err
+	}
+	var cols []*goparquet.Column
+	// positions[k] is the position of cols[k] among all the columns in the file
+	var positions []int
+	var projectedLabels int
+	for k, col := range schemaColumns {
+		if len(columns) > 0 && !selected[col.FlatName()] {
+			continue
+		}
+		if col.FlatName() != col.Name() || col.MaxRepetitionLevel() > 0 {
+			return nil, fmt.Errorf("column %s: nested and repeated columns are not supported", col.FlatName())
+		}
+		delete(selected, col.FlatName())
+		if k < numLabels {
+			projectedLabels++
+		}
+		cols = append(cols, col)
+		positions = append(positions, k)
+	}
+	for name := range selected {
+		return nil, fmt.Errorf("column %s not in file", name)
+	}
+	if len(cols) == 0 {
+		return nil, fmt.Errorf("file must contain at least one column")
+	}
+	numRows := int(fr.NumRows())
+	values := make([][]interface{}, len(cols))
+	isNull := make([][]bool, len(cols))
+	for k := range cols {
+		values[k] = make([]interface{}, 0, numRows)
+		isNull[k] = make([]bool, 0, numRows)
+	}
+	for {
+		row, err := fr.NextRow()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("row %d: %v", len(values[0]), err)
+		}
+		for k := range cols {
+			v, ok := row[cols[k].Name()]
+			values[k] = append(values[k], v)
+			isNull[k] = append(isNull[k], !ok || v == nil)
+		}
+	}
+	containers := make([]*valueContainer, len(cols))
+	for k := range cols {
+		slice, err := convertParquetValues(cols[k].Element(), values[k], isNull[k])
+		if err != nil {
+			return nil, fmt.Errorf("column %s: %v", cols[k].Name(), err)
+		}
+		containers[k] = newValueContainer(slice, isNull[k], cols[k].Name())
+		if fieldMeta != nil {
+			err := containers[k].restoreType(fieldMeta[positions[k]])
+			if err != nil {
+				return nil, fmt.Errorf("column %s: %v", cols[k].Name(), err)
+			}
+		}
+	}
+	if hasLabelLevels {
+		metadata[metadataLabelLevels] = strconv.Itoa(projectedLabels)
+	}
+	return containersToDFFromMetadata(containers, metadata, labelLevels)
+}
+
+// convertParquetValues converts the values read from a Parquet column into a slice typed by the column's logical type.
+// Null values are converted to the zero value of the slice type.
+func convertParquetValues(elem *parquet.SchemaElement, values []interface{}, isNull []bool) (interface{}, error) {
+	lt := elem.GetLogicalType()
+	ct := elem.ConvertedType
+	hasConvertedType := func(types ...parquet.ConvertedType) bool {
+		for _, t := range types {
+			if ct != nil && *ct == t {
+				return true
+			}
+		}
+		return false
+	}
+	var unit int64
+	switch {
+	case lt != nil && lt.TIMESTAMP != nil:
+		unit = parquetUnitNanos(lt.TIMESTAMP.Unit)
+	case lt != nil && lt.TIME != nil:
+		unit = parquetUnitNanos(lt.TIME.Unit)
+	case hasConvertedType(parquet.ConvertedType_TIMESTAMP_MILLIS, parquet.ConvertedType_TIME_MILLIS):
+		unit = int64(time.Millisecond)
+	case hasConvertedType(parquet.ConvertedType_TIMESTAMP_MICROS, parquet.ConvertedType_TIME_MICROS):
+		unit = int64(time.Microsecond)
+	}
+	switch {
+	case (lt != nil && lt.DECIMAL != nil) || hasConvertedType(parquet.ConvertedType_DECIMAL):
+		pow := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(elem.GetScale())), nil)
+		ret := make([]*big.Rat, len(values))
+		for i := range values {
+			ret[i] = new(big.Rat)
+			if !isNull[i] {
+				n, err := parquetDecimalToBigInt(values[i])
+				if err != nil {
+					return nil, err
+				}
+				ret[i].SetFrac(n, pow)
+			}
+		}
+		return ret, nil
+	case (lt != nil && lt.DATE != nil) || hasConvertedType(parquet.ConvertedType_DATE):
+		ret := make([]civil.Date, len(values))
+		for i := range values {
+			if !isNull[i] {
+				ret[i] = arrowEpoch.AddDays(int(values[i].(int32)))
+			}
+		}
+		return ret, nil
+	case (lt != nil && lt.TIMESTAMP != nil) ||
+		hasConvertedType(parquet.ConvertedType_TIMESTAMP_MILLIS, parquet.ConvertedType_TIMESTAMP_MICROS):
+		ret := make([]time.Time, len(values))
+		for i := range values {
+			if !isNull[i] {
+				ret[i] = time.Unix(0, values[i].(int64)*unit).UTC()
+			}
+		}
+		return ret, nil
+	case (lt != nil && lt.TIME != nil) ||
+		hasConvertedType(parquet.ConvertedType_TIME_MILLIS, parquet.ConvertedType_TIME_MICROS):
+		ret := make([]civil.Time, len(values))
+		for i := range values {
+			if !isNull[i] {
+				var n int64
+				switch values[i].(type) {
+				case int32:
+					n = int64(values[i].(int32))
+				case int64:
+					n = values[i].(int64)
+				}
+				ret[i] = civil.TimeOf(time.Unix(0, n*unit).UTC())
+			}
+		}
+		return ret, nil
+	}
+	switch elem.GetType() {
+	case parquet.Type_BOOLEAN:
+		ret := make([]bool, len(values))
+		for i := range values {
+			if !isNull[i] {
+				ret[i] = values[i].(bool)
+			}
+		}
+		return ret, nil
+	case parquet.Type_INT32:
+		ret := make([]int, len(values))
+		for i := range values {
+			if !isNull[i] {
+				ret[i] = int(values[i].(int32))
+			}
+		}
+		return ret, nil
+	case parquet.Type_INT64:
+		ret := make([]int64, len(values))
+		for i := range values {
+			if !isNull[i] {
+				ret[i] = values[i].(int64)
+			}
+		}
+		return ret, nil
+	case parquet.Type_INT96:
+		ret := make([]time.Time, len(values))
+		for i := range values {
+			if !isNull[i] {
+				ret[i] = goparquet.Int96ToTime(values[i].([12]byte)).UTC()
+			}
+		}
+		return ret, nil
+	case parquet.Type_FLOAT:
+		ret := make([]float64, len(values))
+		for i := range values {
+			if !isNull[i] {
+				ret[i] = float64(values[i].(float32))
+			}
+		}
+		return ret, nil
+	case parquet.Type_DOUBLE:
+		ret := make([]float64, len(values))
+		for i := range values {
+			if !isNull[i] {
+				ret[i] = values[i].(float64)
+			}
+		}
+		return ret, nil
+	case parquet.Type_BYTE_ARRAY, parquet.Type_FIXED_LEN_BYTE_ARRAY:
+		ret := make([]string, len(values))
+		for i := range values {
+			if !isNull[i] {
+				ret[i] = string(values[i].([]byte))
+			}
+		}
+		return ret, nil
+	default:
+		return nil, fmt.Errorf("unsupported parquet type (%v)", elem.GetType())
+	}
+}
+
+// parquetUnitNanos returns the number of nanoseconds in one Parquet time unit.
+func parquetUnitNanos(unit *parquet.TimeUnit) int64 {
+	switch {
+	case unit.IsSetMILLIS():
+		return int64(time.Millisecond)
+	case unit.IsSetMICROS():
+		return int64(time.Microsecond)
+	default:
+		return 1
+	}
+}
+
+// parquetDecimalToBigInt converts the unscaled value of a Parquet decimal
+// (int32, int64, or big-endian two's complement byte array) to a *big.Int.
+func parquetDecimalToBigInt(v interface{}) (*big.Int, error) {
+	switch v.(type) {
+	case int32:
+		return big.NewInt(int64(v.(int32))), nil
+	case int64:
+		return big.NewInt(v.(int64)), nil
+	case []byte:
+		b := v.([]byte)
+		n := new(big.Int).SetBytes(b)
+		if len(b) > 0 && b[0]&0x80 != 0 {
+			n.Sub(n, new(big.Int).Lsh(big.NewInt(1), uint(len(b)*8)))
+		}
+		return n, nil
+	default:
+		return nil, fmt.Errorf("unsupported decimal value type (%T)", v)
+	}
+}
+
+// -- JSON
+
+// a jsonTable accumulates stringified JSON values into columns, in the order in which the columns are first encountered.
+type jsonTable struct {
+	names    []string
+	colIndex map[string]int
+	values   [][]string
+	isNull   [][]bool
+	numRows  int
+}
+
+func newJSONTable() *jsonTable {
+	return &jsonTable{colIndex: make(map[string]int)}
+}
+
+// addColumn adds a column that is null in all prior rows, if it does not already exist.
+func (t *jsonTable) addColumn(name string) {
+	if _, ok := t.colIndex[name]; ok {
+		return
+	}
+	t.colIndex[name] = len(t.names)
+	t.names = append(t.names, name)
+	nulls := make([]bool, t.numRows)
+	for i := range nulls {
+		nulls[i] = true
+	}
+	t.values = append(t.values, make([]string, t.numRows))
+	t.isNull = append(t.isNull, nulls)
+}
+
+// addRow adds a row from a flattened JSON object. Keys missing from row are null.
+func (t *jsonTable) addRow(keys []string, row map[string]string) {
+	for _, key := range keys {
+		t.addColumn(key)
+	}
+	for k := range t.names {
+		v, ok := row[t.names[k]]
+		t.values[k] = append(t.values[k], v)
+		t.isNull[k] = append(t.isNull[k], !ok)
+	}
+	t.numRows++
+}
+
+func (t *jsonTable) containers() ([]*valueContainer, error) {
+	if t.numRows == 0 {
+		return nil, fmt.Errorf("must have at least one row")
+	}
+	if len(t.names) == 0 {
+		return nil, fmt.Errorf("must have at least one key")
+	}
+	ret := make([]*valueContainer, len(t.names))
+	for k := range t.names {
+		ret[k] = newValueContainer(t.values[k], t.isNull[k], t.names[k])
+	}
+	return ret, nil
+}
+
+// readJSONLines reads one row per JSON object in r and returns one []string container per flattened key.
+func readJSONLines(r io.Reader) ([]*valueContainer, error) {
+	br := bufio.NewReader(r)
+	table := newJSONTable()
+	for lineNumber := 1; ; lineNumber++ {
+		line, err := br.ReadBytes('\n')
+		if err != nil && err != io.EOF {
+			return nil, fmt.Errorf("line %d: %v", lineNumber, err)
+		}
+		line = bytes.TrimSpace(line)
+		if len(line) > 0 {
+			keys, row, readErr := readJSONRow(line)
+			if readErr != nil {
+				return nil, fmt.Errorf("line %d: %v", lineNumber, readErr)
+			}
+			table.addRow(keys, row)
+		}
+		if err == io.EOF {
+			break
+		}
+	}
+	return table.containers()
+}
+
+// readJSONRow flattens the JSON object in b and returns its keys in order and its stringified non-null values.
+func readJSONRow(b []byte) ([]string, map[string]string, error) {
+	var keys []string
+	row := make(map[string]string)
+	err := flattenJSONObject(b, nil, func(name string, raw json.RawMessage) error {
+		keys = append(keys, name)
+		if s, isNull := stringifyJSON(raw); !isNull {
+			row[name] = s
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	return keys, row, nil
+}
+
+// iterateJSONObject calls fn with each key and raw value of the JSON object in b, in the order in which they appear.
+func iterateJSONObject(b []byte, fn func(key string, raw json.RawMessage) error) error {
+	dec := json.NewDecoder(bytes.NewReader(b))
+	t, err := dec.Token()
+	if err != nil {
+		return err
+	}
+	if delim, ok := t.(json.Delim); !ok || delim != '{' {
+		return fmt.Errorf("must be a JSON object")
+	}
+	for dec.More() {
+		t, err := dec.Token()
+		if err != nil {
+			return err
+		}
+		var raw json.RawMessage
+		err = dec.Decode(&raw)
+		if err != nil {
+			return err
+		}
+		err = fn(t.(string), raw)
+		if err != nil {
+			return err
+		}
+	}
+	_, err = dec.Token()
+	if err != nil {
+		return err
+	}
+	if dec.More() {
+		return fmt.Errorf("unexpected data after JSON object")
+	}
+	return nil
+}
+
+// flattenJSONObject calls fn with each non-object value nested within the JSON object in b,
+// named by prefix and its nested keys joined by the level separator.
+func flattenJSONObject(b []byte, prefix []string, fn func(name string, raw json.RawMessage) error) error {
+	return iterateJSONObject(b, func(key string, raw json.RawMessage) error {
+		levels := append(append([]string{}, prefix...), key)
+		if raw[0] == '{' {
+			return flattenJSONObject(raw, levels, fn)
+		}
+		return fn(joinLevelsIntoName(levels), raw)
+	})
+}
+
+// stringifyJSON converts a raw JSON value to a string: strings are unquoted, and all other values are compacted.
+// Returns true if the value is null.
+func stringifyJSON(raw json.RawMessage) (string, bool) {
+	switch raw[0] {
+	case 'n':
+		return "", true
+	case '"':
+		var s string
+		json.Unmarshal(raw, &s)
+		return s, false
+	default:
+		compact := new(bytes.Buffer)
+		json.Compact(compact, raw)
+		return compact.String(), false
+	}
+}
+
+// stringifyJSONArray converts a raw JSON array to a slice of strings and whether each value is null.
+func stringifyJSONArray(raw json.RawMessage) ([]string, []bool, error) {
+	var arr []json.RawMessage
+	err := json.Unmarshal(raw, &arr)
+	if err != nil {
+		return nil, nil, err
+	}
+	values := make([]string, len(arr))
+	isNull := make([]bool, len(arr))
+	for i := range arr {
+		values[i], isNull[i] = stringifyJSON(arr[i])
+	}
+	return values, isNull, nil
+}
+
+// padColLevels ensures that every column has the same number of levels as the column with the most levels,
+// padding shorter names with empty levels.
+func (df *DataFrame) padColLevels() {
+	var maxLevels int
+	for k := range df.values {
+		if n := len(splitNameIntoLevels(df.values[k].name)); n > maxLevels {
+			maxLevels = n
+		}
+	}
+	if maxLevels <= 1 {
+		return
+	}
+	for k := range df.values {
+		levels := splitNameIntoLevels(df.values[k].name)
+		for len(levels) < maxLevels {
+			levels = append(levels, "")
+		}
+		df.values[k].name = joinLevelsIntoName(levels)
+	}
+	df.colLevelNames = make([]string, maxLevels)
+	for l := range df.colLevelNames {
+		df.colLevelNames[l] = fmt.Sprintf("*%d", l)
+	}
+}
+
+// readJSONRecords reads a JSON array of objects, with one row per object.
+func readJSONRecords(b []byte) ([]*valueContainer, error) {
+	var records []json.RawMessage
+	err := json.Unmarshal(b, &records)
+	if err != nil {
+		return nil, err
+	}
+	table := newJSONTable()
+	for i := range records {
+		keys, row, err := readJSONRow(records[i])
+		if err != nil {
+			return nil, fmt.Errorf("record %d: %v", i, err)
+		}
+		table.addRow(keys, row)
+	}
+	return table.containers()
+}
+
+// readJSONColumns reads a JSON object of arrays, with one column per (flattened) key.
+func readJSONColumns(b []byte) ([]*valueContainer, error) {
+	var ret []*valueContainer
+	err := flattenJSONObject(b, nil, func(name string, raw json.RawMessage) error {
+		values, isNull, err := stringifyJSONArray(raw)
+		if err != nil {
+			return fmt.Errorf("column %s: %v", name, err)
+		}
+		if len(ret) > 0 && len(values) != ret[0].len() {
+			return fmt.Errorf("column %s: length (%d) does not match column %s (%d)",
+				name, len(values), ret[0].name, ret[0].len())
+		}
+		ret = append(ret, newValueContainer(values, isNull, name))
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(ret) == 0 {
+		return nil, fmt.Errorf("must have at least one column")
+	}
+	return ret, nil
+}
+
+// readJSONIndex reads a JSON object of objects, with one row per key.
+// Each key is split by the level separator into label levels.
+func readJSONIndex(b []byte) (labels []*valueContainer, values []*valueContainer, err error) {
+	table := newJSONTable()
+	var keys [][]string
+	err = iterateJSONObject(b, func(key string, raw json.RawMessage) error {
+		levels := splitNameIntoLevels(key)
+		if len(keys) > 0 && len(levels) != len(keys[0]) {
+			return fmt.Errorf("index %s: number of levels (%d) does not match first index (%d)",
+				key, len(levels), len(keys[0]))
+		}
+		rowKeys, row, err := readJSONRow(raw)
+		if err != nil {
+			return fmt.Errorf("index %s: %v", key, err)
+		}
+		keys = append(keys, levels)
+		table.addRow(rowKeys, row)
+		return nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	values, err = table.containers()
+	if err != nil {
+		return nil, nil, err
+	}
+	labels = make([]*valueContainer, len(keys[0]))
+	for j := range labels {
+		slice := make([]string, len(keys))
+		for i := range keys {
+			slice[i] = keys[i][j]
+		}
+		labels[j] = newValueContainer(slice, make([]bool, len(slice)), fmt.Sprintf("*%d", j))
+	}
+	return labels, values, nil
+}
+
+// jsonSplit is the split orientation of a DataFrame in JSON.
+type jsonSplit struct {
+	Name       string              `json:"name"`
+	LabelNames []string            `json:"labelNames"`
+	Columns    []json.RawMessage   `json:"columns"`
+	Labels     [][]json.RawMessage `json:"labels"`
+	Data       [][]json.RawMessage `json:"data"`
+}
+
+// readJSONSplit reads the split orientation. Column names may be either strings or arrays of levels.
+func readJSONSplit(b []byte) (labels []*valueContainer, values []*valueContainer, name string, err error) {
+	var split jsonSplit
+	err = json.Unmarshal(b, &split)
+	if err != nil {
+		return nil, nil, "", err
+	}
+	if len(split.Columns) == 0 {
+		return nil, nil, "", fmt.Errorf("must have at least one column")
+	}
+	if len(split.Labels) != len(split.Data) {
+		return nil, nil, "", fmt.Errorf("number of label rows (%d) does not match number of data rows (%d)",
+			len(split.Labels), len(split.Data))
+	}
+	names := make([]string, len(split.Columns))
+	for k := range split.Columns {
+		var levels []string
+		if json.Unmarshal(split.Columns[k], &levels) == nil {
+			names[k] = joinLevelsIntoName(levels)
+		} else if err := json.Unmarshal(split.Columns[k], &names[k]); err != nil {
+			return nil, nil, "", fmt.Errorf("column %d: name must be a string or an array of strings", k)
+		}
+	}
+	labels, err = splitRowsToContainers(split.Labels, split.LabelNames)
+	if err != nil {
+		return nil, nil, "", fmt.Errorf("labels: %v", err)
+	}
+	values, err = splitRowsToContainers(split.Data, names)
+	if err != nil {
+		return nil, nil, "", fmt.Errorf("data: %v", err)
+	}
+	return labels, values, split.Name, nil
+}
+
+// splitRowsToContainers converts rows of raw JSON values into one []string container per name.
+func splitRowsToContainers(rows [][]json.RawMessage, names []string) ([]*valueContainer, error) {
+	ret := make([]*valueContainer, len(names))
+	for k := range names {
+		slice := make([]string, len(rows))
+		isNull := make([]bool, len(rows))
+		for i := range rows {
+			if len(rows[i]) != len(names) {
+				return nil, fmt.Errorf("row %d: number of values (%d) does not match number of names (%d)",
+					i, len(rows[i]), len(names))
+			}
+			slice[i], isNull[i] = stringifyJSON(rows[i][k])
+		}
+		ret[k] = newValueContainer(slice, isNull, names[k])
+	}
+	return ret, nil
+}
+
+// jsonNode is a key in a nested JSON object. Leaf nodes refer to a container by position.
+type jsonNode struct {
+	key       string
+	container int
+	children  []*jsonNode
+}
+
+// child returns the child of n with key, creating it if necessary.
+func (n *jsonNode) child(key string) *jsonNode {
+	for _, c := range n.children {
+		if c.key == key {
+			return c
+		}
+	}
+	c := &jsonNode{key: key, container: -1}
+	n.children = append(n.children, c)
+	return c
+}
+
+// makeJSONTree returns the tree of keys for containers.
+// If nested, multi-level names are split into nested keys and empty trailing levels are ignored.
+func makeJSONTree(containers []*valueContainer, nested bool) (*jsonNode, error) {
+	root := &jsonNode{container: -1}
+	for k := range containers {
+		levels := []string{containers[k].name}
+		if nested {
+			levels = splitNameIntoLevels(containers[k].name)
+			for len(levels) > 1 && levels[len(levels)-1] == "" {
+				levels = levels[:len(levels)-1]
+			}
+		}
+		node := root
+		for _, level := range levels {
+			if node.container != -1 {
+				return nil, fmt.Errorf("column %s: conflicts with column %s", containers[k].name, containers[node.container].name)
+			}
+			node = node.child(level)
+		}
+		if node.container != -1 || len(node.children) > 0 {
+			return nil, fmt.Errorf("column %s: duplicate or conflicting name", containers[k].name)
+		}
+		node.container = k
+	}
+	return root, nil
+}
+
+// writeJSON writes n as an object of its children, or calls leaf with the container position if n is a leaf.
+func (n *jsonNode) writeJSON(w *bufio.Writer, leaf func(container int) error) error {
+	if n.container != -1 {
+		return leaf(n.container)
+	}
+	w.WriteByte('{')
+	for j, c := range n.children {
+		if j > 0 {
+			w.WriteByte(',')
+		}
+		key, _ := json.Marshal(c.key)
+		w.Write(key)
+		w.WriteByte(':')
+		err := c.writeJSON(w, leaf)
+		if err != nil {
+			return err
+		}
+	}
+	w.WriteByte('}')
+	return nil
+}
+
+// writeJSONValue writes the value of containers[k] at row i.
+func writeJSONValue(w *bufio.Writer, containers []*valueContainer, k int, i int, timeLayout string) error {
+	b, err := json.Marshal(containers[k].jsonValue(i, timeLayout))
+	if err != nil {
+		return fmt.Errorf("column %s: %v", containers[k].name, err)
+	}
+	w.Write(b)
+	return nil
+}
+
+// writeJSONRows writes each row of containers as a JSON object, separated by sep.
+func writeJSONRows(w *bufio.Writer, containers []*valueContainer, nested bool, timeLayout string, sep string) error {
+	root, err := makeJSONTree(containers, nested)
+	if err != nil {
+		return err
+	}
+	var numRows int
+	if len(containers) > 0 {
+		numRows = containers[0].len()
+	}
+	for i := 0; i < numRows; i++ {
+		if i > 0 {
+			w.WriteString(sep)
+		}
+		err := root.writeJSON(w, func(k int) error {
+			return writeJSONValue(w, containers, k, i, timeLayout)
+		})
+		if err != nil {
+			return fmt.Errorf("row %d: %v", i, err)
+		}
+	}
+	return nil
+}
+
+// writeJSONLines writes each row of containers as a JSON object on its own line.
+func writeJSONLines(w io.Writer, containers []*valueContainer) error {
+	bw := bufio.NewWriter(w)
+	err := writeJSONRows(bw, containers, true, time.RFC3339Nano, "\n")
+	if err != nil {
+		return err
+	}
+	if len(containers) > 0 && containers[0].len() > 0 {
+		bw.WriteByte('\n')
+	}
+	return bw.Flush()
+}
+
+// writeJSONRecords writes an array with one JSON object per row.
+func writeJSONRecords(w *bufio.Writer, containers []*valueContainer, nested bool, timeLayout string) error {
+	w.WriteByte('[')
+	err := writeJSONRows(w, containers, nested, timeLayout, ",")
+	if err != nil {
+		return err
+	}
+	w.WriteByte(']')
+	return nil
+}
+
+// writeJSONColumns writes an object with one array of values per column.
+func writeJSONColumns(w *bufio.Writer, containers []*valueContainer, nested bool, timeLayout string) error {
+	root, err := makeJSONTree(containers, nested)
+	if err != nil {
+		return err
+	}
+	return root.writeJSON(w, func(k int) error {
+		w.WriteByte('[')
+		for i := 0; i < containers[k].len(); i++ {
+			if i > 0 {
+				w.WriteByte(',')
+			}
+			err := writeJSONValue(w, containers, k, i, timeLayout)
+			if err != nil {
+				return fmt.Errorf("row %d: %v", i, err)
+			}
+		}
+		w.WriteByte(']')
+		return nil
+	})
+}
+
+// writeJSONIndex writes an object with one JSON object per row, keyed by the row's concatenated label values.
+func writeJSONIndex(w *bufio.Writer, df *DataFrame, nested bool, timeLayout string) error {
+	root, err := makeJSONTree(df.values, nested)
+	if err != nil {
+		return err
+	}
+	keys := concatenateLabelsToStringsBytes(df.labels)
+	seen := make(map[string]bool, len(keys))
+	w.WriteByte('{')
+	for i := range keys {
+		if seen[keys[i]] {
+			return fmt.Errorf("row %d: duplicate label (%s)", i, keys[i])
+		}
+		seen[keys[i]] = true
+		if i > 0 {
+			w.WriteByte(',')
+		}
+		key, _ := json.Marshal(keys[i])
+		w.Write(key)
+		w.WriteByte(':')
+		err := root.writeJSON(w, func(k int) error {
+			return writeJSONValue(w, df.values, k, i, timeLayout)
+		})
+		if err != nil {
+			return fmt.Errorf("row %d: %v", i, err)
+		}
+	}
+	w.WriteByte('}')
+	return nil
+}
+
+// writeJSONSplit writes an object with the DataFrame name, label names, column names, and rows of labels and values.
+// If nested, each column name is written as an array of its levels.
+func writeJSONSplit(w *bufio.Writer, df *DataFrame, nested bool, timeLayout string) error {
+	split := jsonSplit{
+		Name:       df.name,
+		LabelNames: make([]string, len(df.labels)),
+		Columns:    make([]json.RawMessage, len(df.values)),
+		Labels:     make([][]json.RawMessage, df.Len()),
+		Data:       make([][]json.RawMessage, df.Len()),
+	}
+	for j := range df.labels {
+		split.LabelNames[j] = df.labels[j].name
+	}
+	for k := range df.values {
+		var name interface{} = df.values[k].name
+		if nested {
+			name = splitNameIntoLevels(df.values[k].name)
+		}
+		split.Columns[k], _ = json.Marshal(name)
+	}
+	rows := func(containers []*valueContainer, dst [][]json.RawMessage) error {
+		for i := range dst {
+			dst[i] = make([]json.RawMessage, len(containers))
+			for k := range containers {
+				b, err := json.Marshal(containers[k].jsonValue(i, timeLayout))
+				if err != nil {
+					return fmt.Errorf("row %d: column %s: %v", i, containers[k].name, err)
+				}
+				dst[i][k] = b
+			}
+		}
+		return nil
+	}
+	err := rows(df.labels, split.Labels)
+	if err != nil {
+		return err
+	}
+	err = rows(df.values, split.Data)
+	if err != nil {
+		return err
+	}
+	b, err := json.Marshal(split)
+	if err != nil {
+		return err
+	}
+	w.Write(b)
+	return nil
+}
+
+// jsonValue returns the value at row i as a value that encodes to JSON, or nil if null.
+// time.Time values are formatted with timeLayout.
+func (vc *valueContainer) jsonValue(i int, timeLayout string) interface{} {
+	if vc.isNull[i] {
+		return nil
+	}
+	switch arr := vc.slice.(type) {
+	case []time.Time:
+		return arr[i].Format(timeLayout)
+	case []civil.Date:
+		return arr[i].String()
+	case []civil.Time:
+		return arr[i].String()
+	case []Category:
+		return arr[i].String()
+	case []*big.Rat:
+		return json.Number(formatDecimal(arr[i]))
+	case []time.Duration:
+		return arr[i].String()
+	default:
+		return reflect.ValueOf(vc.slice).Index(i).Interface()
+	}
+}
+
+// -- database/sql
+
+// readSQLRows scans every row in rows into one container per column, typed by sqlScanDest.
+func readSQLRows(rows *sql.Rows) ([]*valueContainer, error) {
+	columnTypes, err := rows.ColumnTypes()
+	if err != nil {
+		return nil, err
+	}
+	dest := make([]interface{}, len(columnTypes))
+	slices := make([]reflect.Value, len(columnTypes))
+	isNull := make([][]bool, len(columnTypes))
+	for k := range columnTypes {
+		dest[k] = sqlScanDest(columnTypes[k])
+		slices[k] = reflect.MakeSlice(reflect.SliceOf(reflect.TypeOf(sqlValue(dest[k]))), 0, 0)
+	}
+	for i := 0; rows.Next(); i++ {
+		err := rows.Scan(dest...)
+		if err != nil {
+			return nil, fmt.Errorf("row %d: %v", i, err)
+		}
+		for k := range dest {
+			slices[k] = reflect.Append(slices[k], reflect.ValueOf(sqlValue(dest[k])))
+			isNull[k] = append(isNull[k], !sqlValid(dest[k]))
+		}
+	}
+	err = rows.Err()
+	if err != nil {
+		return nil, err
+	}
+	ret := make([]*valueContainer, len(columnTypes))
+	for k := range columnTypes {
+		if isNull[k] == nil {
+			isNull[k] = []bool{}
+		}
+		ret[k] = newValueContainer(slices[k].Interface(), isNull[k], columnTypes[k].Name())
+	}
+	return ret, nil
+}
+
+var (
+	sqlNullFloat64Type = reflect.TypeOf(sql.NullFloat64{})
+	sqlNullInt64Type   = reflect.TypeOf(sql.NullInt64{})
+	sqlNullInt32Type   = reflect.TypeOf(sql.NullInt32{})
+	sqlNullBoolType    = reflect.TypeOf(sql.NullBool{})
+	sqlNullTimeType    = reflect.TypeOf(sql.NullTime{})
+	sqlNullStringType  = reflect.TypeOf(sql.NullString{})
+	timeType           = reflect.TypeOf(time.Time{})
+)
+
+// sqlScanDest returns a pointer to the sql.Null* type that matches the scan type of ct
+// or, if the driver does not report a concrete scan type, its database type name.
+func sqlScanDest(ct *sql.ColumnType) interface{} {
+	st := ct.ScanType()
+	if st != nil {
+		switch st {
+		case sqlNullFloat64Type:
+			return new(sql.NullFloat64)
+		case sqlNullInt64Type, sqlNullInt32Type:
+			return new(sql.NullInt64)
+		case sqlNullBoolType:
+			return new(sql.NullBool)
+		case sqlNullTimeType, timeType:
+			return new(sql.NullTime)
+		case sqlNullStringType:
+			return new(sql.NullString)
+		}
+		switch st.Kind() {
+		case reflect.Float32, reflect.Float64:
+			return new(sql.NullFloat64)
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+			reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			return new(sql.NullInt64)
+		case reflect.Bool:
+			return new(sql.NullBool)
+		case reflect.String, reflect.Slice:
+			return new(sql.NullString)
+		}
+	}
+	switch strings.ToUpper(ct.DatabaseTypeName()) {
+	case "FLOAT", "FLOAT4", "FLOAT8", "REAL", "DOUBLE", "DOUBLE PRECISION", "NUMERIC", "DECIMAL":
+		return new(sql.NullFloat64)
+	case "INT", "INT2", "INT4", "INT8", "INTEGER", "TINYINT", "SMALLINT", "MEDIUMINT", "BIGINT",
+		"SERIAL", "BIGSERIAL":
+		return new(sql.NullInt64)
+	case "BOOL", "BOOLEAN":
+		return new(sql.NullBool)
+	case "DATE", "DATETIME", "TIMESTAMP", "TIMESTAMPTZ":
+		return new(sql.NullTime)
+	default:
+		return new(sql.NullString)
+	}
+}
+
+// sqlValue returns the value held by a sql.Null* pointer returned by sqlScanDest.
+// sql.NullInt64 values are returned as int.
+func sqlValue(dest interface{}) interface{} {
+	switch v := dest.(type) {
+	case *sql.NullFloat64:
+		return v.Float64
+	case *sql.NullInt64:
+		return int(v.Int64)
+	case *sql.NullBool:
+		return v.Bool
+	case *sql.NullTime:
+		return v.Time
+	default:
+		return dest.(*sql.NullString).String
+	}
+}
+
+// sqlValid returns whether a sql.Null* pointer returned by sqlScanDest holds a non-NULL value.
+func sqlValid(dest interface{}) bool {
+	switch v := dest.(type) {
+	case *sql.NullFloat64:
+		return v.Valid
+	case *sql.NullInt64:
+		return v.Valid
+	case *sql.NullBool:
+		return v.Valid
+	case *sql.NullTime:
+		return v.Valid
+	default:
+		return dest.(*sql.NullString).Valid
+	}
+}
+
+// writeSQL creates w.Table (if w.CreateTable) and inserts every row of containers in batches,
+// either executing each statement with w.db or rendering it to w.w.
+func writeSQL(w *SQLWriter, containers []*valueContainer) error {
+	if len(containers) == 0 {
+		return fmt.Errorf("must have at least one column")
+	}
+	table := w.Dialect.quoteTable(w.Table)
+	names := make([]string, len(containers))
+	for k := range containers {
+		names[k] = w.Dialect.quote(containers[k].name)
+	}
+	if w.CreateTable {
+		columns := make([]string, len(containers))
+		for k := range containers {
+			columns[k] = names[k] + " " + w.Dialect.columnType(containers[k].slice)
+		}
+		query := fmt.Sprintf("CREATE TABLE %s (%s)", table, strings.Join(columns, ", "))
+		err := w.execSQL(query, nil)
+		if err != nil {
+			return fmt.Errorf("creating table: %v", err)
+		}
+	}
+	batchSize := w.BatchSize
+	if w.db != nil {
+		// each row in a batch uses one placeholder per column
+		maxRows := w.Dialect.maxPlaceholders() / len(containers)
+		if maxRows == 0 {
+			return fmt.Errorf("number of columns (%d) exceeds maximum number of placeholders per statement (%d)",
+				len(containers), w.Dialect.maxPlaceholders())
+		}
+		if batchSize > maxRows {
+			batchSize = maxRows
+		}
+	}
+	numRows := containers[0].len()
+	prefix := fmt.Sprintf("INSERT INTO %s (%s) VALUES ", table, strings.Join(names, ", "))
+	for start := 0; start < numRows; start += batchSize {
+		end := start + batchSize
+		if end > numRows {
+			end = numRows
+		}
+		var b strings.Builder
+		b.WriteString(prefix)
+		var args []interface{}
+		for i := start; i < end; i++ {
+			if i > start {
+				b.WriteString(", ")
+			}
+			b.WriteByte('(')
+			for k := range containers {
+				if k > 0 {
+					b.WriteString(", ")
+				}
+				if w.db != nil {
+					args = append(args, containers[k].sqlArg(i))
+					b.WriteString(w.Dialect.placeholder(len(args)))
+				} else {
+					b.WriteString(w.Dialect.literal(containers[k].sqlArg(i)))
+				}
+			}
+			b.WriteByte(')')
+		}
+		err := w.execSQL(b.String(), args)
+		if err != nil {
+			return fmt.Errorf("inserting rows %d-%d: %v", start, end-1, err)
+		}
+	}
+	return nil
+}
+
+// execSQL executes query with w.db or, if w.db is nil, writes it to w.w as a statement in a script.
+func (w *SQLWriter) execSQL(query string, args []interface{}) error {
+	if w.db != nil {
+		_, err := w.db.Exec(query, args...)
+		return err
+	}
+	_, err := io.WriteString(w.w, query+";\n")
+	return err
+}
+
+// maxPlaceholders returns the maximum number of placeholders allowed in a single statement.
+func (dialect SQLDialect) maxPlaceholders() int {
+	if dialect == SQLSQLite {
+		// SQLite versions before 3.32.0 allow 999
+		return 999
+	}
+	return 65535
+}
+
+// quote quotes an SQL identifier.
+func (dialect SQLDialect) quote(name string) string {
+	if dialect == SQLMySQL {
+		return "`" + strings.Replace(name, "`", "``", -1) + "`"
+	}
+	return `"` + strings.Replace(name, `"`, `""`, -1) + `"`
+}
+
+// quoteTable quotes each dot-separated part of a (possibly schema-qualified) table name.
+func (dialect SQLDialect) quoteTable(table string) string {
+	parts := strings.Split(table, ".")
+	for i := range parts {
+		parts[i] = dialect.quote(parts[i])
+	}
+	return strings.Join(parts, ".")
+}
+
+// placeholder returns the placeholder for the nth (starting at 1) argument of a statement.
+func (dialect SQLDialect) placeholder(n int) string {
+	if dialect == SQLPostgres {
+		return "$" + strconv.Itoa(n)
+	}
+	return "?"
+}
+
+// columnType returns the column type used to store the values in slice.
+func (dialect SQLDialect) columnType(slice interface{}) string {
+	switch slice.(type) {
+	case []float64, []float32:
+		switch dialect {
+		case SQLPostgres:
+			return "DOUBLE PRECISION"
+		case SQLMySQL:
+			return "DOUBLE"
+		default:
+			return "REAL"
+		}
+	case []int, []int8, []int16, []int32, []int64, []uint, []uint8, []uint16, []uint32, []uint64:
+		if dialect == SQLSQLite {
+			return "INTEGER"
+		}
+		return "BIGINT"
+	case []bool:
+		return "BOOLEAN"
+	case []*big.Rat:
+		return "NUMERIC"
+	case []time.Time:
+		switch dialect {
+		case SQLPostgres:
+			return "TIMESTAMP WITH TIME ZONE"
+		case SQLMySQL:
+			return "DATETIME(6)"
+		default:
+			return "TIMESTAMP"
+		}
+	case []civil.Date:
+		return "DATE"
+	case []civil.Time:
+		switch dialect {
+		case SQLPostgres:
+			return "TIME"
+		case SQLMySQL:
+			return "TIME(6)"
+		default:
+			return "TEXT"
+		}
+	default:
+		return "TEXT"
+	}
+}
+
+// sqlArg returns the value at row i as a database/sql argument, or nil if null.
+// NaN and infinite floats are also nil, because they are not valid SQL literals and most databases reject them.
+// Integers are converted to int64, civil.Date and civil.Time values to strings,
+// and unsupported types to their string representation.
+func (vc *valueContainer) sqlArg(i int) interface{} {
+	if vc.isNull[i] {
+		return nil
+	}
+	switch arr := vc.slice.(type) {
+	case []float64:
+		return sqlFloat(arr[i])
+	case []float32:
+		return sqlFloat(float64(arr[i]))
+	case []bool:
+		return arr[i]
+	case []string:
+		return arr[i]
+	case []time.Time:
+		return arr[i]
+	case []civil.Date:
+		return arr[i].String()
+	case []civil.Time:
+		return arr[i].String()
+	case []*big.Rat:
+		return formatDecimal(arr[i])
+	case []int, []int8, []int16, []int32, []int64:
+		return reflect.ValueOf(arr).Index(i).Int()
+	case []uint, []uint8, []uint16, []uint32, []uint64:
+		return int64(reflect.ValueOf(arr).Index(i).Uint())
+	default:
+		return fmt.Sprint(reflect.ValueOf(arr).Index(i).Interface())
+	}
+}
+
+// sqlFloat returns v, or nil if v is NaN or infinite.
+func sqlFloat(v float64) interface{} {
+	if math.IsNaN(v) || math.IsInf(v, 0) {
+		return nil
+	}
+	return v
+}
+
+// literal renders a value returned by sqlArg as an SQL literal.
+func (dialect SQLDialect) literal(v interface{}) string {
+	switch v := v.(type) {
+	case nil:
+		return "NULL"
+	case float64:
+		return strconv.FormatFloat(v, 'g', -1, 64)
+	case int64:
+		return strconv.FormatInt(v, 10)
+	case bool:
+		if v {
+			return "TRUE"
+		}
+		return "FALSE"
+	case time.Time:
+		if dialect == SQLMySQL {
+			return "'" + v.UTC().Format("2006-01-02 15:04:05.999999") + "'"
+		}
+		return "'" + v.Format("2006-01-02 15:04:05.999999999-07:00") + "'"
+	default:
+		s := strings.Replace(v.(string), "'", "''", -1)
+		if dialect == SQLMySQL {
+			s = strings.Replace(s, `\`, `\\`, -1)
+		}
+		return "'" + s + "'"
+	}
+}
+
+// -- compression
+
+var (
+	gzipMagic  = []byte{0x1f, 0x8b}
+	zstdMagic  = []byte{0x28, 0xb5, 0x2f, 0xfd}
+	bzip2Magic = []byte("BZh")
+	zipMagic   = []byte("PK\x03\x04")
+)
+
+// detectCompression returns the Compression whose magic bytes begin br, or NoCompression if none match.
+func detectCompression(br *bufio.Reader) Compression {
+	magic, _ := br.Peek(4)
+	switch {
+	case bytes.HasPrefix(magic, gzipMagic):
+		return Gzip
+	case bytes.HasPrefix(magic, zstdMagic):
+		return Zstd
+	// the bzip2 magic is followed by the block size, from '1' to '9'
+	case bytes.HasPrefix(magic, bzip2Magic) && len(magic) == 4 && magic[3] >= '1' && magic[3] <= '9':
+		return Bzip2
+	default:
+		return NoCompression
+	}
+}
+
+// compressionFromExtension returns the Compression indicated by the extension of path, or NoCompression if none match.
+func compressionFromExtension(path string) Compression {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".gz", ".gzip":
+		return Gzip
+	case ".zst", ".zstd":
+		return Zstd
+	case ".bz2", ".bzip2":
+		return Bzip2
+	default:
+		return NoCompression
+	}
+}
+
+// newDecompressor returns a reader that decompresses r with compression.
+func newDecompressor(r io.Reader, compression Compression) (io.ReadCloser, error) {
+	switch compression {
+	case NoCompression:
+		return ioutil.NopCloser(r), nil
+	case Gzip:
+		return gzip.NewReader(r)
+	case Zstd:
+		zr, err := zstd.NewReader(r)
+		if err != nil {
+			return nil, err
+		}
+		return zr.IOReadCloser(), nil
+	case Bzip2:
+		return ioutil.NopCloser(bzip2.NewReader(r)), nil
+	default:
+		return nil, fmt.Errorf("unsupported compression (%v)", compression)
+	}
+}
+
+// newCompressor returns a writer that compresses to w with compression. It must be closed to flush the compressed stream.
+func newCompressor(w io.Writer, compression Compression) (io.WriteCloser, error) {
+	switch compression {
+	case Gzip:
+		return gzip.NewWriter(w), nil
+	case Zstd:
+		return zstd.NewWriter(w)
+	case Bzip2:
+		return dsnetbzip2.NewWriter(w, nil)
+	default:
+		return nil, fmt.Errorf("unsupported compression (%v)", compression)
+	}
+}
+
+// a multiCloser reads from an io.Reader and closes each of closers in order.
+type multiCloser struct {
+	io.Reader
+	closers []io.Closer
+}
+
+func (mc *multiCloser) Close() error {
+	var ret error
+	for _, c := range mc.closers {
+		if err := c.Close(); err != nil && ret == nil {
+			ret = err
+		}
+	}
+	return ret
+}
+
+// readTar reads each regular file in tr with newReader.
+func readTar(r io.Reader, newReader func(io.Reader) Reader) (map[string]*DataFrame, error) {
+	tr := tar.NewReader(r)
+	ret := make(map[string]*DataFrame)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		if hdr.Typeflag != tar.TypeReg && hdr.Typeflag != tar.TypeRegA {
+			continue
+		}
+		df, err := readArchiveFile(tr, newReader)
+		if err != nil {
+			return nil, fmt.Errorf("file %s: %v", hdr.Name, err)
+		}
+		ret[hdr.Name] = df
+	}
+	return ret, nil
+}
+
+// readZip reads each regular file in zr with newReader.
+func readZip(zr *zip.Reader, newReader func(io.Reader) Reader) (map[string]*DataFrame, error) {
+	ret := make(map[string]*DataFrame)
+	for _, f := range zr.File {
+		if !f.Mode().IsRegular() {
+			continue
+		}
+		rc, err := f.Open()
+		if err != nil {
+			return nil, fmt.Errorf("file %s: %v", f.Name, err)
+		}
+		df, err := readArchiveFile(rc, newReader)
+		rc.Close()
+		if err != nil {
+			return nil, fmt.Errorf("file %s: %v", f.Name, err)
+		}
+		ret[f.Name] = df
+	}
+	return ret, nil
+}
+
+// readArchiveFile decompresses r according to its magic bytes and reads it with newReader.
+func readArchiveFile(r io.Reader, newReader func(io.Reader) Reader) (*DataFrame, error) {
+	dr, err := Decompress(r)
+	if err != nil {
+		return nil, err
+	}
+	defer dr.Close()
+	return newReader(dr).Read()
+}
+
+// -- multiple files
+
+// listFiles returns the regular files matching the glob pattern or, if pattern is a directory, within it, sorted by path.
+// When searching a directory, files and directories whose names begin with . or _ are skipped.
+func listFiles(pattern string) ([]string, error) {
+	var ret []string
+	info, err := os.Stat(pattern)
+	if err == nil && info.IsDir() {
+		err = filepath.Walk(pattern, func(path string, info os.FileInfo, err error) error {
+			if err != nil {
+				return err
+			}
+			if path != pattern && (strings.HasPrefix(info.Name(), ".") || strings.HasPrefix(info.Name(), "_")) {
+				if info.IsDir() {
+					return filepath.SkipDir
+				}
+				return nil
+			}
+			if info.Mode().IsRegular() {
+				ret = append(ret, path)
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		matches, err := filepath.Glob(pattern)
+		if err != nil {
+			return nil, err
+		}
+		for _, path := range matches {
+			info, err := os.Stat(path)
+			if err != nil {
+				return nil, err
+			}
+			if info.Mode().IsRegular() {
+				ret = append(ret, path)
+			}
+		}
+	}
+	if len(ret) == 0 {
+		return nil, fmt.Errorf("no files match %s", pattern)
+	}
+	sort.Strings(ret)
+	return ret, nil
+}
+
+// partitionKeys returns the key=value pairs in the directory names of path, in order.
+func partitionKeys(path string) [][2]string {
+	var ret [][2]string
+	for _, dir := range strings.Split(filepath.ToSlash(filepath.Dir(path)), "/") {
+		if i := strings.Index(dir, "="); i > 0 {
+			ret = append(ret, [2]string{dir[:i], dir[i+1:]})
+		}
+	}
+	return ret
+}
+
+// addPartitionKeys adds a []string container for each partition key, with the partition value in every row.
+func (df *DataFrame) addPartitionKeys(keys [][2]string, asLabels bool) error {
+	for _, kv := range keys {
+		if _, err := indexOfContainer(kv[0], append(df.labels, df.values...)); err == nil {
+			return fmt.Errorf("partition key (%s) conflicts with existing name", kv[0])
+		}
+		slice := make([]string, df.Len())
+		for i := range slice {
+			slice[i] = kv[1]
+		}
+		vc := newValueContainer(slice, make([]bool, len(slice)), kv[0])
+		if asLabels {
+			df.labels = append(df.labels, vc)
+		} else {
+			df.values = append(df.values, vc)
+		}
+	}
+	return nil
+}
+
+// appendFiles checks that every DataFrame has the same label names, column names, and types as the first,
+// and appends them in order. If defaultLabels, the first label level is reset to increment across all rows.
+func appendFiles(paths []string, dfs []*DataFrame, defaultLabels bool) (*DataFrame, error) {
+	ret := dfs[0]
+	for i := 1; i < len(dfs); i++ {
+		err := matchSchema(ret, dfs[i])
+		if err != nil {
+			return nil, fmt.Errorf("%s does not match %s: %v", paths[i], paths[0], err)
+		}
+		err = ret.InPlace().Append(dfs[i])
+		if err != nil {
+			return nil, fmt.Errorf("%s: %v", paths[i], err)
+		}
+	}
+	if defaultLabels {
+		ret.labels[0] = makeDefaultLabels(0, ret.Len(), true)
+	}
+	return ret, nil
+}
+
+// matchSchema returns an error if other does not have the same label and column names and types as df.
+func matchSchema(df, other *DataFrame) error {
+	match := func(kind string, want, got []*valueContainer) error {
+		if len(want) != len(got) {
+			return fmt.Errorf("number of %s (%d != %d)", kind, len(got), len(want))
+		}
+		for k := range want {
+			if want[k].name != got[k].name {
+				return fmt.Errorf("%s %d: name (%s != %s)", kind, k, got[k].name, want[k].name)
+			}
+			if reflect.TypeOf(want[k].slice) != reflect.TypeOf(got[k].slice) {
+				return fmt.Errorf("%s %s: type (%v != %v)", kind, got[k].name,
+					reflect.TypeOf(got[k].slice), reflect.TypeOf(want[k].slice))
+			}
+		}
+		return nil
+	}
+	err := match("label levels", df.labels, other.labels)
+	if err != nil {
+		return err
+	}
+	return match("columns", df.values, other.values)
+}
+
+// -- xlsx
+
+// positions of the cell formats written to styles.xml by an XLSXWriter
+const (
+	xlsxStyleDefault = iota
+	xlsxStyleHeader
+	xlsxStyleDate
+	xlsxStyleDateTime
+)
+
+// day 0 in the 1900 date system (adjusted for the nonexistent 1900-02-29) and the 1904 date system
+var (
+	xlsxEpoch     = time.Date(1899, 12, 30, 0, 0, 0, 0, time.UTC)
+	xlsxEpoch1904 = time.Date(1904, 1, 1, 0, 0, 0, 0, time.UTC)
+)
+
+type xlsxCellKind int
+
+const (
+	xlsxBlank xlsxCellKind = iota
+	xlsxText
+	xlsxNumber
+	xlsxBool
+	xlsxDate
+	xlsxDateTime
+)
+
+// xlsxCell is the value of a single cell. text is set for every kind, num for numbers, and date for dates.
+type xlsxCell struct {
+	kind xlsxCellKind
+	text string
+	num  float64
+	date time.Time
+}
+
+type xlsxWorkbookXML struct {
+	WorkbookPr struct {
+		Date1904 bool `xml:"date1904,attr"`
+	} `xml:"workbookPr"`
+	Sheets []struct {
+		Name string `xml:"name,attr"`
+		RID  string `xml:"http://schemas.openxmlformats.org/officeDocument/2006/relationships id,attr"`
+	} `xml:"sheets>sheet"`
+}
+
+type xlsxRelationshipsXML struct {
+	Relationships []struct {
+		ID     string `xml:"Id,attr"`
+		Target string `xml:"Target,attr"`
+	} `xml:"Relationship"`
+}
+
+type xlsxRichTextXML struct {
+	T    string `xml:"t"`
+	Runs []struct {
+		T string `xml:"t"`
+	} `xml:"r"`
+}
+
+func (rt xlsxRichTextXML) String() string {
+	ret := rt.T
+	for _, run := range rt.Runs {
+		ret += run.T
+	}
+	return ret
+}
+
+type xlsxSharedStringsXML struct {
+	Items []xlsxRichTextXML `xml:"si"`
+}
+
+type xlsxStylesXML struct {
+	NumFmts []struct {
+		ID   int    `xml:"numFmtId,attr"`
+		Code string `xml:"formatCode,attr"`
+	} `xml:"numFmts>numFmt"`
+	CellXfs []struct {
+		NumFmtID int `xml:"numFmtId,attr"`
+	} `xml:"cellXfs>xf"`
+}
+
+type xlsxSheetXML struct {
+	Rows []struct {
+		R     int `xml:"r,attr"`
+		Cells []struct {
+			R      string          `xml:"r,attr"`
+			T      string          `xml:"t,attr"`
+			S      int             `xml:"s,attr"`
+			V      string          `xml:"v"`
+			Inline xlsxRichTextXML `xml:"is"`
+		} `xml:"c"`
+	} `xml:"sheetData>row"`
+	MergeCells []struct {
+		Ref string `xml:"ref,attr"`
+	} `xml:"mergeCells>mergeCell"`
+}
+
+// readXLSX returns the cells in a sheet (selected by name or, if name is empty, by index) as rows of equal length.
+// If cellRange is empty, the rows span every cell in use.
+func readXLSX(r io.ReaderAt, size int64, name string, index int, cellRange string) ([][]xlsxCell, error) {
+	zr, err := zip.NewReader(r, size)
+	if err != nil {
+		return nil, err
+	}
+	files := make(map[string]*zip.File, len(zr.File))
+	for _, f := range zr.File {
+		files[f.Name] = f
+	}
+	var workbook xlsxWorkbookXML
+	err = decodeXLSXPart(files, "xl/workbook.xml", true, &workbook)
+	if err != nil {
+		return nil, err
+	}
+	var rels xlsxRelationshipsXML
+	err = decodeXLSXPart(files, "xl/_rels/workbook.xml.rels", true, &rels)
+	if err != nil {
+		return nil, err
+	}
+	var rid string
+	if name != "" {
+		for _, sheet := range workbook.Sheets {
+			if sheet.Name == name {
+				rid = sheet.RID
+			}
+		}
+		if rid == "" {
+			return nil, fmt.Errorf("sheet %q not in workbook", name)
+		}
+	} else {
+		if index < 0 || index >= len(workbook.Sheets) {
+			return nil, fmt.Errorf("sheet index (%d) out of range [0, %d)", index, len(workbook.Sheets))
+		}
+		rid = workbook.Sheets[index].RID
+	}
+	var path string
+	for _, rel := range rels.Relationships {
+		if rel.ID == rid {
+			path = rel.Target
+		}
+	}
+	if strings.HasPrefix(path, "/") {
+		path = path[1:]
+	} else {
+		path = "xl/" + path
+	}
+	var sharedStrings xlsxSharedStringsXML
+	err = decodeXLSXPart(files, "xl/sharedStrings.xml", false, &sharedStrings)
+	if err != nil {
+		return nil, err
+	}
+	var styles xlsxStylesXML
+	err = decodeXLSXPart(files, "xl/styles.xml", false, &styles)
+	if err != nil {
+		return nil, err
+	}
+	var sheet xlsxSheetXML
+	err = decodeXLSXPart(files, path, true, &sheet)
+	if err != nil {
+		return nil, err
+	}
+
+	dateStyles := xlsxDateStyles(styles)
+	epoch := xlsxEpoch
+	if workbook.WorkbookPr.Date1904 {
+		epoch = xlsxEpoch1904
+	}
+	cells := make(map[[2]int]xlsxCell)
+	first, last := [2]int{-1, -1}, [2]int{-1, -1}
+	extend := func(pos [2]int) {
+		for d := range pos {
+			if first[d] == -1 || pos[d] < first[d] {
+				first[d] = pos[d]
+			}
+			if pos[d] > last[d] {
+				last[d] = pos[d]
+			}
+		}
+	}
+	row := -1
+	for _, xmlRow := range sheet.Rows {
+		row++
+		if xmlRow.R > 0 {
+			row = xmlRow.R - 1
+		}
+		col := -1
+		for _, c := range xmlRow.Cells {
+			col++
+			if c.R != "" {
+				var err error
+				_, col, err = parseXLSXCellRef(c.R)
+				if err != nil {
+					return nil, err
+				}
+			}
+			cell, err := readXLSXCell(c.T, c.V, c.Inline, dateStyles[c.S], sharedStrings.Items, epoch)
+			if err != nil {
+				return nil, fmt.Errorf("cell %s: %v", formatXLSXCellRef(row, col), err)
+			}
+			if cell.kind != xlsxBlank {
+				cells[[2]int{row, col}] = cell
+				extend([2]int{row, col})
+			}
+		}
+	}
+	for _, merge := range sheet.MergeCells {
+		from, to, err := parseXLSXRange(merge.Ref)
+		if err != nil {
+			return nil, err
+		}
+		cell := cells[from]
+		for i := from[0]; i <= to[0]; i++ {
+			for k := from[1]; k <= to[1]; k++ {
+				cells[[2]int{i, k}] = cell
+			}
+		}
+		if cell.kind != xlsxBlank {
+			extend(to)
+		}
+	}
+	if cellRange != "" {
+		first, last, err = parseXLSXRange(cellRange)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if first[0] == -1 {
+		return nil, fmt.Errorf("sheet is empty")
+	}
+	ret := make([][]xlsxCell, last[0]-first[0]+1)
+	for i := range ret {
+		ret[i] = make([]xlsxCell, last[1]-first[1]+1)
+		for k := range ret[i] {
+			ret[i][k] = cells[[2]int{first[0] + i, first[1] + k}]
+		}
+	}
+	return ret, nil
+}
+
+// decodeXLSXPart decodes the XML file at path within an xlsx archive into v.
+// If the file does not exist, returns an error only if required is true.
+func decodeXLSXPart(files map[string]*zip.File, path string, required bool, v interface{}) error {
+	f, ok := files[path]
+	if !ok {
+		if required {
+			return fmt.Errorf("missing %s", path)
+		}
+		return nil
+	}
+	rc, err := f.Open()
+	if err != nil {
+		return err
+	}
+	defer rc.Close()
+	err = xml.NewDecoder(rc).Decode(v)
+	if err != nil {
+		return fmt.Errorf("%s: %v", path, err)
+	}
+	return nil
+}
+
+// readXLSXCell converts the type, value, and inline string of a cell to an xlsxCell.
+func readXLSXCell(t string, v string, inline xlsxRichTextXML, isDate bool, sharedStrings []xlsxRichTextXML, epoch time.Time) (xlsxCell, error) {
+	switch t {
+	case "s":
+		i, err := strconv.Atoi(v)
+		if err != nil || i < 0 || i >= len(sharedStrings) {
+			return xlsxCell{}, fmt.Errorf("invalid shared string index (%s)", v)
+		}
+		return xlsxCell{kind: xlsxText, text: sharedStrings[i].String()}, nil
+	case "inlineStr":
+		return xlsxCell{kind: xlsxText, text: inline.String()}, nil
+	case "str", "e":
+		return xlsxCell{kind: xlsxText, text: v}, nil
+	case "b":
+		return xlsxCell{kind: xlsxBool, text: strconv.FormatBool(v == "1")}, nil
+	case "d":
+		for _, layout := range []string{time.RFC3339Nano, "2006-01-02T15:04:05.999999999", "2006-01-02"} {
+			date, err := time.Parse(layout, v)
+			if err == nil {
+				return xlsxCell{kind: xlsxDateTime, text: date.Format(time.RFC3339), date: date}, nil
+			}
+		}
+		return xlsxCell{}, fmt.Errorf("cannot parse %q as date", v)
+	}
+	if v == "" {
+		return xlsxCell{}, nil
+	}
+	num, err := strconv.ParseFloat(v, 64)
+	if err != nil {
+		return xlsxCell{}, fmt.Errorf("cannot parse %q as number", v)
+	}
+	if isDate {
+		date := xlsxSerialToTime(num, epoch)
+		return xlsxCell{kind: xlsxDateTime, text: date.Format(time.RFC3339), date: date}, nil
+	}
+	return xlsxCell{kind: xlsxNumber, text: strconv.FormatFloat(num, 'g', -1, 64), num: num}, nil
+}
+
+// xlsxDateStyles returns whether each cell format in styles formats numbers as dates.
+func xlsxDateStyles(styles xlsxStylesXML) map[int]bool {
+	codes := make(map[int]string)
+	for _, numFmt := range styles.NumFmts {
+		codes[numFmt.ID] = numFmt.Code
+	}
+	ret := make(map[int]bool)
+	for i, xf := range styles.CellXfs {
+		code, ok := codes[xf.NumFmtID]
+		if !ok {
+			// built-in date and time formats
+			ret[i] = (xf.NumFmtID >= 14 && xf.NumFmtID <= 22) || (xf.NumFmtID >= 45 && xf.NumFmtID <= 47)
+			continue
+		}
+		ret[i] = isXLSXDateFormat(code)
+	}
+	return ret
+}
+
+var xlsxFormatLiterals = regexp.MustCompile(`"[^"]*"|\[[^\]]*\]|\\.`)
+
+// isXLSXDateFormat returns true if a number format code contains any date or time placeholders outside of literal text.
+func isXLSXDateFormat(code string) bool {
+	code = xlsxFormatLiterals.ReplaceAllString(code, "")
+	return strings.ContainsAny(strings.ToLower(code), "ymdhs")
+}
+
+// xlsxSerialToTime converts an Excel serial date (days since epoch) to a UTC time, rounded to the microsecond.
+func xlsxSerialToTime(serial float64, epoch time.Time) time.Time {
+	days := math.Floor(serial)
+	micros := math.Round((serial - days) * 24 * float64(time.Hour/time.Microsecond))
+	return epoch.AddDate(0, 0, int(days)).Add(time.Duration(micros) * time.Microsecond)
+}
+
+// xlsxTimeToSerial converts the wall clock time of t to an Excel serial date.
+func xlsxTimeToSerial(t time.Time) float64 {
+	wall := time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), time.UTC)
+	return float64(wall.Unix()-xlsxEpoch.Unix())/float64(24*time.Hour/time.Second) +
+		float64(wall.Nanosecond())/float64(24*time.Hour)
+}
+
+// parseXLSXCellRef converts a cell reference (e.g., B3) to a zero-indexed row and column.
+func parseXLSXCellRef(ref string) (row int, col int, err error) {
+	var i int
+	for i < len(ref) && ref[i] >= 'A' && ref[i] <= 'Z' {
+		col = col*26 + int(ref[i]-'A') + 1
+		i++
+	}
+	row, err = strconv.Atoi(ref[i:])
+	if i == 0 || err != nil || row < 1 {
+		return 0, 0, fmt.Errorf("invalid cell reference (%s)", ref)
+	}
+	return row - 1, col - 1, nil
+}
+
+// formatXLSXCellRef converts a zero-indexed row and column to a cell reference (e.g., B3).
+func formatXLSXCellRef(row int, col int) string {
+	var letters []byte
+	for col++; col > 0; col = (col - 1) / 26 {
+		letters = append([]byte{byte('A' + (col-1)%26)}, letters...)
+	}
+	return string(letters) + strconv.Itoa(row+1)
+}
+
+// parseXLSXRange converts a range reference (e.g., B3:D10) to its first and last {row, column} positions.
+func parseXLSXRange(ref string) (first [2]int, last [2]int, err error) {
+	refs := strings.Split(strings.ToUpper(ref), ":")
+	if len(refs) != 2 {
+		return first, last, fmt.Errorf("invalid range (%s): must be two cell references separated by :", ref)
+	}
+	first[0], first[1], err = parseXLSXCellRef(refs[0])
+	if err != nil {
+		return first, last, err
+	}
+	last[0], last[1], err = parseXLSXCellRef(refs[1])
+	if err != nil {
+		return first, last, err
+	}
+	if last[0] < first[0] || last[1] < first[1] {
+		return first, last, fmt.Errorf("invalid range (%s): last cell must be below and to the right of first cell", ref)
+	}
+	return first, last, nil
+}
+
+// setXLSXTypes converts each container whose non-blank cells are all numbers to []float64,
+// or all dates to []time.Time, and sets blank cells to null.
+func setXLSXTypes(containers []*valueContainer, rows [][]xlsxCell) {
+	for k := range containers {
+		kinds := make(map[xlsxCellKind]bool)
+		for i := range rows {
+			if rows[i][k].kind == xlsxBlank {
+				containers[k].isNull[i] = true
+				continue
+			}
+			kinds[rows[i][k].kind] = true
+		}
+		if len(kinds) != 1 {
+			continue
+		}
+		switch {
+		case kinds[xlsxNumber]:
+			arr := make([]float64, len(rows))
+			for i := range rows {
+				arr[i] = rows[i][k].num
+			}
+			containers[k].slice = arr
+		case kinds[xlsxDateTime]:
+			arr := make([]time.Time, len(rows))
+			for i := range rows {
+				arr[i] = rows[i][k].date
+			}
+			containers[k].slice = arr
+		}
+	}
+}
+
+// xlsxCells converts each value in vc to a cell. Null values are blank cells.
+func (vc *valueContainer) xlsxCells() []xlsxCell {
+	ret := make([]xlsxCell, vc.len())
+	switch arr := vc.slice.(type) {
+	case []float64:
+		for i := range arr {
+			ret[i] = xlsxCell{kind: xlsxNumber, num: arr[i]}
+		}
+	case []bool:
+		for i := range arr {
+			ret[i] = xlsxCell{kind: xlsxBool, text: strconv.FormatBool(arr[i])}
+		}
+	case []time.Time:
+		for i := range arr {
+			ret[i] = xlsxCell{kind: xlsxDateTime, date: arr[i]}
+		}
+	case []civil.Date:
+		for i := range arr {
+			ret[i] = xlsxCell{kind: xlsxDate, date: arr[i].In(time.UTC)}
+		}
+	case []int, []int8, []int16, []int32, []int64, []uint, []uint8, []uint16, []uint32, []uint64, []float32:
+		v := reflect.ValueOf(arr)
+		for i := range ret {
+			ret[i] = xlsxCell{kind: xlsxNumber, num: v.Index(i).Convert(reflect.TypeOf(float64(0))).Float()}
+		}
+	default:
+		for i, s := range vc.string().slice {
+			ret[i] = xlsxCell{kind: xlsxText, text: s}
+		}
+	}
+	for i := range ret {
+		if vc.isNull[i] {
+			ret[i] = xlsxCell{}
+		}
+	}
+	return ret
+}
+
+// xlsxSheetNames returns the name of the sheet for each DataFrame, which defaults to Sheet1, Sheet2, etc.
+func xlsxSheetNames(dfs []*DataFrame) ([]string, error) {
+	ret := make([]string, len(dfs))
+	unique := make(map[string]bool)
+	for i := range dfs {
+		ret[i] = dfs[i].name
+		if ret[i] == "" {
+			ret[i] = fmt.Sprintf("Sheet%d", i+1)
+		}
+		if utf8.RuneCountInString(ret[i]) > 31 || strings.ContainsAny(ret[i], `[]:*?/\`) {
+			return nil, fmt.Errorf("invalid sheet name (%s): must be at most 31 characters and may not contain any of []:*?/\\", ret[i])
+		}
+		if unique[strings.ToLower(ret[i])] {
+			return nil, fmt.Errorf("duplicate sheet name (%s)", ret[i])
+		}
+		unique[strings.ToLower(ret[i])] = true
+	}
+	return ret, nil
+}
+
+const (
+	xlsxHeader           = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>` + "\n"
+	xlsxMainNamespace    = `http://schemas.openxmlformats.org/spreadsheetml/2006/main`
+	xlsxRelNamespace     = `http://schemas.openxmlformats.org/officeDocument/2006/relationships`
+	xlsxPackageNamespace = `http://schemas.openxmlformats.org/package/2006/relationships`
+	xlsxStyles           = xlsxHeader + `<styleSheet xmlns="` + xlsxMainNamespace + `">` +
+		`<numFmts count="2"><numFmt numFmtId="164" formatCode="yyyy-mm-dd"/><numFmt numFmtId="165" formatCode="yyyy-mm-dd hh:mm:ss"/></numFmts>` +
+		`<fonts count="2"><font><sz val="11"/><name val="Calibri"/></font><font><b/><sz val="11"/><name val="Calibri"/></font></fonts>` +
+		`<fills count="2"><fill><patternFill patternType="none"/></fill><fill><patternFill patternType="gray125"/></fill></fills>` +
+		`<borders count="1"><border><left/><right/><top/><bottom/><diagonal/></border></borders>` +
+		`<cellStyleXfs count="1"><xf numFmtId="0" fontId="0" fillId="0" borderId="0"/></cellStyleXfs>` +
+		`<cellXfs count="4">` +
+		`<xf numFmtId="0" fontId="0" fillId="0" borderId="0" xfId="0"/>` +
+		`<xf numFmtId="0" fontId="1" fillId="0" borderId="0" xfId="0" applyFont="1" applyAlignment="1"><alignment horizontal="center"/></xf>` +
+		`<xf numFmtId="164" fontId="0" fillId="0" borderId="0" xfId="0" applyNumberFormat="1"/>` +
+		`<xf numFmtId="165" fontId="0" fillId="0" borderId="0" xfId="0" applyNumberFormat="1"/>` +
+		`</cellXfs>` +
+		`<cellStyles count="1"><cellStyle name="Normal" xfId="0" builtinId="0"/></cellStyles>` +
+		`</styleSheet>`
+)
+
+// writeXLSX writes each DataFrame as a sheet in an xlsx workbook.
+func writeXLSX(w io.Writer, dfs []*DataFrame, includeLabels bool) error {
+	names, err := xlsxSheetNames(dfs)
+	if err != nil {
+		return err
+	}
+	var contentTypes, workbook, rels bytes.Buffer
+	contentTypes.WriteString(xlsxHeader + `<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">` +
+		`<Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/>` +
+		`<Default Extension="xml" ContentType="application/xml"/>` +
+		`<Override PartName="/xl/workbook.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/>` +
+		`<Override PartName="/xl/styles.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.styles+xml"/>`)
+	workbook.WriteString(xlsxHeader + `<workbook xmlns="` + xlsxMainNamespace + `" xmlns:r="` + xlsxRelNamespace + `"><sheets>`)
+	rels.WriteString(xlsxHeader + `<Relationships xmlns="` + xlsxPackageNamespace + `">`)
+	for i := range names {
+		fmt.Fprintf(&contentTypes, `<Override PartName="/xl/worksheets/sheet%d.xml" `+
+			`ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/>`, i+1)
+		workbook.WriteString(`<sheet name="`)
+		xml.EscapeText(&workbook, []byte(names[i]))
+		fmt.Fprintf(&workbook, `" sheetId="%d" r:id="rId%d"/>`, i+1, i+1)
+		fmt.Fprintf(&rels, `<Relationship Id="rId%d" Type="%s/worksheet" Target="worksheets/sheet%d.xml"/>`,
+			i+1, xlsxRelNamespace, i+1)
+	}
+	contentTypes.WriteString(`</Types>`)
+	workbook.WriteString(`</sheets></workbook>`)
+	fmt.Fprintf(&rels, `<Relationship Id="rId%d" Type="%s/styles" Target="styles.xml"/></Relationships>`,
+		len(names)+1, xlsxRelNamespace)
+
+	zw := zip.NewWriter(w)
+	parts := []struct {
+		path    string
+		content []byte
+	}{
+		{"[Content_Types].xml", contentTypes.Bytes()},
+		{"_rels/.rels", []byte(xlsxHeader + `<Relationships xmlns="` + xlsxPackageNamespace + `">` +
+			`<Relationship Id="rId1" Type="` + xlsxRelNamespace + `/officeDocument" Target="xl/workbook.xml"/></Relationships>`)},
+		{"xl/workbook.xml", workbook.Bytes()},
+		{"xl/_rels/workbook.xml.rels", rels.Bytes()},
+		{"xl/styles.xml", []byte(xlsxStyles)},
+	}
+	for i := range dfs {
+		parts = append(parts, struct {
+			path    string
+			content []byte
+		}{fmt.Sprintf("xl/worksheets/sheet%d.xml", i+1), writeXLSXSheet(dfs[i], includeLabels)})
+	}
+	for _, part := range parts {
+		f, err := zw.Create(part.path)
+		if err != nil {
+			return err
+		}
+		_, err = f.Write(part.content)
+		if err != nil {
+			return err
+		}
+	}
+	return zw.Close()
+}
+
+// writeXLSXSheet returns the worksheet XML for df, with frozen header rows and label columns and merged multi-level headers.
+func writeXLSXSheet(df *DataFrame, includeLabels bool) []byte {
+	containers := df.values
+	var numLabels int
+	if includeLabels {
+		containers = append(df.labels, df.values...)
+		numLabels = len(df.labels)
+	}
+	numHeaders := df.numColLevels()
+	headers := make([][]string, len(containers))
+	cells := make([][]xlsxCell, len(containers))
+	for k := range containers {
+		headers[k] = make([]string, numHeaders)
+		// default label names are left blank
+		if k >= numLabels || !strings.HasPrefix(containers[k].name, optionPrefix) {
+			copy(headers[k], splitNameIntoLevels(containers[k].name))
+		}
+		cells[k] = containers[k].xlsxCells()
+	}
+	// merge adjacent column headers that share the same levels, except at the last level
+	spans := headerSpans(headers, numLabels, numHeaders)
+	var merges []string
+	for k := range spans {
+		for l := range spans[k] {
+			if spans[k][l] > 1 {
+				merges = append(merges, formatXLSXCellRef(l, k)+":"+formatXLSXCellRef(l, k+spans[k][l]-1))
+			}
+		}
+	}
+
+	var b bytes.Buffer
+	b.WriteString(xlsxHeader + `<worksheet xmlns="` + xlsxMainNamespace + `">`)
+	if numHeaders > 0 || numLabels > 0 {
+		pane := "bottomRight"
+		if numLabels == 0 {
+			pane = "bottomLeft"
+		} else if numHeaders == 0 {
+			pane = "topRight"
+		}
+		b.WriteString(`<sheetViews><sheetView workbookViewId="0"><pane`)
+		if numLabels > 0 {
+			fmt.Fprintf(&b, ` xSplit="%d"`, numLabels)
+		}
+		if numHeaders > 0 {
+			fmt.Fprintf(&b, ` ySplit="%d"`, numHeaders)
+		}
+		fmt.Fprintf(&b, ` topLeftCell="%s" activePane="%s" state="frozen"/></sheetView></sheetViews>`,
+			formatXLSXCellRef(numHeaders, numLabels), pane)
+	}
+	b.WriteString(`<sheetData>`)
+	for l := 0; l < numHeaders; l++ {
+		fmt.Fprintf(&b, `<row r="%d">`, l+1)
+		for k := range containers {
+			if headers[k][l] != "" && spans[k][l] > 0 {
+				writeXLSXCell(&b, l, k, xlsxCell{kind: xlsxText, text: headers[k][l]}, xlsxStyleHeader)
+			}
+		}
+		b.WriteString(`</row>`)
+	}
+	for i := 0; i < df.Len(); i++ {
+		fmt.Fprintf(&b, `<row r="%d">`, numHeaders+i+1)
+		for k := range cells {
+			writeXLSXCell(&b, numHeaders+i, k, cells[k][i], xlsxStyleDefault)
+		}
+		b.WriteString(`</row>`)
+	}
+	b.WriteString(`</sheetData>`)
+	if len(merges) > 0 {
+		fmt.Fprintf(&b, `<mergeCells count="%d">`, len(merges))
+		for _, ref := range merges {
+			fmt.Fprintf(&b, `<mergeCell ref="%s"/>`, ref)
+		}
+		b.WriteString(`</mergeCells>`)
+	}
+	b.WriteString(`</worksheet>`)
+	return b.Bytes()
+}
+
+// writeXLSXCell writes the XML for a cell at row and col. Blank cells and non-finite numbers are omitted.
+func writeXLSXCell(b *bytes.Buffer, row int, col int, cell xlsxCell, style int) {
+	ref := formatXLSXCellRef(row, col)
+	switch cell.kind {
+	case xlsxText:
+		fmt.Fprintf(b, `<c r="%s" s="%d" t="inlineStr"><is><t xml:space="preserve">`, ref, style)
+		xml.EscapeText(b, []byte(cell.text))
+		b.WriteString(`</t></is></c>`)
+	case xlsxNumber:
+		if math.IsNaN(cell.num) || math.IsInf(cell.num, 0) {
+			return
+		}
+		fmt.Fprintf(b, `<c r="%s" s="%d"><v>%s</v></c>`, ref, style, strconv.FormatFloat(cell.num, 'g', -1, 64))
+	case xlsxBool:
+		v := 0
+		if cell.text == "true" {
+			v = 1
+		}
+		fmt.Fprintf(b, `<c r="%s" s="%d" t="b"><v>%d</v></c>`, ref, style, v)
+	case xlsxDate, xlsxDateTime:
+		style = xlsxStyleDateTime
+		if cell.kind == xlsxDate {
+			style = xlsxStyleDate
+		}
+		fmt.Fprintf(b, `<c r="%s" s="%d"><v>%s</v></c>`, ref, style,
+			strconv.FormatFloat(xlsxTimeToSerial(cell.date), 'g', -1, 64))
+	}
+}
+
+// -- fixed-width text
+
+// readFixedWidthLines returns every line in r that is not entirely whitespace, as runes.
+func readFixedWidthLines(r io.Reader) ([][]rune, error) {
+	var ret [][]rune
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), "\r")
+		if strings.TrimSpace(line) != "" {
+			ret = append(ret, []rune(line))
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if len(ret) == 0 {
+		return nil, fmt.Errorf("must have at least one non-blank line")
+	}
+	return ret, nil
+}
+
+// inferFixedWidthColumns returns a column for every span of positions that are not whitespace in at least one line.
+func inferFixedWidthColumns(lines [][]rune) []FixedWidthColumn {
+	var width int
+	for i := range lines {
+		if len(lines[i]) > width {
+			width = len(lines[i])
+		}
+	}
+	used := make([]bool, width)
+	for i := range lines {
+		for j, c := range lines[i] {
+			if !unicode.IsSpace(c) {
+				used[j] = true
+			}
+		}
+	}
+	var ret []FixedWidthColumn
+	for j := 0; j < width; j++ {
+		if !used[j] {
+			continue
+		}
+		start := j
+		for j < width && used[j] {
+			j++
+		}
+		ret = append(ret, FixedWidthColumn{Start: start, End: j})
+	}
+	return ret
+}
+
+// splitFixedWidthLines returns a record for every line, with one trimmed field per column.
+func splitFixedWidthLines(lines [][]rune, columns []FixedWidthColumn) ([][]string, error) {
+	for k := range columns {
+		if columns[k].Start < 0 || columns[k].End <= columns[k].Start {
+			return nil, fmt.Errorf("column %d: invalid position [%d, %d): start must be >= 0 and less than end",
+				k, columns[k].Start, columns[k].End)
+		}
+	}
+	ret := make([][]string, len(lines))
+	for i := range lines {
+		ret[i] = make([]string, len(columns))
+		for k := range columns {
+			if columns[k].Start >= len(lines[i]) {
+				continue
+			}
+			end := columns[k].End
+			if end > len(lines[i]) {
+				end = len(lines[i])
+			}
+			ret[i][k] = strings.TrimSpace(string(lines[i][columns[k].Start:end]))
+		}
+	}
+	return ret, nil
+}
+
+// hasFixedWidthNames returns true if every column has a name.
+func hasFixedWidthNames(columns []FixedWidthColumn) bool {
+	for k := range columns {
+		if columns[k].Name == "" {
+			return false
+		}
+	}
+	return true
+}
+
+// -- text tables
+
+// renderedTable is a DataFrame rendered as text cells, with the spans of merged header and label cells.
+type renderedTable struct {
+	numLabels   int
+	names       []string   // by container; default label level names are blank
+	headers     [][]string // by container, then column level; label level names are in the last level
+	headerSpans [][]int    // by container, then column level
+	cells       [][]string // by container, then row
+	isNull      [][]bool   // by container, then row
+	rowSpans    [][]int    // by label level, then row
+	numeric     []bool     // by container
+}
+
+// renderTable renders every value in df as text, according to format.
+func renderTable(df *DataFrame, format TableFormat) (*renderedTable, error) {
+	if df.err != nil {
+		return nil, df.err
+	}
+	containers := df.values
+	ret := new(renderedTable)
+	if format.IncludeLabels {
+		containers = append(df.labels, df.values...)
+		ret.numLabels = len(df.labels)
+	}
+	for name := range format.NumberFormats {
+		_, err := indexOfContainer(name, containers)
+		if err != nil {
+			return nil, fmt.Errorf("number format: %v", err)
+		}
+	}
+	numLevels := df.numColLevels()
+	ret.names = make([]string, len(containers))
+	ret.headers = make([][]string, len(containers))
+	ret.cells = make([][]string, len(containers))
+	ret.isNull = make([][]bool, len(containers))
+	ret.numeric = make([]bool, len(containers))
+	for k := range containers {
+		ret.headers[k] = make([]string, numLevels)
+		if k < ret.numLabels {
+			if !strings.HasPrefix(containers[k].name, optionPrefix) {
+				ret.names[k] = containers[k].name
+				ret.headers[k][numLevels-1] = containers[k].name
+			}
+		} else {
+			ret.names[k] = containers[k].name
+			copy(ret.headers[k], splitNameIntoLevels(containers[k].name))
+		}
+		ret.cells[k], ret.numeric[k] = containers[k].tableCells(format.NumberFormats[containers[k].name], format.NullString)
+		ret.isNull[k] = containers[k].isNull
+	}
+	ret.headerSpans = headerSpans(ret.headers, ret.numLabels, numLevels)
+	ret.rowSpans = make([][]int, ret.numLabels)
+	for j := range ret.rowSpans {
+		ret.rowSpans[j] = make([]int, df.Len())
+		for i := range ret.rowSpans[j] {
+			ret.rowSpans[j][i] = 1
+		}
+	}
+	if format.MergeRepeats {
+		for i := df.Len() - 1; i > 0; i-- {
+			for j := 0; j < ret.numLabels && ret.cells[j][i] == ret.cells[j][i-1]; j++ {
+				ret.rowSpans[j][i-1] += ret.rowSpans[j][i]
+				ret.rowSpans[j][i] = 0
+			}
+		}
+	}
+	return ret, nil
+}
+
+// headerSpans returns the number of containers spanned by each header (by container, then level),
+// or 0 if the header is merged into the header to its left.
+// At every level but the last, adjacent containers (starting at first) with the same non-blank names at every level
+// down to the current level are merged.
+func headerSpans(headers [][]string, first int, numLevels int) [][]int {
+	ret := make([][]int, len(headers))
+	for k := range ret {
+		ret[k] = make([]int, numLevels)
+		for l := range ret[k] {
+			ret[k][l] = 1
+		}
+	}
+	for l := 0; l < numLevels-1; l++ {
+		for k := first; k < len(headers); {
+			end := k
+			for headers[k][l] != "" && end+1 < len(headers) && reflect.DeepEqual(headers[end+1][:l+1], headers[k][:l+1]) {
+				end++
+			}
+			ret[k][l] = end - k + 1
+			for m := k + 1; m <= end; m++ {
+				ret[m][l] = 0
+			}
+			k = end + 1
+		}
+	}
+	return ret
+}
+
+// tableCells renders every value in vc as text, formatting numeric values with numberFormat (if not empty)
+// and replacing null values with nullString. Also returns whether vc is numeric.
+func (vc *valueContainer) tableCells(numberFormat string, nullString string) ([]string, bool) {
+	var numeric bool
+	switch reflect.TypeOf(vc.slice).Elem().Kind() {
+	case reflect.Float32, reflect.Float64,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		numeric = true
+	}
+	var ret []string
+	if arr, ok := vc.slice.([]*big.Rat); ok && numberFormat != "" {
+		// *big.Rat does not implement fmt.Formatter, so each value is formatted as a *big.Float with ample precision
+		numeric = true
+		ret = make([]string, len(arr))
+		for i := range arr {
+			if arr[i] != nil {
+				ret[i] = fmt.Sprintf(numberFormat, new(big.Float).SetPrec(decimalFormatPrecision).SetRat(arr[i]))
+			}
+		}
+	} else if numeric && numberFormat != "" {
+		v := reflect.ValueOf(vc.slice)
+		ret = make([]string, v.Len())
+		for i := range ret {
+			ret[i] = fmt.Sprintf(numberFormat, v.Index(i).Interface())
+		}
+	} else {
+		numeric = numeric || vc.isDecimal()
+		ret = append([]string{}, vc.string().slice...)
+	}
+	for i := range ret {
+		if vc.isNull[i] {
+			ret[i] = nullString
+		}
+	}
+	return ret, numeric
+}
+
+// decimalFormatPrecision is the mantissa precision (in bits) used to format a Decimal value with a table number format.
+const decimalFormatPrecision = 512
+
+var markdownEscaper = strings.NewReplacer("|", `\|`, "\r\n", " ", "\n", " ")
+
+// markdown returns the table in GitHub-flavored Markdown.
+func (t *renderedTable) markdown() []byte {
+	var b bytes.Buffer
+	writeRow := func(cells []string) {
+		b.WriteString("|")
+		for _, cell := range cells {
+			b.WriteString(" " + markdownEscaper.Replace(cell) + " |")
+		}
+		b.WriteString("\n")
+	}
+	writeRow(t.names)
+	alignments := make([]string, len(t.names))
+	for k := range alignments {
+		alignments[k] = "---"
+		if t.numeric[k] {
+			alignments[k] = "---:"
+		}
+	}
+	b.WriteString("|" + strings.Join(alignments, "|") + "|\n")
+	for i := 0; len(t.cells) > 0 && i < len(t.cells[0]); i++ {
+		row := make([]string, len(t.cells))
+		for k := range t.cells {
+			if k >= t.numLabels || t.rowSpans[k][i] > 0 {
+				row[k] = t.cells[k][i]
+			}
+		}
+		writeRow(row)
+	}
+	return b.Bytes()
+}
+
+// html returns the table as an HTML <table> element.
+func (t *renderedTable) html(caption string, class string, labelClass string, nullClass string) []byte {
+	var b bytes.Buffer
+	classAttr := func(class string) string {
+		if class == "" {
+			return ""
+		}
+		return fmt.Sprintf(` class="%s"`, html.EscapeString(class))
+	}
+	fmt.Fprintf(&b, "<table%s>\n", classAttr(class))
+	if caption != "" {
+		fmt.Fprintf(&b, "<caption>%s</caption>\n", html.EscapeString(caption))
+	}
+	b.WriteString("<thead>\n")
+	for l := 0; len(t.headers) > 0 && l < len(t.headers[0]); l++ {
+		b.WriteString("<tr>")
+		for k := range t.headers {
+			switch span := t.headerSpans[k][l]; span {
+			case 0:
+			case 1:
+				fmt.Fprintf(&b, "<th>%s</th>", html.EscapeString(t.headers[k][l]))
+			default:
+				fmt.Fprintf(&b, `<th colspan="%d">%s</th>`, span, html.EscapeString(t.headers[k][l]))
+			}
+		}
+		b.WriteString("</tr>\n")
+	}
+	b.WriteString("</thead>\n<tbody>\n")
+	for i := 0; len(t.cells) > 0 && i < len(t.cells[0]); i++ {
+		b.WriteString("<tr>")
+		for k := range t.cells {
+			tag := "td"
+			var attrs string
+			if k < t.numLabels {
+				if t.rowSpans[k][i] == 0 {
+					continue
+				}
+				tag = "th"
+				if t.rowSpans[k][i] > 1 {
+					attrs += fmt.Sprintf(` rowspan="%d"`, t.rowSpans[k][i])
+				}
+				attrs += classAttr(labelClass)
+			} else if t.isNull[k][i] {
+				attrs += classAttr(nullClass)
+			}
+			fmt.Fprintf(&b, "<%s%s>%s</%s>", tag, attrs, html.EscapeString(t.cells[k][i]), tag)
+		}
+		b.WriteString("</tr>\n")
+	}
+	b.WriteString("</tbody>\n</table>\n")
+	return b.Bytes()
+}
+
+var latexEscaper = strings.NewReplacer(
+	`\`, `\textbackslash{}`, "&", `\&`, "%", `\%`, "$", `\$`, "#", `\#`, "_", `\_`,
+	"{", `\{`, "}", `\}`, "~", `\textasciitilde{}`, "^", `\textasciicircum{}`, "\n", " ")
+
+// latex returns the table as a LaTeX tabular environment.
+func (t *renderedTable) latex() []byte {
+	var b bytes.Buffer
+	var colSpec string
+	for k := range t.cells {
+		if k == t.numLabels && k > 0 {
+			colSpec += "|"
+		}
+		if t.numeric[k] {
+			colSpec += "r"
+		} else {
+			colSpec += "l"
+		}
+	}
+	fmt.Fprintf(&b, "\\begin{tabular}{%s}\n\\hline\n", colSpec)
+	for l := 0; len(t.headers) > 0 && l < len(t.headers[0]); l++ {
+		var row []string
+		for k := range t.headers {
+			switch span := t.headerSpans[k][l]; span {
+			case 0:
+			case 1:
+				row = append(row, latexEscaper.Replace(t.headers[k][l]))
+			default:
+				row = append(row, fmt.Sprintf(`\multicolumn{%d}{c}{%s}`, span, latexEscaper.Replace(t.headers[k][l])))
+			}
+		}
+		b.WriteString(strings.Join(row, " & ") + " \\\\\n")
+	}
+	b.WriteString("\\hline\n")
+	for i := 0; len(t.cells) > 0 && i < len(t.cells[0]); i++ {
+		row := make([]string, len(t.cells))
+		for k := range t.cells {
+			if k >= t.numLabels || t.rowSpans[k][i] > 0 {
+				row[k] = latexEscaper.Replace(t.cells[k][i])
+			}
+		}
+		b.WriteString(strings.Join(row, " & ") + " \\\\\n")
+	}
+	b.WriteString("\\hline\n\\end{tabular}\n")
+	return b.Bytes()
+}
+
+// -- matrix
+
+// denseMatrix is a column-major Matrix of float64 values.
+type denseMatrix struct {
+	columns    [][]float64
+	numRows    int
+	transposed bool
+}
+
+// Dims returns the number of rows and columns in the matrix.
+func (mat denseMatrix) Dims() (r, c int) {
+	if mat.transposed {
+		return len(mat.columns), mat.numRows
+	}
+	return mat.numRows, len(mat.columns)
+}
+
+// At returns the value at row i and column j. Panics if either is out of range.
+func (mat denseMatrix) At(i, j int) float64 {
+	if mat.transposed {
+		i, j = j, i
+	}
+	return mat.columns[j][i]
+}
+
+// T returns the transpose of the matrix, without copying the underlying data.
+func (mat denseMatrix) T() Matrix {
+	mat.transposed = !mat.transposed
+	return mat
+}
+
+// writeMatrix returns the named containers (default: all columns) as a Matrix, handling null values according to policy.
+func writeMatrix(df *DataFrame, names []string, policy MatrixNullPolicy, fillValue float64) (Matrix, error) {
+	containers := df.values
+	if len(names) > 0 {
+		mergedLabelsAndCols := append(df.labels, df.values...)
+		containers = make([]*valueContainer, len(names))
+		for k := range names {
+			index, err := indexOfContainer(names[k], mergedLabelsAndCols)
+			if err != nil {
+				return nil, err
+			}
+			containers[k] = mergedLabelsAndCols[index]
+		}
+	}
+	if policy < MatrixNullError || policy > MatrixNullFill {
+		return nil, fmt.Errorf("unsupported null policy (%d)", policy)
+	}
+	columns := make([][]float64, len(containers))
+	dropRows := make(map[int]bool)
+	for k := range containers {
+		v := reflect.ValueOf(containers[k].slice)
+		switch v.Type().Elem().Kind() {
+		case reflect.Float64:
+			columns[k] = containers[k].slice.([]float64)
+		case reflect.Float32,
+			reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+			reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			columns[k] = make([]float64, v.Len())
+			for i := range columns[k] {
+				columns[k][i] = v.Index(i).Convert(reflect.TypeOf(float64(0))).Float()
+			}
+		default:
+			return nil, fmt.Errorf("column (%s) is not numeric (%T)", containers[k].name, containers[k].slice)
+		}
+		var nulls []int
+		for i := range containers[k].isNull {
+			if containers[k].isNull[i] {
+				nulls = append(nulls, i)
+			}
+		}
+		if len(nulls) == 0 {
+			continue
+		}
+		switch policy {
+		case MatrixNullError:
+			return nil, fmt.Errorf("column (%s) has null value at row %d", containers[k].name, nulls[0])
+		case MatrixNullDropRow:
+			for _, i := range nulls {
+				dropRows[i] = true
+			}
+		case MatrixNullFill:
+			if v.Type().Elem().Kind() == reflect.Float64 {
+				// copy to avoid changing shared data
+				columns[k] = append([]float64{}, columns[k]...)
+			}
+			for _, i := range nulls {
+				columns[k][i] = fillValue
+			}
+		}
+	}
+	numRows := df.Len()
+	if len(dropRows) > 0 {
+		numRows -= len(dropRows)
+		for k := range columns {
+			kept := make([]float64, 0, numRows)
+			for i := range columns[k] {
+				if !dropRows[i] {
+					kept = append(kept, columns[k][i])
+				}
+			}
+			columns[k] = kept
+		}
+	}
+	return denseMatrix{columns: columns, numRows: numRows}, nil
+}
+
+// -- snapshots
+
+const (
+	snapshotFormat  = "tada snapshot"
+	snapshotVersion = 1
+)
+
+// snapshotDTypes maps the slice type recorded in a snapshot to the slice type to decode into.
+var snapshotDTypes = map[string]reflect.Type{}
+
+func init() {
+	for _, slice := range []interface{}{
+		[]float64{}, []float32{}, []string{}, []bool{}, []interface{}{}, [][]byte{},
+		[]int{}, []int8{}, []int16{}, []int32{}, []int64{},
+		[]uint{}, []uint8{}, []uint16{}, []uint32{}, []uint64{},
+		[]time.Time{}, []civil.Date{}, []civil.Time{}, []civil.DateTime{}, []*big.Rat{}, []time.Duration{},
+	} {
+		snapshotDTypes[reflect.TypeOf(slice).String()] = reflect.TypeOf(slice)
+	}
+	snapshotDTypes[reflect.TypeOf([]Category{}).String()] = reflect.TypeOf(categorySlice{})
+	// concrete types that may be stored in []interface{}
+	for _, v := range []interface{}{time.Time{}, civil.Date{}, civil.Time{}, civil.DateTime{}} {
+		gob.Register(v)
+	}
+}
+
+// snapshotHeader is written once at the start of a snapshot.
+type snapshotHeader struct {
+	Format        string
+	Version       int
+	Name          string
+	ColLevelNames []string
+	NumLabels     int
+	NumColumns    int
+}
+
+// snapshotContainer is written before the slice of every container.
+// Lengths and flags are recorded because gob does not distinguish between nil and empty slices.
+type snapshotContainer struct {
+	DType    string
+	Len      int
+	IsNull   []bool
+	Cache    []string
+	HasCache bool
+	Name     string
+	ID       string
+	// Location is the name of the location shared by all []time.Time values, if any,
+	// because gob records only the offset of each time
+	Location string
+}
+
+// categorySlice gob- and JSON-encodes []Category as one dictionary and a code for every row.
+// If the values do not all share the same dictionary, they are encoded with a new dictionary of all their values.
+type categorySlice []Category
+
+type categorySliceAlias struct {
+	Categories []string `json:"categories"`
+	Codes      []int32  `json:"codes"` // -1: null, -2: no dictionary
+}
+
+// alias returns the dictionary and codes of arr.
+func (arr categorySlice) alias() categorySliceAlias {
+	var dict *categoryDictionary
+	for i := range arr {
+		if arr[i].dict == nil {
+			continue
+		}
+		if dict != nil && arr[i].dict != dict {
+			dict = nil
+			break
+		}
+		dict = arr[i].dict
+	}
+	if dict == nil {
+		// no shared dictionary: re-encode from the values
+		vals := categoryStrings(arr)
+		isNull := make([]bool, len(arr))
+		for i := range arr {
+			isNull[i] = arr[i].Code() < 0
+		}
+		encoded := newCategories(vals, isNull)
+		for i := range arr {
+			if arr[i].dict == nil {
+				encoded[i] = Category{}
+			}
+		}
+		arr = encoded
+		if len(arr) > 0 {
+			dict = arr[0].dict
+		}
+	}
+	alias := categorySliceAlias{Codes: make([]int32, len(arr))}
+	if dict != nil {
+		alias.Categories = dict.values
+	}
+	for i := range arr {
+		if arr[i].dict == nil {
+			alias.Codes[i] = -2
+		} else {
+			alias.Codes[i] = arr[i].code
+		}
+	}
+	return alias
+}
+
+// categories decodes the values encoded by categorySlice.alias.
+func (alias categorySliceAlias) categories() (categorySlice, error) {
+	dict := &categoryDictionary{values: alias.Categories, codes: make(map[string]int32, len(alias.Categories))}
+	for code, v := range dict.values {
+		dict.codes[v] = int32(code)
+	}
+	ret := make(categorySlice, len(alias.Codes))
+	for i, code := range alias.Codes {
+		if code == -2 {
+			continue
+		}
+		if code < -1 || int(code) >= len(dict.values) {
+			return nil, fmt.Errorf("category code (%d) out of range", code)
+		}
+		ret[i] = Category{code: code, dict: dict}
+	}
+	return ret, nil
+}
+
+// GobEncode implements gob.GobEncoder.
+func (arr categorySlice) GobEncode() ([]byte, error) {
+	b := new(bytes.Buffer)
+	err := gob.NewEncoder(b).Encode(arr.alias())
+	return b.Bytes(), err
+}
+
+// GobDecode implements gob.GobDecoder.
+func (arr *categorySlice) GobDecode(b []byte) error {
+	var alias categorySliceAlias
+	err := gob.NewDecoder(bytes.NewReader(b)).Decode(&alias)
+	if err != nil {
+		return err
+	}
+	*arr, err = alias.categories()
+	return err
+}
+
+// MarshalJSON implements json.Marshaler.
+func (arr categorySlice) MarshalJSON() ([]byte, error) {
+	return json.Marshal(arr.alias())
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (arr *categorySlice) UnmarshalJSON(b []byte) error {
+	var alias categorySliceAlias
+	err := json.Unmarshal(b, &alias)
+	if err != nil {
+		return err
+	}
+	*arr, err = alias.categories()
+	return err
+}
+
+// writeSnapshot gob-encodes a header, then every label level and column in df.
+func writeSnapshot(w io.Writer, df *DataFrame) error {
+	enc := gob.NewEncoder(w)
+	err := enc.Encode(snapshotHeader{
+		Format:        snapshotFormat,
+		Version:       snapshotVersion,
+		Name:          df.name,
+		ColLevelNames: df.colLevelNames,
+		NumLabels:     len(df.labels),
+		NumColumns:    len(df.values),
+	})
+	if err != nil {
+		return err
+	}
+	for _, vc := range append(df.labels, df.values...) {
+		dtype := reflect.TypeOf(vc.slice).String()
+		if _, ok := snapshotDTypes[dtype]; !ok {
+			return fmt.Errorf("%s: unsupported type (%s)", vc.name, dtype)
+		}
+		meta := snapshotContainer{
+			DType:    dtype,
+			Len:      vc.len(),
+			IsNull:   vc.isNull,
+			Cache:    vc.cache,
+			HasCache: vc.cache != nil,
+			Name:     vc.name,
+			ID:       vc.id,
+		}
+		if times, ok := vc.slice.([]time.Time); ok {
+			if loc := sharedLocation(times, vc.isNull); loc != nil {
+				meta.Location = loc.String()
+			}
+		}
+		err := enc.Encode(meta)
+		if err != nil {
+			return fmt.Errorf("%s: %v", vc.name, err)
+		}
+		slice := vc.slice
+		if arr, ok := slice.([]Category); ok {
+			slice = categorySlice(arr)
+		}
+		if _, ok := slice.([]*big.Rat); ok {
+			// gob cannot encode nil elements, so nil (null) values are written as 0
+			slice = vc.copy().decimal().slice
+		}
+		err = enc.Encode(slice)
+		if err != nil {
+			return fmt.Errorf("%s: %v", vc.name, err)
+		}
+	}
+	return nil
+}
+
+// readSnapshot decodes a DataFrame written by writeSnapshot.
+func readSnapshot(r io.Reader) (*DataFrame, error) {
+	dec := gob.NewDecoder(r)
+	var header snapshotHeader
+	err := dec.Decode(&header)
+	if err != nil {
+		return nil, err
+	}
+	if header.Format != snapshotFormat {
+		return nil, fmt.Errorf("not a snapshot")
+	}
+	if header.Version > snapshotVersion {
+		return nil, fmt.Errorf("unsupported version (%d)", header.Version)
+	}
+	containers := make([]*valueContainer, header.NumLabels+header.NumColumns)
+	for k := range containers {
+		var meta snapshotContainer
+		err := dec.Decode(&meta)
+		if err != nil {
+			return nil, err
+		}
+		sliceType, ok := snapshotDTypes[meta.DType]
+		if !ok {
+			return nil, fmt.Errorf("%s: unsupported type (%s)", meta.Name, meta.DType)
+		}
+		slice := reflect.New(sliceType)
+		err = dec.Decode(slice.Interface())
+		if err != nil {
+			return nil, fmt.Errorf("%s: %v", meta.Name, err)
+		}
+		if slice.Elem().Len() != meta.Len || len(meta.IsNull) != meta.Len {
+			return nil, fmt.Errorf("%s: length of values (%d) and null values (%d) must be %d",
+				meta.Name, slice.Elem().Len(), len(meta.IsNull), meta.Len)
+		}
+		if meta.Len == 0 {
+			slice.Elem().Set(reflect.MakeSlice(sliceType, 0, 0))
+			meta.IsNull = []bool{}
+			if meta.HasCache {
+				meta.Cache = []string{}
+			}
+		}
+		values := slice.Elem().Interface()
+		if arr, ok := values.(categorySlice); ok {
+			values = []Category(arr)
+		}
+		if times, ok := values.([]time.Time); ok && meta.Location != "" {
+			err := restoreLocation(times, meta.IsNull, meta.Location)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %v", meta.Name, err)
+			}
+		}
+		containers[k] = &valueContainer{
+			slice:  values,
+			isNull: meta.IsNull,
+			cache:  meta.Cache,
+			name:   meta.Name,
+			id:     meta.ID,
+		}
+	}
+	return &DataFrame{
+		labels:        containers[:header.NumLabels],
+		values:        containers[header.NumLabels:],
+		name:          header.Name,
+		colLevelNames: header.ColLevelNames,
+	}, nil
+}
@@ -2,10 +2,12 @@ package tada
 
 import (
 	"bytes"
+	"database/sql"
 	"errors"
 	"fmt"
 	"log"
 	"math"
+	"math/big"
 	"os"
 	"reflect"
 	"testing"
@@ -22,6 +24,15 @@ func (c mockClock) now() time.Time {
 
 var mockID = tadaID + fmt.Sprint(int(mockClock{}.now().UnixNano()))
 
+// mockDecimal parses s as a *big.Rat, and panics if s is not a valid decimal.
+func mockDecimal(s string) *big.Rat {
+	ret, ok := new(big.Rat).SetString(s)
+	if !ok {
+		panic(fmt.Sprintf("invalid decimal (%s)", s))
+	}
+	return ret
+}
+
 func TestMain(m *testing.M) {
 	DisableWarnings()
 	clock = mockClock{}
@@ -406,6 +417,23 @@ func Test_valueContainer_copy(t *testing.T) {
 	}
 }
 
+func Test_valueContainer_copy_decimal(t *testing.T) {
+	vc := &valueContainer{
+		slice:  []*big.Rat{mockDecimal("1.5"), new(big.Rat)},
+		isNull: []bool{false, true},
+		name:   "foo",
+	}
+	got := vc.copy()
+	if !reflect.DeepEqual(got, vc) {
+		t.Errorf("valueContainer.copy() = %v, want %v", got, vc)
+	}
+	got.slice.([]*big.Rat)[0].SetInt64(2)
+	if want := mockDecimal("1.5"); vc.slice.([]*big.Rat)[0].Cmp(want) != 0 {
+		t.Errorf("valueContainer.copy() retained reference to original decimal, got %v, want %v",
+			vc.slice.([]*big.Rat)[0], want)
+	}
+}
+
 func Test_makeDefaultLabels(t *testing.T) {
 	type args struct {
 		min       int
@@ -680,6 +708,8 @@ func Test_difference(t *testing.T) {
 }
 
 func Test_reduceContainers(t *testing.T) {
+	categories := newCategories([]string{"qux", "", "qux", "bar"}, []bool{false, true, false, false})
+	otherCategories := newCategories([]string{"qux"}, []bool{false})
 	type args struct {
 		containers []*valueContainer
 	}
@@ -719,6 +749,24 @@ func Test_reduceContainers(t *testing.T) {
 			},
 			wantOriginalRowIndexes: [][]int{{0, 2}, {1}},
 			wantOrderedKeys:        []string{"bar", ""}},
+		{name: "single level - categorical",
+			args: args{containers: []*valueContainer{
+				{slice: categories, isNull: []bool{false, true, false, false}, id: mockID, name: "baz"},
+			}},
+			wantNewContainers: []*valueContainer{
+				{slice: []Category{categories[0], categories[1], categories[3]}, isNull: []bool{false, true, false}, id: mockID, name: "baz"},
+			},
+			wantOriginalRowIndexes: [][]int{{0, 2}, {1}, {3}},
+			wantOrderedKeys:        []string{"qux", "", "bar"}},
+		{name: "single level - categorical - multiple dictionaries",
+			args: args{containers: []*valueContainer{
+				{slice: []Category{categories[0], otherCategories[0]}, isNull: []bool{false, false}, id: mockID, name: "baz"},
+			}},
+			wantNewContainers: []*valueContainer{
+				{slice: []Category{categories[0]}, isNull: []bool{false}, id: mockID, name: "baz"},
+			},
+			wantOriginalRowIndexes: [][]int{{0, 1}},
+			wantOrderedKeys:        []string{"qux"}},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -1344,9 +1392,10 @@ func Test_valueContainer_sort(t *testing.T) {
 		id     string
 	}
 	type args struct {
-		dtype     DType
-		ascending bool
-		index     []int
+		dtype         DType
+		categoryOrder []string
+		ascending     bool
+		index         []int
 	}
 	tests := []struct {
 		name   string
@@ -1366,6 +1415,15 @@ func Test_valueContainer_sort(t *testing.T) {
 		{"float - nulls",
 			fields{slice: []float64{3, 1, 0, 2}, isNull: []bool{false, false, true, false}, id: mockID, name: "foo"},
 			args{dtype: Float64, ascending: true, index: []int{0, 1, 2, 3}}, []int{1, 3, 0, 2}},
+		{"int64 - beyond float precision",
+			fields{slice: []int64{9007199254740993, 9007199254740992, 1}, isNull: []bool{false, false, false}, id: mockID, name: "foo"},
+			args{dtype: Int64, ascending: true, index: []int{0, 1, 2}}, []int{2, 1, 0}},
+		{"int64 - nulls - descending",
+			fields{slice: []int64{3, 1, 0, 2}, isNull: []bool{false, false, true, false}, id: mockID, name: "foo"},
+			args{dtype: Int64, ascending: false, index: []int{0, 1, 2, 3}}, []int{0, 3, 1, 2}},
+		{"bool - convert from string",
+			fields{slice: []string{"true", "false", "true"}, isNull: []bool{false, false, false}, id: mockID, name: "foo"},
+			args{dtype: Bool, ascending: true, index: []int{0, 1, 2}}, []int{1, 0, 2}},
 		{"strings - no nulls",
 			fields{slice: []string{"foo", "bar", "a", "baz"}, isNull: []bool{false, false, false, false}, id: mockID, name: "foo"},
 			args{dtype: String, ascending: true, index: []int{0, 1, 2, 3}}, []int{2, 1, 3, 0}},
@@ -1401,6 +1459,23 @@ func Test_valueContainer_sort(t *testing.T) {
 				time.Date(1, 1, 1, 9, 45, 1, 0, time.UTC)},
 				isNull: []bool{false, false, false, false}, id: mockID, name: "foo"},
 			args{dtype: Date, ascending: false, index: []int{0, 1, 2, 3}}, []int{0, 3, 1, 2}},
+		{"decimal",
+			fields{slice: []*big.Rat{mockDecimal("0.3"), mockDecimal("0.1"), new(big.Rat), mockDecimal("-2")},
+				isNull: []bool{false, false, true, false}, id: mockID, name: "foo"},
+			args{dtype: Decimal, ascending: true, index: []int{0, 1, 2, 3}}, []int{3, 1, 0, 2}},
+		{"categorical - dictionary order",
+			fields{slice: newCategories([]string{"low", "high", "medium", "high"}, []bool{false, false, false, false}),
+				isNull: []bool{false, false, false, false}, id: mockID, name: "foo"},
+			args{dtype: Categorical, ascending: true, index: []int{0, 1, 2, 3}}, []int{1, 3, 0, 2}},
+		{"categorical - explicit order - nulls",
+			fields{slice: newCategories([]string{"low", "high", "medium", "", "other"}, []bool{false, false, false, true, false}),
+				isNull: []bool{false, false, false, true, false}, id: mockID, name: "foo"},
+			args{dtype: Categorical, categoryOrder: []string{"low", "medium", "high"}, ascending: true, index: []int{0, 1, 2, 3, 4}},
+			[]int{0, 2, 1, 4, 3}},
+		{"categorical - explicit order - descending",
+			fields{slice: []string{"low", "high", "medium"}, isNull: []bool{false, false, false}, id: mockID, name: "foo"},
+			args{dtype: Categorical, categoryOrder: []string{"low", "medium", "high"}, ascending: false, index: []int{0, 1, 2}},
+			[]int{1, 2, 0}},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -1410,7 +1485,7 @@ func Test_valueContainer_sort(t *testing.T) {
 				name:   tt.fields.name,
 				id:     tt.fields.id,
 			}
-			if got := vc.sort(tt.args.dtype, tt.args.ascending, tt.args.index); !reflect.DeepEqual(got, tt.want) {
+			if got := vc.sort(tt.args.dtype, tt.args.categoryOrder, tt.args.ascending, tt.args.index); !reflect.DeepEqual(got, tt.want) {
 				t.Errorf("valueContainer.sort() = %v, want %v", got, tt.want)
 			}
 		})
@@ -1530,6 +1605,54 @@ func Test_inferType(t *testing.T) {
 	}
 }
 
+func Test_inferType_intAndBool(t *testing.T) {
+	SetOptionInferIntAndBool(true)
+	defer SetOptionInferIntAndBool(false)
+	type args struct {
+		input string
+	}
+	tests := []struct {
+		name string
+		args args
+		want DType
+	}{
+		{"int", args{"9007199254740993"}, Int64},
+		{"float", args{"1.5"}, Float64},
+		{"bool", args{"true"}, Bool},
+		{"not bool", args{"t"}, String},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := inferType(tt.args.input); got != tt.want {
+				t.Errorf("inferType() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_valueContainer_inferType_intAndFloat(t *testing.T) {
+	SetOptionInferIntAndBool(true)
+	defer SetOptionInferIntAndBool(false)
+	tests := []struct {
+		name  string
+		slice []string
+		want  DType
+	}{
+		{"int", []string{"1", "2", "3"}, Int64},
+		{"mostly int", []string{"1", "2", "3", "1.5"}, Float64},
+		{"float after sample", []string{"1", "2", "3", "4", "5", "6", "7", "8", "9", "10", "11.5"}, Float64},
+		{"string after sample", []string{"1", "2", "3", "4", "5", "6", "7", "8", "9", "10", "foo"}, Int64},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			vc := &valueContainer{slice: tt.slice, isNull: make([]bool, len(tt.slice))}
+			if got := vc.inferType(); got != tt.want {
+				t.Errorf("valueContainer.inferType() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
 func Test_valueContainer_apply(t *testing.T) {
 	type fields struct {
 		slice  interface{}
@@ -2075,12 +2198,432 @@ func Test_max(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got, got1 := max(tt.args.vals, tt.args.isNull, tt.args.index)
-			if got != tt.want {
-				t.Errorf("max() got = %v, want %v", got, tt.want)
+			got, got1 := max(tt.args.vals, tt.args.isNull, tt.args.index)
+			if got != tt.want {
+				t.Errorf("max() got = %v, want %v", got, tt.want)
+			}
+			if got1 != tt.want1 {
+				t.Errorf("max() got1 = %v, want %v", got1, tt.want1)
+			}
+		})
+	}
+}
+
+func Test_sumInt64(t *testing.T) {
+	type args struct {
+		vals   []int64
+		isNull []bool
+		index  []int
+	}
+	tests := []struct {
+		name  string
+		args  args
+		want  int64
+		want1 bool
+	}{
+		{"exact beyond float precision", args{
+			[]int64{9007199254740992, 1, 100}, []bool{false, false, true}, []int{0, 1, 2}},
+			9007199254740993, false},
+		{"overflow", args{
+			[]int64{math.MaxInt64, 1}, []bool{false, false}, []int{0, 1}},
+			0, true},
+		{"all null", args{
+			[]int64{1, 2, 3}, []bool{false, true, true}, []int{1, 2}},
+			0, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, got1 := sumInt64(tt.args.vals, tt.args.isNull, tt.args.index)
+			if got != tt.want {
+				t.Errorf("sumInt64() got = %v, want %v", got, tt.want)
+			}
+			if got1 != tt.want1 {
+				t.Errorf("sumInt64() got1 = %v, want %v", got1, tt.want1)
+			}
+		})
+	}
+}
+
+func Test_minInt64(t *testing.T) {
+	type args struct {
+		vals   []int64
+		isNull []bool
+		index  []int
+	}
+	tests := []struct {
+		name  string
+		args  args
+		want  int64
+		want1 bool
+	}{
+		{"at least one valid", args{
+			[]int64{3, 1, 2}, []bool{false, true, false}, []int{0, 1, 2}},
+			2, false},
+		{"all null", args{
+			[]int64{1, 2, 3}, []bool{false, true, true}, []int{1, 2}},
+			0, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, got1 := minInt64(tt.args.vals, tt.args.isNull, tt.args.index)
+			if got != tt.want {
+				t.Errorf("minInt64() got = %v, want %v", got, tt.want)
+			}
+			if got1 != tt.want1 {
+				t.Errorf("minInt64() got1 = %v, want %v", got1, tt.want1)
+			}
+		})
+	}
+}
+
+func Test_maxInt64(t *testing.T) {
+	type args struct {
+		vals   []int64
+		isNull []bool
+		index  []int
+	}
+	tests := []struct {
+		name  string
+		args  args
+		want  int64
+		want1 bool
+	}{
+		{"at least one valid", args{
+			[]int64{1, 3, 2}, []bool{false, true, false}, []int{0, 1, 2}},
+			2, false},
+		{"all null", args{
+			[]int64{1, 2, 3}, []bool{false, true, true}, []int{1, 2}},
+			0, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, got1 := maxInt64(tt.args.vals, tt.args.isNull, tt.args.index)
+			if got != tt.want {
+				t.Errorf("maxInt64() got = %v, want %v", got, tt.want)
+			}
+			if got1 != tt.want1 {
+				t.Errorf("maxInt64() got1 = %v, want %v", got1, tt.want1)
+			}
+		})
+	}
+}
+
+func Test_parseISODuration(t *testing.T) {
+	tests := []struct {
+		name    string
+		s       string
+		want    time.Duration
+		wantErr bool
+	}{
+		{"hours and minutes", "PT1H30M", 90 * time.Minute, false},
+		{"weeks and days", "P1W2D", 9 * 24 * time.Hour, false},
+		{"days and time", "P1DT2H", 26 * time.Hour, false},
+		{"fractional seconds", "PT1.5S", 1500 * time.Millisecond, false},
+		{"fractional comma", "PT0,25H", 15 * time.Minute, false},
+		{"negative", "-PT10S", -10 * time.Second, false},
+		{"fail - years", "P1Y", 0, true},
+		{"fail - months", "P1M", 0, true},
+		{"fail - no components", "P", 0, true},
+		{"fail - empty time", "PT", 0, true},
+		{"fail - not iso", "1h30m", 0, true},
+		{"fail - overflow", "P100000W", 0, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseISODuration(tt.s)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("parseISODuration() error = %v, want %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("parseISODuration() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_sumDuration(t *testing.T) {
+	type args struct {
+		vals   []time.Duration
+		isNull []bool
+		index  []int
+	}
+	tests := []struct {
+		name  string
+		args  args
+		want  time.Duration
+		want1 bool
+	}{
+		{"pass", args{[]time.Duration{time.Hour, time.Minute, time.Second}, []bool{false, false, true}, []int{0, 1, 2}},
+			time.Hour + time.Minute, false},
+		{"overflow is null", args{[]time.Duration{math.MaxInt64, 1}, []bool{false, false}, []int{0, 1}},
+			0, true},
+		{"all null", args{[]time.Duration{time.Hour}, []bool{true}, []int{0}},
+			0, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, got1 := sumDuration(tt.args.vals, tt.args.isNull, tt.args.index)
+			if got != tt.want {
+				t.Errorf("sumDuration() got = %v, want %v", got, tt.want)
+			}
+			if got1 != tt.want1 {
+				t.Errorf("sumDuration() got1 = %v, want %v", got1, tt.want1)
+			}
+		})
+	}
+}
+
+func Test_meanDuration(t *testing.T) {
+	type args struct {
+		vals   []time.Duration
+		isNull []bool
+		index  []int
+	}
+	tests := []struct {
+		name  string
+		args  args
+		want  time.Duration
+		want1 bool
+	}{
+		{"pass", args{[]time.Duration{time.Hour, 2 * time.Hour, time.Second}, []bool{false, false, true}, []int{0, 1, 2}},
+			90 * time.Minute, false},
+		{"no overflow", args{[]time.Duration{math.MaxInt64, math.MaxInt64}, []bool{false, false}, []int{0, 1}},
+			math.MaxInt64, false},
+		{"all null", args{[]time.Duration{time.Hour}, []bool{true}, []int{0}},
+			0, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, got1 := meanDuration(tt.args.vals, tt.args.isNull, tt.args.index)
+			if got != tt.want {
+				t.Errorf("meanDuration() got = %v, want %v", got, tt.want)
+			}
+			if got1 != tt.want1 {
+				t.Errorf("meanDuration() got1 = %v, want %v", got1, tt.want1)
+			}
+		})
+	}
+}
+
+func Test_medianDuration(t *testing.T) {
+	type args struct {
+		vals   []time.Duration
+		isNull []bool
+		index  []int
+	}
+	tests := []struct {
+		name  string
+		args  args
+		want  time.Duration
+		want1 bool
+	}{
+		{"odd", args{[]time.Duration{3 * time.Hour, time.Hour, 2 * time.Hour}, []bool{false, false, false}, []int{0, 1, 2}},
+			2 * time.Hour, false},
+		{"even", args{[]time.Duration{3 * time.Hour, time.Hour, 2 * time.Hour, 4 * time.Hour, 0}, []bool{false, false, false, false, true}, []int{0, 1, 2, 3, 4}},
+			150 * time.Minute, false},
+		{"all null", args{[]time.Duration{time.Hour}, []bool{true}, []int{0}},
+			0, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, got1 := medianDuration(tt.args.vals, tt.args.isNull, tt.args.index)
+			if got != tt.want {
+				t.Errorf("medianDuration() got = %v, want %v", got, tt.want)
+			}
+			if got1 != tt.want1 {
+				t.Errorf("medianDuration() got1 = %v, want %v", got1, tt.want1)
+			}
+		})
+	}
+}
+
+func Test_minDuration(t *testing.T) {
+	type args struct {
+		vals   []time.Duration
+		isNull []bool
+		index  []int
+	}
+	tests := []struct {
+		name  string
+		args  args
+		want  time.Duration
+		want1 bool
+	}{
+		{"pass", args{[]time.Duration{time.Hour, -time.Minute, -time.Hour}, []bool{false, false, true}, []int{0, 1, 2}},
+			-time.Minute, false},
+		{"all null", args{[]time.Duration{time.Hour}, []bool{true}, []int{0}},
+			0, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, got1 := minDuration(tt.args.vals, tt.args.isNull, tt.args.index)
+			if got != tt.want {
+				t.Errorf("minDuration() got = %v, want %v", got, tt.want)
+			}
+			if got1 != tt.want1 {
+				t.Errorf("minDuration() got1 = %v, want %v", got1, tt.want1)
+			}
+		})
+	}
+}
+
+func Test_maxDuration(t *testing.T) {
+	type args struct {
+		vals   []time.Duration
+		isNull []bool
+		index  []int
+	}
+	tests := []struct {
+		name  string
+		args  args
+		want  time.Duration
+		want1 bool
+	}{
+		{"pass", args{[]time.Duration{-time.Hour, -time.Minute, time.Hour}, []bool{false, false, true}, []int{0, 1, 2}},
+			-time.Minute, false},
+		{"all null", args{[]time.Duration{time.Hour}, []bool{true}, []int{0}},
+			0, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, got1 := maxDuration(tt.args.vals, tt.args.isNull, tt.args.index)
+			if got != tt.want {
+				t.Errorf("maxDuration() got = %v, want %v", got, tt.want)
+			}
+			if got1 != tt.want1 {
+				t.Errorf("maxDuration() got1 = %v, want %v", got1, tt.want1)
+			}
+		})
+	}
+}
+
+func Test_sumDecimal(t *testing.T) {
+	type args struct {
+		vals   []*big.Rat
+		isNull []bool
+		index  []int
+	}
+	tests := []struct {
+		name  string
+		args  args
+		want  string
+		want1 bool
+	}{
+		{"exact", args{
+			[]*big.Rat{mockDecimal("0.1"), mockDecimal("0.2"), mockDecimal("100")}, []bool{false, false, true}, []int{0, 1, 2}},
+			"0.3", false},
+		{"all null", args{
+			[]*big.Rat{mockDecimal("1"), new(big.Rat)}, []bool{false, true}, []int{1}},
+			"0", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, got1 := sumDecimal(tt.args.vals, tt.args.isNull, tt.args.index)
+			if formatDecimal(got) != tt.want {
+				t.Errorf("sumDecimal() got = %v, want %v", formatDecimal(got), tt.want)
+			}
+			if got1 != tt.want1 {
+				t.Errorf("sumDecimal() got1 = %v, want %v", got1, tt.want1)
+			}
+		})
+	}
+}
+
+func Test_meanDecimal(t *testing.T) {
+	type args struct {
+		vals   []*big.Rat
+		isNull []bool
+		index  []int
+	}
+	tests := []struct {
+		name  string
+		args  args
+		want  string
+		want1 bool
+	}{
+		{"exact", args{
+			[]*big.Rat{mockDecimal("0.1"), mockDecimal("0.2"), mockDecimal("100")}, []bool{false, false, true}, []int{0, 1, 2}},
+			"0.15", false},
+		{"non-terminating", args{
+			[]*big.Rat{mockDecimal("1"), mockDecimal("0"), mockDecimal("0")}, []bool{false, false, false}, []int{0, 1, 2}},
+			"0.3333333333333333", false},
+		{"all null", args{
+			[]*big.Rat{mockDecimal("1"), new(big.Rat)}, []bool{false, true}, []int{1}},
+			"0", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, got1 := meanDecimal(tt.args.vals, tt.args.isNull, tt.args.index)
+			if formatDecimal(got) != tt.want {
+				t.Errorf("meanDecimal() got = %v, want %v", formatDecimal(got), tt.want)
+			}
+			if got1 != tt.want1 {
+				t.Errorf("meanDecimal() got1 = %v, want %v", got1, tt.want1)
+			}
+		})
+	}
+}
+
+func Test_minDecimal(t *testing.T) {
+	type args struct {
+		vals   []*big.Rat
+		isNull []bool
+		index  []int
+	}
+	tests := []struct {
+		name  string
+		args  args
+		want  string
+		want1 bool
+	}{
+		{"at least one valid", args{
+			[]*big.Rat{mockDecimal("3"), mockDecimal("-1.5"), mockDecimal("-2")}, []bool{false, false, true}, []int{0, 1, 2}},
+			"-1.5", false},
+		{"all null", args{
+			[]*big.Rat{mockDecimal("1"), new(big.Rat)}, []bool{false, true}, []int{1}},
+			"0", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, got1 := minDecimal(tt.args.vals, tt.args.isNull, tt.args.index)
+			if formatDecimal(got) != tt.want {
+				t.Errorf("minDecimal() got = %v, want %v", formatDecimal(got), tt.want)
+			}
+			if got1 != tt.want1 {
+				t.Errorf("minDecimal() got1 = %v, want %v", got1, tt.want1)
+			}
+		})
+	}
+}
+
+func Test_maxDecimal(t *testing.T) {
+	type args struct {
+		vals   []*big.Rat
+		isNull []bool
+		index  []int
+	}
+	tests := []struct {
+		name  string
+		args  args
+		want  string
+		want1 bool
+	}{
+		{"at least one valid", args{
+			[]*big.Rat{mockDecimal("3.01"), mockDecimal("3.1"), mockDecimal("4")}, []bool{false, false, true}, []int{0, 1, 2}},
+			"3.1", false},
+		{"all null", args{
+			[]*big.Rat{mockDecimal("1"), new(big.Rat)}, []bool{false, true}, []int{1}},
+			"0", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, got1 := maxDecimal(tt.args.vals, tt.args.isNull, tt.args.index)
+			if formatDecimal(got) != tt.want {
+				t.Errorf("maxDecimal() got = %v, want %v", formatDecimal(got), tt.want)
 			}
 			if got1 != tt.want1 {
-				t.Errorf("max() got1 = %v, want %v", got1, tt.want1)
+				t.Errorf("maxDecimal() got1 = %v, want %v", got1, tt.want1)
 			}
 		})
 	}
@@ -2239,6 +2782,267 @@ func Test_valueContainer_fillnull(t *testing.T) {
 	}
 }
 
+func Test_valueContainer_compare(t *testing.T) {
+	d := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	type args struct {
+		other *valueContainer
+	}
+	tests := []struct {
+		name  string
+		vc    *valueContainer
+		args  args
+		want  []int
+		want1 []bool
+	}{
+		{"float64 and int", &valueContainer{slice: []float64{1, 2, 3}, isNull: []bool{false, false, true}},
+			args{&valueContainer{slice: []int{2, 2, 2}, isNull: []bool{false, false, false}}},
+			[]int{-1, 0, 0}, []bool{false, false, true}},
+		{"int64 - exact", &valueContainer{slice: []int64{9007199254740993}, isNull: []bool{false}},
+			args{&valueContainer{slice: []int{9007199254740992}, isNull: []bool{false}}},
+			[]int{1}, []bool{false}},
+		{"string - parsed as number", &valueContainer{slice: []string{"10", "foo"}, isNull: []bool{false, false}},
+			args{&valueContainer{slice: []float64{9, 9}, isNull: []bool{false, false}}},
+			[]int{1, 0}, []bool{false, true}},
+		{"string", &valueContainer{slice: []string{"a", "b"}, isNull: []bool{false, false}},
+			args{&valueContainer{slice: []string{"b", "b"}, isNull: []bool{false, false}}},
+			[]int{-1, 0}, []bool{false, false}},
+		{"datetime and civil.Date", &valueContainer{slice: []time.Time{d, d.AddDate(0, 0, 1)}, isNull: []bool{false, false}},
+			args{&valueContainer{slice: []civil.Date{{Year: 2020, Month: 1, Day: 1}, {Year: 2020, Month: 1, Day: 1}}, isNull: []bool{false, false}}},
+			[]int{0, 1}, []bool{false, false}},
+		{"duration and string", &valueContainer{slice: []time.Duration{time.Hour}, isNull: []bool{false}},
+			args{&valueContainer{slice: []string{"90m"}, isNull: []bool{false}}},
+			[]int{-1}, []bool{false}},
+		{"decimal", &valueContainer{slice: []*big.Rat{mockDecimal("0.3")}, isNull: []bool{false}},
+			args{&valueContainer{slice: []string{"0.30"}, isNull: []bool{false}}},
+			[]int{0}, []bool{false}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			originalNulls := copyNulls(tt.vc.isNull)
+			got, got1 := tt.vc.compare(tt.args.other)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("valueContainer.compare() got = %v, want %v", got, tt.want)
+			}
+			if !reflect.DeepEqual(got1, tt.want1) {
+				t.Errorf("valueContainer.compare() got1 = %v, want %v", got1, tt.want1)
+			}
+			if !reflect.DeepEqual(tt.vc.isNull, originalNulls) {
+				t.Errorf("valueContainer.compare() modified original nulls: %v, want %v", tt.vc.isNull, originalNulls)
+			}
+		})
+	}
+}
+
+func Test_valueContainer_isIn(t *testing.T) {
+	type args struct {
+		values *valueContainer
+	}
+	tests := []struct {
+		name  string
+		vc    *valueContainer
+		args  args
+		want  []bool
+		want1 []bool
+	}{
+		{"float64 and int", &valueContainer{slice: []float64{1, 2, 3}, isNull: []bool{false, false, true}},
+			args{&valueContainer{slice: []int{2, 3}, isNull: []bool{false, false}}},
+			[]bool{false, true, false}, []bool{false, false, true}},
+		{"string - ignores null values", &valueContainer{slice: []string{"foo", "bar"}, isNull: []bool{false, false}},
+			args{&valueContainer{slice: []string{"foo", "bar"}, isNull: []bool{false, true}}},
+			[]bool{true, false}, []bool{false, false}},
+		{"datetime - different locations", &valueContainer{slice: []time.Time{time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)}, isNull: []bool{false}},
+			args{&valueContainer{slice: []time.Time{time.Date(2019, 12, 31, 19, 0, 0, 0, time.FixedZone("EST", -5*60*60))}, isNull: []bool{false}}},
+			[]bool{true}, []bool{false}},
+		{"decimal", &valueContainer{slice: []*big.Rat{mockDecimal("0.1")}, isNull: []bool{false}},
+			args{&valueContainer{slice: []string{"0.10"}, isNull: []bool{false}}},
+			[]bool{true}, []bool{false}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, got1 := tt.vc.isIn(tt.args.values)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("valueContainer.isIn() got = %v, want %v", got, tt.want)
+			}
+			if !reflect.DeepEqual(got1, tt.want1) {
+				t.Errorf("valueContainer.isIn() got1 = %v, want %v", got1, tt.want1)
+			}
+		})
+	}
+}
+
+func Test_maskIndex(t *testing.T) {
+	type args struct {
+		labels []*valueContainer
+		mask   *Series
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    []int
+		wantErr bool
+	}{
+		{"null is false", args{
+			labels: []*valueContainer{{slice: []int{0, 1, 2}, isNull: []bool{false, false, false}, name: "*0"}},
+			mask: &Series{values: &valueContainer{slice: []bool{true, true, false}, isNull: []bool{false, true, false}},
+				labels: []*valueContainer{{slice: []int{0, 1, 2}, isNull: []bool{false, false, false}, name: "*0"}}}},
+			[]int{0}, false},
+		{"aligned by label - missing is false", args{
+			labels: []*valueContainer{{slice: []int{0, 1, 2}, isNull: []bool{false, false, false}, name: "*0"}},
+			mask: &Series{values: &valueContainer{slice: []bool{true, true}, isNull: []bool{false, false}},
+				labels: []*valueContainer{{slice: []int{2, 1}, isNull: []bool{false, false}, name: "*0"}}}},
+			[]int{1, 2}, false},
+		{"fail - no matching labels", args{
+			labels: []*valueContainer{{slice: []int{0}, isNull: []bool{false}, name: "*0"}},
+			mask: &Series{values: &valueContainer{slice: []bool{true}, isNull: []bool{false}},
+				labels: []*valueContainer{{slice: []int{0}, isNull: []bool{false}, name: "foo"}}}},
+			nil, true},
+		{"fail - mask has error", args{
+			labels: []*valueContainer{{slice: []int{0}, isNull: []bool{false}, name: "*0"}},
+			mask:   &Series{err: fmt.Errorf("foo")}},
+			nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := maskIndex(tt.args.labels, tt.args.mask)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("maskIndex() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("maskIndex() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_localizeTime(t *testing.T) {
+	tz, err := time.LoadLocation("America/Los_Angeles")
+	if err != nil {
+		log.Fatal(err)
+	}
+	type args struct {
+		t   time.Time
+		loc *time.Location
+	}
+	tests := []struct {
+		name string
+		args args
+		want time.Time
+	}{
+		{"standard time", args{time.Date(2020, 1, 1, 12, 0, 0, 0, time.UTC), tz},
+			time.Date(2020, 1, 1, 12, 0, 0, 0, tz)},
+		{"replaces existing location", args{time.Date(2020, 7, 1, 12, 0, 0, 0, tz), time.UTC},
+			time.Date(2020, 7, 1, 12, 0, 0, 0, time.UTC)},
+		{"ambiguous - fall back resolves to earlier time", args{time.Date(2020, 11, 1, 1, 30, 0, 0, time.UTC), tz},
+			time.Date(2020, 11, 1, 8, 30, 0, 0, time.UTC).In(tz)},
+		{"nonexistent - spring forward shifts forward", args{time.Date(2020, 3, 8, 2, 30, 0, 0, time.UTC), tz},
+			time.Date(2020, 3, 8, 10, 30, 0, 0, time.UTC).In(tz)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := localizeTime(tt.args.t, tt.args.loc)
+			if !got.Equal(tt.want) || got.Location().String() != tt.want.Location().String() {
+				t.Errorf("localizeTime() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_valueContainer_localize(t *testing.T) {
+	tz, err := time.LoadLocation("America/Los_Angeles")
+	if err != nil {
+		log.Fatal(err)
+	}
+	type fields struct {
+		slice  interface{}
+		isNull []bool
+		name   string
+		cache  []string
+		id     string
+	}
+	type args struct {
+		loc *time.Location
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		want   *valueContainer
+	}{
+		{"time.Time - resets cache", fields{slice: []time.Time{time.Date(2020, 1, 1, 12, 0, 0, 0, time.UTC), {}}, isNull: []bool{false, true},
+			cache: []string{"2020-01-01 12:00:00 +0000 UTC", ""}, id: mockID, name: "foo"},
+			args{tz},
+			&valueContainer{slice: []time.Time{time.Date(2020, 1, 1, 12, 0, 0, 0, tz), {}}, isNull: []bool{false, true}, id: mockID, name: "foo"}},
+		{"civil.Date - midnight in location", fields{slice: []civil.Date{{Year: 2020, Month: 3, Day: 8}}, isNull: []bool{false}, id: mockID, name: "foo"},
+			args{tz},
+			&valueContainer{slice: []time.Time{time.Date(2020, 3, 8, 0, 0, 0, 0, tz)}, isNull: []bool{false}, id: mockID, name: "foo"}},
+		{"civil.DateTime", fields{slice: []civil.DateTime{civil.DateTimeOf(time.Date(2020, 3, 8, 12, 0, 0, 0, time.UTC))}, isNull: []bool{false}, id: mockID, name: "foo"},
+			args{tz},
+			&valueContainer{slice: []time.Time{time.Date(2020, 3, 8, 12, 0, 0, 0, tz)}, isNull: []bool{false}, id: mockID, name: "foo"}},
+		{"nil location is UTC", fields{slice: []string{"2020-01-01T12:00:00Z"}, isNull: []bool{false}, id: mockID, name: "foo"},
+			args{nil},
+			&valueContainer{slice: []time.Time{time.Date(2020, 1, 1, 12, 0, 0, 0, time.UTC)}, isNull: []bool{false}, id: mockID, name: "foo"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			vc := &valueContainer{
+				slice:  tt.fields.slice,
+				isNull: tt.fields.isNull,
+				name:   tt.fields.name,
+				cache:  tt.fields.cache,
+				id:     tt.fields.id,
+			}
+			vc.localize(tt.args.loc)
+			if !reflect.DeepEqual(vc, tt.want) {
+				t.Errorf("vc.localize() -> %v, want %v", vc.slice, tt.want.slice)
+			}
+		})
+	}
+}
+
+func Test_valueContainer_convertTimeZone(t *testing.T) {
+	tz, err := time.LoadLocation("America/Los_Angeles")
+	if err != nil {
+		log.Fatal(err)
+	}
+	type fields struct {
+		slice  interface{}
+		isNull []bool
+		name   string
+		id     string
+	}
+	type args struct {
+		loc *time.Location
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		want   *valueContainer
+	}{
+		{"time.Time", fields{slice: []time.Time{time.Date(2020, 1, 1, 12, 0, 0, 0, time.UTC), {}}, isNull: []bool{false, true}, id: mockID, name: "foo"},
+			args{tz},
+			&valueContainer{slice: []time.Time{time.Date(2020, 1, 1, 4, 0, 0, 0, tz), {}}, isNull: []bool{false, true}, id: mockID, name: "foo"}},
+		{"string with offset", fields{slice: []string{"2020-07-01T12:00:00-07:00"}, isNull: []bool{false}, id: mockID, name: "foo"},
+			args{time.UTC},
+			&valueContainer{slice: []time.Time{time.Date(2020, 7, 1, 19, 0, 0, 0, time.UTC)}, isNull: []bool{false}, id: mockID, name: "foo"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			vc := &valueContainer{
+				slice:  tt.fields.slice,
+				isNull: tt.fields.isNull,
+				name:   tt.fields.name,
+				id:     tt.fields.id,
+			}
+			vc.convertTimeZone(tt.args.loc)
+			if !reflect.DeepEqual(vc, tt.want) {
+				t.Errorf("vc.convertTimeZone() -> %v, want %v", vc.slice, tt.want.slice)
+			}
+		})
+	}
+}
+
 func Test_valueContainer_resample(t *testing.T) {
 	d := time.Date(2020, 2, 2, 12, 30, 45, 0, time.UTC)
 	tz, err := time.LoadLocation("America/Los_Angeles")
@@ -2289,6 +3093,22 @@ func Test_valueContainer_resample(t *testing.T) {
 			args{Resampler{ByDay: true, Location: tz}},
 			&valueContainer{slice: []time.Time{time.Date(2019, 12, 31, 0, 0, 0, 0, tz)},
 				isNull: []bool{false}, id: mockID, name: "foo"}},
+		{"duration - truncates and resets cache",
+			fields{slice: []time.Duration{90 * time.Minute, 26 * time.Hour}, isNull: []bool{false, false}, id: mockID, name: "foo",
+				cache: []string{"1h30m0s", "26h0m0s"}},
+			args{Resampler{ByDuration: time.Hour}},
+			&valueContainer{slice: []time.Duration{time.Hour, 26 * time.Hour},
+				isNull: []bool{false, false}, id: mockID, name: "foo"}},
+		{"duration - day",
+			fields{slice: []time.Duration{26 * time.Hour}, isNull: []bool{false}, id: mockID, name: "foo"},
+			args{Resampler{ByDay: true}},
+			&valueContainer{slice: []time.Duration{24 * time.Hour},
+				isNull: []bool{false}, id: mockID, name: "foo"}},
+		{"duration - month is null",
+			fields{slice: []time.Duration{26 * time.Hour}, isNull: []bool{false}, id: mockID, name: "foo"},
+			args{Resampler{ByMonth: true}},
+			&valueContainer{slice: []time.Duration{0},
+				isNull: []bool{true}, id: mockID, name: "foo"}},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -2355,6 +3175,11 @@ func Test_valueContainer_valueCounts(t *testing.T) {
 			isNull: []bool{false, false, false, true},
 			name:   "foo",
 		}, map[string]int{"1": 2, "2": 1}},
+		{"categorical", fields{
+			slice:  newCategories([]string{"a", "a", "b", ""}, []bool{false, false, false, true}),
+			isNull: []bool{false, false, false, true},
+			name:   "foo",
+		}, map[string]int{"a": 2, "b": 1}},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -4898,6 +5723,36 @@ type testStructNoTags struct {
 type testStructNoFields struct {
 }
 
+type testStructNullable struct {
+	Name  *string         `json:"name"`
+	Age   sql.NullInt64   `json:"age"`
+	Score sql.NullFloat64 `json:"score"`
+}
+
+type testStructLayout struct {
+	Date string `tada:"date,layout=2006-01-02"`
+}
+
+type testStructBadTag struct {
+	Name string `tada:"name,foo"`
+}
+
+type testStructEmbedded struct {
+	ID int `json:"id"`
+}
+
+type testStructAddress struct {
+	City string `json:"city"`
+	Zip  int
+}
+
+type testStructNested struct {
+	testStructEmbedded
+	Name    string            `json:"name"`
+	Address testStructAddress `json:"address"`
+	Updated time.Time
+}
+
 func Test_readStructSlice(t *testing.T) {
 	type args struct {
 		slice  interface{}
@@ -5014,6 +5869,47 @@ func Test_readStructSlice(t *testing.T) {
 				},
 			},
 			nil, true},
+		{"pass - pointers and sql.Null types",
+			args{
+				[]testStructNullable{
+					{Name: &[]string{"foo"}[0], Age: sql.NullInt64{Int64: 1, Valid: true}},
+					{Score: sql.NullFloat64{Float64: 2.5, Valid: true}},
+				},
+				nil,
+			},
+			[]*valueContainer{
+				{slice: []string{"foo", ""}, isNull: []bool{false, true}, id: mockID, name: "name"},
+				{slice: []int64{1, 0}, isNull: []bool{false, true}, id: mockID, name: "age"},
+				{slice: []float64{0, 2.5}, isNull: []bool{true, false}, id: mockID, name: "score"}},
+			false},
+		{"pass - time layout",
+			args{
+				[]testStructLayout{{"2020-01-02"}, {""}},
+				nil,
+			},
+			[]*valueContainer{
+				{slice: []time.Time{time.Date(2020, 1, 2, 0, 0, 0, 0, time.UTC), {}},
+					isNull: []bool{false, true}, id: mockID, name: "date"}},
+			false},
+		{"pass - nested structs",
+			args{
+				[]testStructNested{
+					{testStructEmbedded{1}, "foo", testStructAddress{"bar", 10}, time.Date(2020, 1, 2, 0, 0, 0, 0, time.UTC)},
+					{Name: "baz"},
+				},
+				nil,
+			},
+			[]*valueContainer{
+				{slice: []int{1, 0}, isNull: []bool{false, false}, id: mockID, name: "id"},
+				{slice: []string{"foo", "baz"}, isNull: []bool{false, false}, id: mockID, name: "name"},
+				{slice: []string{"bar", ""}, isNull: []bool{false, false}, id: mockID, name: "address|city"},
+				{slice: []int{10, 0}, isNull: []bool{false, false}, id: mockID, name: "address|Zip"},
+				{slice: []time.Time{time.Date(2020, 1, 2, 0, 0, 0, 0, time.UTC), {}}, isNull: []bool{false, true}, id: mockID, name: "Updated"}},
+			false},
+		{"fail - cannot parse time layout", args{[]testStructLayout{{"01/02/2020"}}, nil},
+			nil, true},
+		{"fail - unsupported tag option", args{[]testStructBadTag{{"foo"}}, nil},
+			nil, true},
 		{"fail - not slice", args{testStruct{"foo", 1}, nil},
 			nil, true},
 		{"fail - not struct", args{[]string{"foo"}, nil},
@@ -5025,7 +5921,7 @@ func Test_readStructSlice(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got, err := readStructSlice(tt.args.slice, tt.args.isNull)
+			got, _, err := readStructSlice(tt.args.slice, tt.args.isNull)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("readStructSlice() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -5160,11 +6056,81 @@ func Test_writeStructSlice(t *testing.T) {
 			},
 			false,
 		},
+		{"pass - convert types",
+			args{
+				[]*valueContainer{
+					{slice: []float64{1, 2}, isNull: []bool{false, false}, name: "name", id: mockID},
+					{slice: []string{"3", "4"}, isNull: []bool{false, false}, name: "age", id: mockID},
+				}, &[]testStruct{},
+				true,
+			},
+			&[]testStruct{
+				{"1", 3},
+				{"2", 4},
+			},
+			[][]bool{
+				{false, false},
+				{false, false},
+			},
+			false,
+		},
+		{"pass - pointers and sql.Null types",
+			args{
+				[]*valueContainer{
+					{slice: []string{"foo", ""}, isNull: []bool{false, true}, name: "name", id: mockID},
+					{slice: []float64{1, 0}, isNull: []bool{false, true}, name: "age", id: mockID},
+					{slice: []float64{0, 2.5}, isNull: []bool{true, false}, name: "score", id: mockID},
+				}, &[]testStructNullable{},
+				true,
+			},
+			&[]testStructNullable{
+				{Name: &[]string{"foo"}[0], Age: sql.NullInt64{Int64: 1, Valid: true}},
+				{Score: sql.NullFloat64{Float64: 2.5, Valid: true}},
+			},
+			[][]bool{
+				{false, false, true},
+				{true, true, false},
+			},
+			false,
+		},
+		{"pass - time layout",
+			args{
+				[]*valueContainer{
+					{slice: []time.Time{time.Date(2020, 1, 2, 0, 0, 0, 0, time.UTC)}, isNull: []bool{false}, name: "date", id: mockID},
+				}, &[]testStructLayout{},
+				true,
+			},
+			&[]testStructLayout{
+				{"2020-01-02"},
+			},
+			[][]bool{
+				{false},
+			},
+			false,
+		},
+		{"pass - nested structs",
+			args{
+				[]*valueContainer{
+					{slice: []int{1}, isNull: []bool{false}, name: "id|", id: mockID},
+					{slice: []string{"foo"}, isNull: []bool{false}, name: "name|", id: mockID},
+					{slice: []string{"bar"}, isNull: []bool{false}, name: "address|city", id: mockID},
+					{slice: []int{10}, isNull: []bool{false}, name: "address|Zip", id: mockID},
+				}, &[]testStructNested{},
+				true,
+			},
+			&[]testStructNested{
+				{testStructEmbedded{1}, "foo", testStructAddress{"bar", 10}, time.Time{}},
+			},
+			[][]bool{
+				{false, false, false, false, false},
+			},
+			false,
+		},
 		{"fail - wrong type",
 			args{
 				[]*valueContainer{
 					{slice: []string{"foo", "bar"}, isNull: []bool{false, false}, name: "name", id: mockID},
-					{slice: []float64{1, 2}, isNull: []bool{false, false}, name: "age", id: mockID},
+					{slice: []float64{1.5, 2}, isNull: []bool{false, false}, name: "age", id: mockID},
 				}, &[]testStruct{},
 				true,
 			},
@@ -5209,7 +6175,7 @@ func Test_writeStructSlice(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got, err := writeStructSlice(tt.args.containers, tt.args.slice, tt.args.noUnmatchedCols)
+			got, err := writeStructSlice(nil, tt.args.containers, tt.args.slice, tt.args.noUnmatchedCols)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("writeStructSlice() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -5224,6 +6190,46 @@ func Test_writeStructSlice(t *testing.T) {
 	}
 }
 
+func Test_readMapSlice(t *testing.T) {
+	type args struct {
+		maps []map[string]interface{}
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    []*valueContainer
+		wantErr bool
+	}{
+		{"pass",
+			args{[]map[string]interface{}{
+				{"foo": 1.5, "bar": "a", "baz": map[string]interface{}{"qux": true}},
+				{"foo": nil, "bar": 2},
+			}},
+			[]*valueContainer{
+				{slice: []interface{}{"a", 2}, isNull: []bool{false, false}, id: mockID, name: "bar"},
+				{slice: []bool{true, false}, isNull: []bool{false, true}, id: mockID, name: "baz|qux"},
+				{slice: []float64{1.5, 0}, isNull: []bool{false, true}, id: mockID, name: "foo"},
+			},
+			false},
+		{"fail - empty", args{[]map[string]interface{}{}},
+			nil, true},
+		{"fail - no keys", args{[]map[string]interface{}{{}}},
+			nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := readMapSlice(tt.args.maps)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("readMapSlice() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("readMapSlice() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
 func Test_mockContainersFromDTypes(t *testing.T) {
 	type args struct {
 		names       []string
@@ -5304,7 +6310,7 @@ func Test_castToInferredTypes(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			castToInferredTypes(tt.args.containers)
+			castToInferredTypes(tt.args.containers, nil)
 			if !reflect.DeepEqual(tt.args.containers, tt.want) {
 				t.Errorf("castToInferredTypes() -> %v, want %v", tt.args.containers, tt.want)
 			}
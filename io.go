@@ -1,13 +1,28 @@
 package tada
 
 import (
+	"archive/zip"
+	"bufio"
+	"bytes"
+	"database/sql"
 	"encoding/csv"
 	"encoding/json"
 	"fmt"
 	"io"
+	"io/ioutil"
+	"math/big"
+	"os"
 	"reflect"
-
+	"runtime"
+	"sync"
+	"time"
+
+	"cloud.google.com/go/civil"
+	"github.com/apache/arrow/go/arrow"
+	"github.com/apache/arrow/go/arrow/array"
+	"github.com/apache/arrow/go/arrow/ipc"
 	"github.com/d4l3k/messagediff"
+	goparquet "github.com/fraugster/parquet-go"
 	"github.com/ptiger10/tablediff"
 )
 
@@ -24,38 +39,141 @@ import (
 
 // -- custom Encoder/Decoder
 
-func (vc valueContainerAlias) vc() valueContainer {
-	return valueContainer{
-		slice:  vc.Slice,
+// jsonVersion is the current version of the DataFrame and Series JSON encoding.
+// Version 0 (no version field) does not record dtypes, so every container decodes to []interface{}.
+const jsonVersion = 1
+
+// jsonDTypes maps the dtype recorded in JSON to the slice type to decode into.
+var jsonDTypes = map[string]reflect.Type{}
+
+func init() {
+	for _, slice := range []interface{}{
+		[]float64{}, []float32{}, []string{}, []bool{}, []interface{}{},
+		[]int{}, []int8{}, []int16{}, []int32{}, []int64{},
+		[]uint{}, []uint8{}, []uint16{}, []uint32{}, []uint64{},
+		[]time.Time{}, []civil.Date{}, []civil.Time{}, []*big.Rat{}, []time.Duration{},
+	} {
+		jsonDTypes[reflect.TypeOf(slice).String()] = reflect.TypeOf(slice)
+	}
+	// categories are encoded as one dictionary and a code for every row
+	jsonDTypes[reflect.TypeOf([]Category{}).String()] = reflect.TypeOf(categorySlice{})
+}
+
+func (vc valueContainerAlias) vc() (valueContainer, error) {
+	ret := valueContainer{
 		isNull: vc.IsNull,
 		name:   vc.Name,
 		id:     vc.ID,
 	}
+	raw, ok := vc.Slice.(json.RawMessage)
+	if !ok {
+		ret.slice = vc.Slice
+		return ret, nil
+	}
+	if vc.DType == "" {
+		var slice []interface{}
+		err := json.Unmarshal(raw, &slice)
+		if err != nil {
+			return valueContainer{}, err
+		}
+		ret.slice = slice
+		return ret, nil
+	}
+	sliceType, ok := jsonDTypes[vc.DType]
+	if !ok {
+		return valueContainer{}, fmt.Errorf("unsupported dtype (%v)", vc.DType)
+	}
+	ptr := reflect.New(sliceType)
+	err := json.Unmarshal(raw, ptr.Interface())
+	if err != nil {
+		return valueContainer{}, fmt.Errorf("dtype %v: %v", vc.DType, err)
+	}
+	ret.slice = ptr.Elem().Interface()
+	if arr, ok := ret.slice.(categorySlice); ok {
+		ret.slice = []Category(arr)
+	}
+	if reflect.ValueOf(ret.slice).Len() != len(ret.isNull) {
+		return valueContainer{}, fmt.Errorf("slice length (%d) does not match isNull length (%d)",
+			reflect.ValueOf(ret.slice).Len(), len(ret.isNull))
+	}
+	if times, ok := ret.slice.([]time.Time); ok && vc.Location != "" {
+		err := restoreLocation(times, ret.isNull, vc.Location)
+		if err != nil {
+			return valueContainer{}, err
+		}
+	}
+	return ret, nil
 }
 
+// alias records the dtype of vc if it is supported by jsonDTypes,
+// and the location name of []time.Time values if they all share one.
+// Null values are encoded as JSON null (e.g., because JSON cannot represent NaN) and decode to the zero value of the dtype.
 func (vc valueContainer) alias() valueContainerAlias {
-	return valueContainerAlias{
+	ret := valueContainerAlias{
 		Slice:  vc.slice,
 		IsNull: vc.isNull,
 		Name:   vc.name,
 		ID:     vc.id,
 	}
+	if vc.slice == nil {
+		return ret
+	}
+	if _, ok := jsonDTypes[reflect.TypeOf(vc.slice).String()]; ok {
+		ret.DType = reflect.TypeOf(vc.slice).String()
+	}
+	if arr, ok := vc.slice.([]Category); ok {
+		// null values are recorded in the category codes
+		ret.Slice = categorySlice(arr)
+		return ret
+	}
+	if times, ok := vc.slice.([]time.Time); ok {
+		// JSON records only the offset of each time, so the location name is recorded separately
+		if loc := sharedLocation(times, vc.isNull); loc != nil {
+			ret.Location = loc.String()
+		}
+	}
+	var hasNull bool
+	for i := range vc.isNull {
+		if vc.isNull[i] {
+			hasNull = true
+			break
+		}
+	}
+	if hasNull {
+		v := reflect.ValueOf(vc.slice)
+		vals := make([]interface{}, v.Len())
+		for i := range vals {
+			if !vc.isNull[i] {
+				vals[i] = v.Index(i).Interface()
+			}
+		}
+		ret.Slice = vals
+	}
+	return ret
 }
 
+// MarshalJSON satisifies the json.Marshaler interface for writing a valueContainer to JSON.
 func (vc valueContainer) MarshalJSON() ([]byte, error) {
 	return json.Marshal(vc.alias())
 }
 
+// UnmarshalJSON satisifies the json.Unmarshaler interface for reading a valueContainer from JSON.
+// If the dtype is not recorded, the values are read as []interface{}.
 func (vc *valueContainer) UnmarshalJSON(b []byte) error {
 	if string(b) == "null" {
 		return nil
 	}
-	var alias valueContainerAlias
+	alias := valueContainerAlias{Slice: new(json.RawMessage)}
 	err := json.Unmarshal(b, &alias)
 	if err != nil {
 		return fmt.Errorf("unmarshaling value container alias: %v", err)
 	}
-	*vc = alias.vc()
+	alias.Slice = *alias.Slice.(*json.RawMessage)
+	ret, err := alias.vc()
+	if err != nil {
+		return fmt.Errorf("unmarshaling value container alias: %v", err)
+	}
+	*vc = ret
 	return nil
 }
 
@@ -70,6 +188,7 @@ func (df dataFrameAlias) df() DataFrame {
 
 func (df *DataFrame) alias() dataFrameAlias {
 	return dataFrameAlias{
+		Version:       jsonVersion,
 		Labels:        df.labels,
 		Values:        df.values,
 		Name:          df.name,
@@ -78,11 +197,13 @@ func (df *DataFrame) alias() dataFrameAlias {
 }
 
 // MarshalJSON satisifies the json.Marshaler interface for writing a DataFrame to JSON.
+// The dtype of each label level and column is recorded so that UnmarshalJSON restores the original types.
 func (df *DataFrame) MarshalJSON() ([]byte, error) {
 	return json.Marshal(df.alias())
 }
 
 // UnmarshalJSON satisifies the json.Unmarshaler interface for reading a DataFrame from JSON.
+// JSON written before dtypes were recorded is still supported; its values are read as []interface{}.
 func (df *DataFrame) UnmarshalJSON(b []byte) error {
 	if string(b) == "null" {
 		return nil
@@ -92,10 +213,51 @@ func (df *DataFrame) UnmarshalJSON(b []byte) error {
 	if err != nil {
 		return fmt.Errorf("unmarshaling DataFrame: %v", err)
 	}
+	if alias.Version > jsonVersion {
+		return fmt.Errorf("unmarshaling DataFrame: unsupported version (%d)", alias.Version)
+	}
 	*df = alias.df()
 	return nil
 }
 
+func (s seriesAlias) series() Series {
+	return Series{
+		labels: s.Labels,
+		values: s.Values,
+	}
+}
+
+func (s *Series) alias() seriesAlias {
+	return seriesAlias{
+		Version: jsonVersion,
+		Labels:  s.labels,
+		Values:  s.values,
+	}
+}
+
+// MarshalJSON satisifies the json.Marshaler interface for writing a Series to JSON.
+// The dtype of each label level and the values is recorded so that UnmarshalJSON restores the original types.
+func (s *Series) MarshalJSON() ([]byte, error) {
+	return json.Marshal(s.alias())
+}
+
+// UnmarshalJSON satisifies the json.Unmarshaler interface for reading a Series from JSON.
+func (s *Series) UnmarshalJSON(b []byte) error {
+	if string(b) == "null" {
+		return nil
+	}
+	var alias seriesAlias
+	err := json.Unmarshal(b, &alias)
+	if err != nil {
+		return fmt.Errorf("unmarshaling Series: %v", err)
+	}
+	if alias.Version > jsonVersion {
+		return fmt.Errorf("unmarshaling Series: unsupported version (%d)", alias.Version)
+	}
+	*s = alias.series()
+	return nil
+}
+
 // A Reader can read in a DataFrame from various data sources.
 type Reader interface {
 	Read() (*DataFrame, error)
@@ -209,6 +371,15 @@ func (r SliceReader) Read() (*DataFrame, error) {
 
 // -- [][]string records
 
+// A ColumnSchema configures how one column is read from [][]string records.
+type ColumnSchema struct {
+	DType       DType
+	NullStrings []string       // read as null in this column, in addition to the default null strings
+	TimeLayout  string         // if not empty, DateTime, Date, and Time values are parsed with this layout instead of the default formats
+	Location    *time.Location // location of times parsed without a time zone (default: the reader's Location, or UTC)
+	Required    bool           // if true, reading fails if the column is missing
+}
+
 // RecordReader reads [][]string records into a DataFrame.
 type RecordReader struct {
 	HeaderRows        int
@@ -217,7 +388,10 @@ type RecordReader struct {
 	Name              string
 	InferTypes        bool
 	BlankStringAsNull bool
+	Schema            map[string]ColumnSchema // keyed by column or label level name
+	Location          *time.Location          // location of times parsed without a time zone, unless set in Schema (default: UTC)
 	records           [][]string
+	rowOffset         int // number of data rows preceding records, used in parsing errors
 }
 
 // NewRecordReader returns a default RecordReader.
@@ -233,23 +407,21 @@ func NewRecordReader(records [][]string) RecordReader {
 }
 
 // Read reads [][]string records to a DataFrame.
-// All columns will be read as []string.
+// All columns will be read as []string, unless r.InferTypes = true or the column is in r.Schema.
 // Records are read with row as the major dimension, unless r.ByColumn = true.
 //
+// Each column (or label level) in r.Schema is parsed as its DType, and any non-null value that does not parse returns an error
+// identifying the row and column. Row numbers start at 0 with the first row after the header rows.
+// Columns in r.Schema with Required = true must be present. Columns not in r.Schema are read as []string or, if r.InferTypes = true,
+// cast to their inferred types.
+// Times without a time zone are parsed in r.Location (default: UTC), unless their column's schema sets a different Location.
+// If a location is set, date times without a time zone (e.g., "2006-01-02 15:04:05") are also recognized,
+// and wall clocks skipped or repeated by daylight saving transitions are resolved as in Series.Localize.
+//
 // If no label levels are supplied, a default label level is inserted ([]int incrementing from 0).
 // If no headers are supplied, a default level of sequential column names (e.g., 0, 1, etc) is used. Default column names are displayed on printing.
 // Label levels are named *i (e.g., *0, *1, etc) by default when first created. Default label names are hidden on printing.
 func (r RecordReader) Read() (*DataFrame, error) {
-	if r.BlankStringAsNull {
-		_, ok := optionNullStrings.Read()[""]
-		if !ok {
-			defer func() {
-				SetOptionEmptyStringAsNull(false)
-			}()
-		}
-		SetOptionEmptyStringAsNull(true)
-	}
-
 	if len(r.records) == 0 {
 		return nil, fmt.Errorf("reading csv from records: must have at least one record")
 	}
@@ -260,8 +432,21 @@ func (r RecordReader) Read() (*DataFrame, error) {
 	if err != nil {
 		return nil, fmt.Errorf("reading csv from records: %v", err)
 	}
+	if r.BlankStringAsNull {
+		setBlankStringsAsNull(vc)
+	}
+	inSchema, err := applySchema(vc, r.Schema, r.rowOffset, r.Location)
+	if err != nil {
+		return nil, fmt.Errorf("reading csv from records: %v", err)
+	}
 	if r.InferTypes {
-		castToInferredTypes(vc)
+		var inferred []*valueContainer
+		for k := range vc {
+			if !inSchema[k] {
+				inferred = append(inferred, vc[k])
+			}
+		}
+		castToInferredTypes(inferred, r.Location)
 	}
 	df := containersToDF(vc, r.HeaderRows, r.LabelLevels, r.Name)
 	return df, nil
@@ -336,10 +521,138 @@ func (r *CSVReader) Read() (*DataFrame, error) {
 	return df, nil
 }
 
+// CSVChunkIterator iterates over a CSV file in DataFrames of up to a fixed number of rows,
+// without reading the whole file into memory.
+type CSVChunkIterator struct {
+	r         *CSVReader
+	chunkSize int
+	headers   [][]string
+	dtypes    map[int]DType // inferred from the first chunk, keyed by container position
+	offset    int
+	current   *DataFrame
+	err       error
+}
+
+// Chunks returns an iterator over DataFrames of up to chunkSize rows each, read from r as with Read.
+// Every chunk has the same header rows, label levels, and name.
+// If r.InferTypes is true, types are inferred from the first chunk and every later chunk is cast to the same types.
+// If a non-null, non-blank value in a later chunk cannot be cast to the type of its column, an error naming the row is returned.
+// Columns in r.Schema are parsed in every chunk, and row numbers in parsing errors count from the start of the file.
+// If r has no label levels, the default label level continues incrementing across chunks.
+// Chunks does not support r.ByColumn.
+func (r *CSVReader) Chunks(chunkSize int) *CSVChunkIterator {
+	iter := &CSVChunkIterator{
+		r:         r,
+		chunkSize: chunkSize,
+	}
+	if chunkSize < 1 {
+		iter.err = fmt.Errorf("CSVReader: chunk size (%d) must be >= 1", chunkSize)
+	}
+	if r.ByColumn {
+		iter.err = fmt.Errorf("CSVReader: chunks cannot be read by column")
+	}
+	return iter
+}
+
+// Next reads the next chunk. Returns false at the end of the file or if there is an error.
+func (iter *CSVChunkIterator) Next() bool {
+	if iter.err != nil {
+		return false
+	}
+	if iter.headers == nil {
+		iter.headers = make([][]string, 0, iter.r.HeaderRows)
+		for len(iter.headers) < iter.r.HeaderRows {
+			record, err := iter.r.Reader.Read()
+			if err != nil {
+				if err == io.EOF {
+					err = fmt.Errorf("must have at least %d header rows", iter.r.HeaderRows)
+				}
+				iter.err = fmt.Errorf("CSVReader: %v", err)
+				return false
+			}
+			iter.headers = append(iter.headers, record)
+		}
+	}
+	records := append([][]string{}, iter.headers...)
+	for len(records) < len(iter.headers)+iter.chunkSize {
+		record, err := iter.r.Reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			iter.err = fmt.Errorf("CSVReader: %v", err)
+			return false
+		}
+		records = append(records, record)
+	}
+	numRows := len(records) - len(iter.headers)
+	if numRows == 0 {
+		iter.current = nil
+		return false
+	}
+	df, err := iter.readChunk(records)
+	if err != nil {
+		iter.err = fmt.Errorf("CSVReader: chunk starting at row %d: %v", iter.offset, err)
+		return false
+	}
+	iter.current = df
+	iter.offset += numRows
+	return true
+}
+
+// readChunk converts records (including header rows) into a DataFrame with the same types as the first chunk.
+func (iter *CSVChunkIterator) readChunk(records [][]string) (*DataFrame, error) {
+	r := iter.r.RecordReader
+	r.InferTypes = false
+	r.records = records
+	r.rowOffset = iter.offset
+	df, err := r.Read()
+	if err != nil {
+		return nil, err
+	}
+	containers := df.values
+	if r.LabelLevels > 0 {
+		containers = append(df.labels, df.values...)
+	} else {
+		df.labels[0] = makeDefaultLabels(iter.offset, iter.offset+df.Len(), true)
+	}
+	if iter.r.InferTypes {
+		if iter.dtypes == nil {
+			iter.dtypes = make(map[int]DType, len(containers))
+			for k := range containers {
+				if _, ok := r.Schema[containers[k].name]; !ok {
+					iter.dtypes[k] = containers[k].inferTypeIn(r.Location)
+					containers[k].castIn(iter.dtypes[k], r.Location)
+				}
+			}
+			return df, nil
+		}
+		for k, dtype := range iter.dtypes {
+			err := containers[k].castStrings(dtype, r.Location, iter.offset)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+	return df, nil
+}
+
+// DataFrame returns the current chunk.
+func (iter *CSVChunkIterator) DataFrame() *DataFrame {
+	return iter.current
+}
+
+// Err returns the first error encountered while reading chunks, if any.
+func (iter *CSVChunkIterator) Err() error {
+	return iter.err
+}
+
 // CSVWriter writes DataFrame values into an encoding/csv.Writer.
 type CSVWriter struct {
 	*RecordWriter
 	*csv.Writer
+	Compression Compression // if not NoCompression, the output is compressed
+	w           io.Writer
 }
 
 // NewCSVWriter creates a new *CSVWriter with embedded encoding/csv.Writer and default settings.
@@ -349,13 +662,36 @@ func NewCSVWriter(w io.Writer) *CSVWriter {
 			IncludeLabels: false,
 			ByColumn:      false,
 		},
-		Writer: csv.NewWriter(w),
+		Writer:      csv.NewWriter(w),
+		Compression: NoCompression,
+		w:           w,
 	}
 }
 
+// Write writes df as csv records.
+// If w.Compression is not NoCompression, each call to Write writes a complete compressed stream,
+// using the Comma and UseCRLF settings of the embedded encoding/csv.Writer.
 func (w *CSVWriter) Write(df *DataFrame) error {
 	w.RecordWriter.Write(df)
-	return w.Writer.WriteAll(w.Records())
+	if w.Compression == NoCompression {
+		return w.Writer.WriteAll(w.Records())
+	}
+	cw, err := newCompressor(w.w, w.Compression)
+	if err != nil {
+		return fmt.Errorf("CSVWriter: %v", err)
+	}
+	csvWriter := csv.NewWriter(cw)
+	csvWriter.Comma = w.Writer.Comma
+	csvWriter.UseCRLF = w.Writer.UseCRLF
+	err = csvWriter.WriteAll(w.Records())
+	if err != nil {
+		return fmt.Errorf("CSVWriter: %v", err)
+	}
+	err = cw.Close()
+	if err != nil {
+		return fmt.Errorf("CSVWriter: %v", err)
+	}
+	return nil
 }
 
 // -- [][]interface{} records
@@ -451,14 +787,26 @@ func NewStructReader(sliceOfStructs interface{}) StructReader {
 
 // ReadStruct reads the exported fields in the StructReader into a DataFrame.
 //
-// If a "json" tag is present, the column will have the same name as the tag value.
+// If a "tada" or "json" tag is present, the column will have the same name as the tag value (the "tada" tag takes precedence).
 // Otherwise, the column will have the same name as the exported field.
+// Fields tagged "-" are skipped.
+// Fields tagged with the "label" option (e.g., `tada:"id,label"`) are read as label levels, in addition to the first LabelLevels fields.
+// String fields tagged with a "layout=" option (e.g., `tada:"date,layout=2006-01-02"`) are parsed as time.Time, and an empty string is null.
+//
+// Nested struct fields are flattened into multi-level column names joined by the level separator (e.g., Outer|Inner),
+// and the fields of untagged embedded structs are promoted without a prefix.
+// If any column is nested, shallower names are padded with empty levels, as in JSONLinesReader.
+//
+// Pointer fields are read as the value they point to, and a nil pointer is null.
+// sql.NullString, sql.NullFloat64, sql.NullInt64, sql.NullInt32, sql.NullBool, and sql.NullTime fields are read as the value they hold,
+// and a value that is not Valid is null.
 func (r StructReader) Read() (*DataFrame, error) {
-	values, err := readStructSlice(r.sliceOfStructs, r.IsNull)
+	values, numLabels, err := readStructSlice(r.sliceOfStructs, r.IsNull)
 	if err != nil {
 		return nil, fmt.Errorf("reading from StructReader: %v", err)
 	}
-	df := containersToDF(values, 1, r.LabelLevels, r.Name)
+	df := containersToDF(values, 1, numLabels+r.LabelLevels, r.Name)
+	df.padColLevels()
 	return df, nil
 }
 
@@ -486,12 +834,20 @@ func (w StructWriter) IsNull() [][]bool {
 }
 
 // Write writes the values of the df into a slice of structs.
+// Fields are matched to containers by the same tags as StructReader,
+// and fields tagged with the "label" option are matched only to label levels.
+//
+// Null values are written as nil pointers or sql.Null* values that are not Valid (or as the stored value, for other fields).
+// Values are converted to the field type if necessary:
+// numeric values are converted to any numeric type unless precision would be lost,
+// strings are parsed as numbers or times (using the "layout=" tag option, if any),
+// and any value may be written to a string field (times are formatted using the "layout=" tag option, or RFC3339 by default).
 func (w *StructWriter) Write(df *DataFrame) error {
 	containers := df.values
 	if w.IncludeLabels {
 		containers = append(df.labels, df.values...)
 	}
-	isNull, err := writeStructSlice(containers, w.sliceOfStructs, w.Strict)
+	isNull, err := writeStructSlice(df.labels, containers, w.sliceOfStructs, w.Strict)
 	if err != nil {
 		return fmt.Errorf("writing to StructWriter: %v", err)
 	}
@@ -499,6 +855,41 @@ func (w *StructWriter) Write(df *DataFrame) error {
 	return nil
 }
 
+// MapReader reads a slice of maps into a DataFrame.
+type MapReader struct {
+	sliceOfMaps []map[string]interface{}
+	LabelLevels int
+	Name        string
+}
+
+// NewMapReader returns a new reader for a slice of maps, such as a decoded JSON payload.
+func NewMapReader(sliceOfMaps []map[string]interface{}) MapReader {
+	return MapReader{
+		sliceOfMaps: sliceOfMaps,
+		LabelLevels: 0,
+	}
+}
+
+// Read reads the maps in the MapReader into a DataFrame. Each map is a row.
+// Columns are the union of keys across all maps, sorted by name. Missing keys and nil values are null.
+// Nested maps (map[string]interface{}) are flattened into multi-level column names as in JSONLinesReader.
+// Each column has the type shared by all of its non-nil values, or []interface{} if the types differ.
+//
+// The first r.LabelLevels columns are read as label levels. If no label levels are supplied, a default label level is inserted ([]int incrementing from 0).
+func (r MapReader) Read() (*DataFrame, error) {
+	containers, err := readMapSlice(r.sliceOfMaps)
+	if err != nil {
+		return nil, fmt.Errorf("reading from MapReader: %v", err)
+	}
+	if r.LabelLevels > len(containers) {
+		return nil, fmt.Errorf("reading from MapReader: label levels (%d) must be <= number of columns (%d)",
+			r.LabelLevels, len(containers))
+	}
+	df := containersToDF(containers, 1, r.LabelLevels, r.Name)
+	df.padColLevels()
+	return df, nil
+}
+
 // gonum.Matrix
 
 // MatrixReader reads from a data structure that implements the gonum.Matrix interface.
@@ -531,6 +922,1280 @@ func (r MatrixReader) Read() (*DataFrame, error) {
 	return df, nil
 }
 
+// MatrixNullPolicy determines how a MatrixWriter handles null values.
+type MatrixNullPolicy int
+
+const (
+	// MatrixNullError returns an error if any value is null.
+	MatrixNullError MatrixNullPolicy = iota
+	// MatrixNullDropRow drops every row that contains a null value.
+	MatrixNullDropRow
+	// MatrixNullFill replaces every null value with a fill value.
+	MatrixNullFill
+)
+
+// MatrixWriter writes numeric columns from a DataFrame to a data structure that implements the gonum.Matrix interface.
+type MatrixWriter struct {
+	Columns    []string         // if not empty, only these columns (or label levels) are written, in order (default: all columns)
+	NullPolicy MatrixNullPolicy // default: MatrixNullError
+	FillValue  float64          // replaces null values if NullPolicy is MatrixNullFill
+	mat        Matrix
+}
+
+// NewMatrixWriter returns a *MatrixWriter with default settings.
+func NewMatrixWriter() *MatrixWriter {
+	return &MatrixWriter{
+		NullPolicy: MatrixNullError,
+	}
+}
+
+// Matrix returns the Matrix written to w.
+func (w *MatrixWriter) Matrix() Matrix {
+	return w.mat
+}
+
+// Write writes each column in w.Columns as a column of a Matrix, with one row per DataFrame row.
+// Every column must be numeric (i.e., a slice of floats, ints, or uints). Null values are handled according to w.NullPolicy.
+// If no values are converted, filled, or dropped, the Matrix shares the underlying data of the DataFrame's []float64 columns.
+func (w *MatrixWriter) Write(df *DataFrame) error {
+	if df.err != nil {
+		return fmt.Errorf("writing matrix: %v", df.err)
+	}
+	mat, err := writeMatrix(df, w.Columns, w.NullPolicy, w.FillValue)
+	if err != nil {
+		return fmt.Errorf("writing matrix: %v", err)
+	}
+	w.mat = mat
+	return nil
+}
+
+// Matrix returns the named columns (default: all columns) as a Matrix, returning an error if any value is null.
+// To drop or fill null values instead, use a MatrixWriter.
+func (df *DataFrame) Matrix(cols ...string) (Matrix, error) {
+	w := NewMatrixWriter()
+	w.Columns = cols
+	err := w.Write(df)
+	if err != nil {
+		return nil, err
+	}
+	return w.Matrix(), nil
+}
+
+// -- snapshots
+
+// SnapshotReader reads a DataFrame from the binary snapshot format written by a SnapshotWriter.
+type SnapshotReader struct {
+	r io.Reader
+}
+
+// NewSnapshotReader returns a SnapshotReader with default settings.
+func NewSnapshotReader(r io.Reader) SnapshotReader {
+	return SnapshotReader{
+		r: r,
+	}
+}
+
+// Read reads a snapshot into a DataFrame that is identical to the one written (i.e., EqualDataFrames returns true).
+// Compressed snapshots are decompressed according to their magic bytes.
+func (r SnapshotReader) Read() (*DataFrame, error) {
+	dr, err := Decompress(r.r)
+	if err != nil {
+		return nil, fmt.Errorf("reading snapshot: %v", err)
+	}
+	defer dr.Close()
+	df, err := readSnapshot(dr)
+	if err != nil {
+		return nil, fmt.Errorf("reading snapshot: %v", err)
+	}
+	return df, nil
+}
+
+// SnapshotWriter writes a DataFrame in a binary snapshot format, which is a fast way to save and load fixtures.
+type SnapshotWriter struct {
+	Compression Compression // if not NoCompression, the output is compressed
+	w           io.Writer
+}
+
+// NewSnapshotWriter returns a *SnapshotWriter with default settings.
+func NewSnapshotWriter(w io.Writer) *SnapshotWriter {
+	return &SnapshotWriter{
+		Compression: NoCompression,
+		w:           w,
+	}
+}
+
+// Write writes every label level and column in df (with its concrete slice type, null values, name, and cached string values),
+// along with the column level names and the DataFrame name, in the encoding/gob format.
+// Slices of any numeric type, string, bool, time.Time, civil.Date, civil.Time, civil.DateTime, []byte, and interface{} are supported.
+func (w *SnapshotWriter) Write(df *DataFrame) error {
+	if df.err != nil {
+		return fmt.Errorf("writing snapshot: %v", df.err)
+	}
+	if w.Compression == NoCompression {
+		err := writeSnapshot(w.w, df)
+		if err != nil {
+			return fmt.Errorf("writing snapshot: %v", err)
+		}
+		return nil
+	}
+	cw, err := newCompressor(w.w, w.Compression)
+	if err != nil {
+		return fmt.Errorf("writing snapshot: %v", err)
+	}
+	err = writeSnapshot(cw, df)
+	if err != nil {
+		return fmt.Errorf("writing snapshot: %v", err)
+	}
+	err = cw.Close()
+	if err != nil {
+		return fmt.Errorf("writing snapshot: %v", err)
+	}
+	return nil
+}
+
+// -- Apache Arrow
+
+// ArrowReader reads an Apache Arrow IPC file or stream into a DataFrame.
+type ArrowReader struct {
+	Stream      bool // reads the Arrow IPC stream format instead of the file format
+	LabelLevels int  // used only if the schema was not written by an ArrowWriter
+	Name        string
+	r           io.Reader
+}
+
+// NewArrowReader returns an ArrowReader with default settings (Arrow IPC file format).
+func NewArrowReader(r io.Reader) ArrowReader {
+	return ArrowReader{
+		Stream:      false,
+		LabelLevels: 0,
+		r:           r,
+	}
+}
+
+// Read reads all the record batches in an Arrow IPC file or stream into a DataFrame.
+// Float columns are read as []float64, int64 columns as []int64, other integer columns as []int, strings as []string,
+// timestamps as []time.Time (in the time zone of the timestamp type, or UTC if none), dates as []civil.Date,
+// times of day as []civil.Time, durations as []time.Duration, decimals as []*big.Rat, and booleans as []bool.
+//
+// If the schema was written by an ArrowWriter, the label levels, column level names, and DataFrame name are restored from the schema metadata,
+// and every container is converted back to the slice type (and time location) with which it was written.
+// Otherwise, the first r.LabelLevels fields are read as label levels.
+func (r ArrowReader) Read() (*DataFrame, error) {
+	var schema *arrow.Schema
+	var records []array.Record
+	if r.Stream {
+		rdr, err := ipc.NewReader(r.r)
+		if err != nil {
+			return nil, fmt.Errorf("reading arrow stream: %v", err)
+		}
+		defer rdr.Release()
+		schema = rdr.Schema()
+		for rdr.Next() {
+			rec := rdr.Record()
+			rec.Retain()
+			records = append(records, rec)
+		}
+		if rdr.Err() != nil {
+			return nil, fmt.Errorf("reading arrow stream: %v", rdr.Err())
+		}
+	} else {
+		ras, ok := r.r.(ipc.ReadAtSeeker)
+		if !ok {
+			b, err := ioutil.ReadAll(r.r)
+			if err != nil {
+				return nil, fmt.Errorf("reading arrow file: %v", err)
+			}
+			ras = bytes.NewReader(b)
+		}
+		rdr, err := ipc.NewFileReader(ras)
+		if err != nil {
+			return nil, fmt.Errorf("reading arrow file: %v", err)
+		}
+		defer rdr.Close()
+		schema = rdr.Schema()
+		for i := 0; i < rdr.NumRecords(); i++ {
+			rec, err := rdr.Record(i)
+			if err != nil {
+				return nil, fmt.Errorf("reading arrow file: record batch %d: %v", i, err)
+			}
+			rec.Retain()
+			records = append(records, rec)
+		}
+	}
+	defer func() {
+		for i := range records {
+			records[i].Release()
+		}
+	}()
+	df, err := readArrowRecords(schema, records, r.LabelLevels)
+	if err != nil {
+		return nil, fmt.Errorf("reading arrow: %v", err)
+	}
+	if r.Name != "" {
+		df.name = r.Name
+	}
+	return df, nil
+}
+
+// ArrowWriter writes a DataFrame as a single record batch to an Apache Arrow IPC file or stream.
+// Categorical containers are written as string arrays, because the supported Arrow version cannot encode dictionary arrays.
+type ArrowWriter struct {
+	Stream bool // writes the Arrow IPC stream format instead of the file format
+	w      io.Writer
+}
+
+// NewArrowWriter returns an *ArrowWriter with default settings (Arrow IPC file format).
+func NewArrowWriter(w io.Writer) *ArrowWriter {
+	return &ArrowWriter{
+		Stream: false,
+		w:      w,
+	}
+}
+
+// Write writes the labels and columns of df to w.
+// []float64 columns are written as float64, []string as utf8, []time.Time as nanosecond timestamps
+// (with the time zone of the values if they share a location, or UTC otherwise), []civil.Date as date32, []civil.Time as nanosecond time64,
+// []time.Duration as nanosecond durations, integers as int64, and []bool as bool.
+// []*big.Rat (Decimal) columns are written as decimal128 with precision 38 and the scale of the most precise value
+// (values without a terminating decimal expansion are rounded to SetOptionDecimalPlaces),
+// or as utf8 if any value needs more than 38 digits.
+// All other types are written as utf8.
+// Null values are written to the validity bitmap of each array.
+// The number of label levels, the column level names, the DataFrame name, and the slice type of every container
+// (with the location of []time.Time values) are written to the schema metadata.
+func (w *ArrowWriter) Write(df *DataFrame) error {
+	if df.err != nil {
+		return fmt.Errorf("writing arrow: %v", df.err)
+	}
+	rec, err := writeArrowRecord(df)
+	if err != nil {
+		return fmt.Errorf("writing arrow: %v", err)
+	}
+	defer rec.Release()
+	if w.Stream {
+		wtr := ipc.NewWriter(w.w, ipc.WithSchema(rec.Schema()))
+		err = wtr.Write(rec)
+		if err != nil {
+			return fmt.Errorf("writing arrow stream: %v", err)
+		}
+		err = wtr.Close()
+		if err != nil {
+			return fmt.Errorf("writing arrow stream: %v", err)
+		}
+		return nil
+	}
+	ws, ok := w.w.(io.WriteSeeker)
+	if !ok {
+		ws = &positionWriter{w: w.w}
+	}
+	wtr, err := ipc.NewFileWriter(ws, ipc.WithSchema(rec.Schema()))
+	if err != nil {
+		return fmt.Errorf("writing arrow file: %v", err)
+	}
+	err = wtr.Write(rec)
+	if err != nil {
+		return fmt.Errorf("writing arrow file: %v", err)
+	}
+	err = wtr.Close()
+	if err != nil {
+		return fmt.Errorf("writing arrow file: %v", err)
+	}
+	return nil
+}
+
+// -- Apache Parquet
+
+// ParquetReader reads a Parquet file into a DataFrame.
+type ParquetReader struct {
+	Columns     []string // if not empty, only these columns are read (default: all columns)
+	LabelLevels int      // used only if the file was not written by a ParquetWriter
+	Name        string
+	r           io.ReadSeeker
+}
+
+// NewParquetReader returns a ParquetReader with default settings.
+func NewParquetReader(r io.ReadSeeker) ParquetReader {
+	return ParquetReader{
+		LabelLevels: 0,
+		r:           r,
+	}
+}
+
+// Read reads a Parquet file into a DataFrame. Only flat (non-nested, non-repeated) columns are supported.
+// Parquet types are read according to their logical type:
+// floating point columns as []float64, decimal columns as []*big.Rat, int64 columns as []int64, int32 columns as []int, strings and byte arrays as []string,
+// timestamps as []time.Time, dates as []civil.Date, times of day as []civil.Time, and booleans as []bool.
+// Values with a definition level below the maximum (i.e., missing optional values) are null.
+//
+// If the file was written by a ParquetWriter, the label levels, column level names, and DataFrame name are restored from the file metadata,
+// and every container is converted back to the slice type (and time location) with which it was written.
+// If r.Columns excludes every label level, a default label level is inserted.
+// If the file was not written by a ParquetWriter, the first r.LabelLevels columns are read as label levels.
+func (r ParquetReader) Read() (*DataFrame, error) {
+	// only the selected columns are decoded
+	fr, err := goparquet.NewFileReader(r.r, r.Columns...)
+	if err != nil {
+		return nil, fmt.Errorf("reading parquet: %v", err)
+	}
+	df, err := readParquet(fr, r.Columns, r.LabelLevels)
+	if err != nil {
+		return nil, fmt.Errorf("reading parquet: %v", err)
+	}
+	if r.Name != "" {
+		df.name = r.Name
+	}
+	return df, nil
+}
+
+// ParquetWriter writes a DataFrame to a Parquet file.
+type ParquetWriter struct {
+	w io.Writer
+}
+
+// NewParquetWriter returns a *ParquetWriter with default settings.
+func NewParquetWriter(w io.Writer) *ParquetWriter {
+	return &ParquetWriter{
+		w: w,
+	}
+}
+
+// Write writes the labels and columns of df to a Parquet file as optional columns in a single row group.
+// []float64 columns are written as double, []string as UTF8 byte arrays, []time.Time as nanosecond timestamps,
+// []civil.Date as dates, []civil.Time as nanosecond times, integers and []time.Duration (as nanoseconds) as int64, and []bool as boolean.
+// []*big.Rat (Decimal) columns are written as 16-byte decimals with precision 38 and the scale of the most precise value
+// (values without a terminating decimal expansion are rounded to SetOptionDecimalPlaces),
+// or as UTF8 byte arrays if any value needs more than 38 digits.
+// All other types are written as UTF8 byte arrays.
+// Null values are omitted (i.e., written with a definition level of 0).
+// The number of label levels, the column level names, the DataFrame name, and the slice type of every container
+// (with the location of []time.Time values) are written to the file metadata.
+func (w *ParquetWriter) Write(df *DataFrame) error {
+	if df.err != nil {
+		return fmt.Errorf("writing parquet: %v", df.err)
+	}
+	err := writeParquet(w.w, df)
+	if err != nil {
+		return fmt.Errorf("writing parquet: %v", err)
+	}
+	return nil
+}
+
+// -- JSON Lines
+
+// JSONLinesReader reads newline-delimited JSON objects into a DataFrame.
+type JSONLinesReader struct {
+	LabelLevels int
+	Name        string
+	InferTypes  bool
+	Location    *time.Location // location of inferred times without a time zone (default: UTC)
+	r           io.Reader
+}
+
+// NewJSONLinesReader returns a JSONLinesReader with default settings.
+func NewJSONLinesReader(r io.Reader) JSONLinesReader {
+	return JSONLinesReader{
+		LabelLevels: 0,
+		InferTypes:  true,
+		r:           r,
+	}
+}
+
+// Read reads newline-delimited JSON objects into a DataFrame. Each object is a row, and blank lines are skipped.
+// Columns are the union of keys across all objects, in the order in which they are first encountered.
+// Missing keys and JSON null values are null.
+// Nested objects are flattened into multi-level column names joined by the level separator (e.g., {"foo": {"bar": 1}} -> foo|bar).
+// If any column is nested, every column has as many levels as the most deeply nested column, and shallower names are padded with empty levels.
+// JSON arrays are read as their compact JSON text.
+//
+// All columns are read as []string and then, if r.InferTypes is true (default), cast to their inferred types as in RecordReader.
+// The first r.LabelLevels columns are read as label levels. If no label levels are supplied, a default label level is inserted ([]int incrementing from 0).
+func (r JSONLinesReader) Read() (*DataFrame, error) {
+	containers, err := readJSONLines(r.r)
+	if err != nil {
+		return nil, fmt.Errorf("reading json lines: %v", err)
+	}
+	if r.LabelLevels > len(containers) {
+		return nil, fmt.Errorf("reading json lines: label levels (%d) must be <= number of columns (%d)",
+			r.LabelLevels, len(containers))
+	}
+	if r.InferTypes {
+		castToInferredTypes(containers, r.Location)
+	}
+	df := containersToDF(containers, 1, r.LabelLevels, r.Name)
+	df.padColLevels()
+	return df, nil
+}
+
+// JSONLinesWriter writes a DataFrame as newline-delimited JSON objects.
+type JSONLinesWriter struct {
+	IncludeLabels bool
+	w             io.Writer
+}
+
+// NewJSONLinesWriter returns a *JSONLinesWriter with default settings.
+func NewJSONLinesWriter(w io.Writer) *JSONLinesWriter {
+	return &JSONLinesWriter{
+		IncludeLabels: false,
+		w:             w,
+	}
+}
+
+// Write writes each row of df as one JSON object per line, with keys in column order.
+// Multi-level column names are written as nested objects (e.g., foo|bar -> {"foo": {"bar": ...}}), and empty trailing levels are ignored.
+// Null values are written as JSON null. []time.Time values are written as RFC 3339 strings,
+// and []civil.Date and []civil.Time values are written as strings.
+// If w.IncludeLabels is true, label levels are written before the columns.
+func (w *JSONLinesWriter) Write(df *DataFrame) error {
+	if df.err != nil {
+		return fmt.Errorf("writing json lines: %v", df.err)
+	}
+	containers := df.values
+	if w.IncludeLabels {
+		containers = append(df.labels, df.values...)
+	}
+	err := writeJSONLines(w.w, containers)
+	if err != nil {
+		return fmt.Errorf("writing json lines: %v", err)
+	}
+	return nil
+}
+
+// -- JSON
+
+// JSONOrientation is the layout of a DataFrame in JSON.
+type JSONOrientation int
+
+const (
+	// JSONRecords -> [{column: value, ...}, ...], one object per row
+	JSONRecords JSONOrientation = iota
+	// JSONColumns -> {column: [value, ...], ...}, one array per column
+	JSONColumns
+	// JSONSplit -> {"name": name, "labelNames": [...], "columns": [...], "labels": [[...], ...], "data": [[...], ...]}
+	JSONSplit
+	// JSONIndex -> {label: {column: value, ...}, ...}, one object per row, keyed by the row's concatenated label values
+	JSONIndex
+)
+
+// JSONReader reads JSON in any JSONOrientation into a DataFrame.
+type JSONReader struct {
+	Orientation JSONOrientation
+	LabelLevels int // used only by JSONRecords and JSONColumns
+	Name        string
+	InferTypes  bool
+	Location    *time.Location // location of inferred times without a time zone (default: UTC)
+	r           io.Reader
+}
+
+// NewJSONReader returns a JSONReader with default settings.
+func NewJSONReader(r io.Reader) JSONReader {
+	return JSONReader{
+		Orientation: JSONRecords,
+		LabelLevels: 0,
+		InferTypes:  true,
+		r:           r,
+	}
+}
+
+// Read reads JSON in r.Orientation into a DataFrame.
+// Nested objects are flattened into multi-level column names joined by the level separator,
+// and column names in JSONSplit may be either strings or arrays of levels.
+// JSON null values and missing keys are null.
+//
+// In JSONRecords and JSONColumns, the first r.LabelLevels columns are read as label levels.
+// In JSONIndex, each key is split by the level separator into label levels.
+// In JSONSplit, the label levels and DataFrame name are read from the JSON.
+// If no label levels are supplied, a default label level is inserted ([]int incrementing from 0).
+//
+// All labels and columns are read as []string and then, if r.InferTypes is true (default), cast to their inferred types as in RecordReader.
+func (r JSONReader) Read() (*DataFrame, error) {
+	b, err := ioutil.ReadAll(r.r)
+	if err != nil {
+		return nil, fmt.Errorf("reading json: %v", err)
+	}
+	var labels, values []*valueContainer
+	var name string
+	switch r.Orientation {
+	case JSONRecords, JSONColumns:
+		var containers []*valueContainer
+		if r.Orientation == JSONRecords {
+			containers, err = readJSONRecords(b)
+		} else {
+			containers, err = readJSONColumns(b)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("reading json: %v", err)
+		}
+		if r.LabelLevels > len(containers) {
+			return nil, fmt.Errorf("reading json: label levels (%d) must be <= number of columns (%d)",
+				r.LabelLevels, len(containers))
+		}
+		labels, values = containers[:r.LabelLevels], containers[r.LabelLevels:]
+	case JSONSplit:
+		labels, values, name, err = readJSONSplit(b)
+	case JSONIndex:
+		labels, values, err = readJSONIndex(b)
+	default:
+		return nil, fmt.Errorf("reading json: unsupported orientation (%v)", r.Orientation)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading json: %v", err)
+	}
+	if r.InferTypes {
+		castToInferredTypes(labels, r.Location)
+		castToInferredTypes(values, r.Location)
+	}
+	if len(labels) == 0 {
+		labels = []*valueContainer{makeDefaultLabels(0, values[0].len(), true)}
+	}
+	df := &DataFrame{
+		labels:        labels,
+		values:        values,
+		colLevelNames: []string{"*0"},
+		name:          name,
+	}
+	df.padColLevels()
+	if r.Name != "" {
+		df.name = r.Name
+	}
+	return df, nil
+}
+
+// JSONWriter writes a DataFrame as JSON in any JSONOrientation.
+type JSONWriter struct {
+	Orientation   JSONOrientation
+	IncludeLabels bool   // used only by JSONRecords and JSONColumns
+	TimeLayout    string // layout used to format time.Time values
+	NestLevels    bool   // if true, multi-level column names are written as nested objects (or arrays of levels in JSONSplit)
+	w             io.Writer
+}
+
+// NewJSONWriter returns a *JSONWriter with default settings.
+func NewJSONWriter(w io.Writer) *JSONWriter {
+	return &JSONWriter{
+		Orientation:   JSONRecords,
+		IncludeLabels: false,
+		TimeLayout:    time.RFC3339,
+		NestLevels:    false,
+		w:             w,
+	}
+}
+
+// Write writes df as JSON in w.Orientation.
+// Null values are written as JSON null, []time.Time values are formatted with w.TimeLayout,
+// and []civil.Date and []civil.Time values are written as strings.
+// If w.NestLevels is false (default), multi-level column names are written joined by the level separator (e.g., foo|bar).
+// Otherwise, they are written as nested objects (e.g., {"foo": {"bar": ...}}), ignoring empty trailing levels.
+//
+// In JSONRecords and JSONColumns, label levels are written before the columns if w.IncludeLabels is true.
+// In JSONIndex, rows are keyed by their label values joined by the level separator, which must be unique.
+// In JSONSplit, label levels and the DataFrame name are always written.
+func (w *JSONWriter) Write(df *DataFrame) error {
+	if df.err != nil {
+		return fmt.Errorf("writing json: %v", df.err)
+	}
+	bw := bufio.NewWriter(w.w)
+	var err error
+	switch w.Orientation {
+	case JSONRecords, JSONColumns:
+		containers := df.values
+		if w.IncludeLabels {
+			containers = append(df.labels, df.values...)
+		}
+		if w.Orientation == JSONRecords {
+			err = writeJSONRecords(bw, containers, w.NestLevels, w.TimeLayout)
+		} else {
+			err = writeJSONColumns(bw, containers, w.NestLevels, w.TimeLayout)
+		}
+	case JSONSplit:
+		err = writeJSONSplit(bw, df, w.NestLevels, w.TimeLayout)
+	case JSONIndex:
+		err = writeJSONIndex(bw, df, w.NestLevels, w.TimeLayout)
+	default:
+		err = fmt.Errorf("unsupported orientation (%v)", w.Orientation)
+	}
+	if err != nil {
+		return fmt.Errorf("writing json: %v", err)
+	}
+	err = bw.Flush()
+	if err != nil {
+		return fmt.Errorf("writing json: %v", err)
+	}
+	return nil
+}
+
+// -- database/sql
+
+// SQLRowsReader reads *sql.Rows into a DataFrame.
+type SQLRowsReader struct {
+	LabelNames []string // if not empty, these columns are read as label levels, in this order
+	Name       string
+	rows       *sql.Rows
+}
+
+// NewSQLRowsReader returns an SQLRowsReader with default settings.
+func NewSQLRowsReader(rows *sql.Rows) SQLRowsReader {
+	return SQLRowsReader{
+		rows: rows,
+	}
+}
+
+// Read reads all remaining rows from r.rows into a DataFrame and closes r.rows.
+// The type of each column is determined by its driver-reported scan type or, if unavailable, its database type name:
+// floating point and decimal columns are read as []float64, integers as []int, booleans as []bool,
+// timestamps and dates as []time.Time, and all other columns as []string.
+// NULL values are null.
+//
+// Columns named in r.LabelNames are read as label levels.
+// If no label levels are supplied, a default label level is inserted ([]int incrementing from 0).
+func (r SQLRowsReader) Read() (*DataFrame, error) {
+	if r.rows == nil {
+		return nil, fmt.Errorf("reading sql rows: rows cannot be nil")
+	}
+	defer r.rows.Close()
+	containers, err := readSQLRows(r.rows)
+	if err != nil {
+		return nil, fmt.Errorf("reading sql rows: %v", err)
+	}
+	labels := make([]*valueContainer, len(r.LabelNames))
+	isLabel := make(map[int]bool, len(r.LabelNames))
+	for j, name := range r.LabelNames {
+		k, err := indexOfContainer(name, containers)
+		if err != nil {
+			return nil, fmt.Errorf("reading sql rows: label names: %v", err)
+		}
+		labels[j] = containers[k]
+		isLabel[k] = true
+	}
+	var values []*valueContainer
+	for k := range containers {
+		if !isLabel[k] {
+			values = append(values, containers[k])
+		}
+	}
+	if len(values) == 0 {
+		return nil, fmt.Errorf("reading sql rows: must have at least one column that is not a label level")
+	}
+	if len(labels) == 0 {
+		labels = []*valueContainer{makeDefaultLabels(0, values[0].len(), true)}
+	}
+	return &DataFrame{
+		labels:        labels,
+		values:        values,
+		colLevelNames: []string{"*0"},
+		name:          r.Name,
+	}, nil
+}
+
+// SQLDialect configures identifier quoting, placeholders, and column types for an SQLWriter.
+type SQLDialect int
+
+const (
+	// SQLPostgres -> "quoted" identifiers, $1 placeholders
+	SQLPostgres SQLDialect = iota
+	// SQLMySQL -> `quoted` identifiers, ? placeholders
+	SQLMySQL
+	// SQLSQLite -> "quoted" identifiers, ? placeholders
+	SQLSQLite
+)
+
+// An SQLExecer executes SQL statements. It is satisfied by *sql.DB and *sql.Tx.
+type SQLExecer interface {
+	Exec(query string, args ...interface{}) (sql.Result, error)
+}
+
+// SQLWriter writes a DataFrame to an SQL table, either by executing statements or by rendering them as a script.
+type SQLWriter struct {
+	Table         string
+	Dialect       SQLDialect
+	CreateTable   bool // if true, a CREATE TABLE statement is written before the INSERT statements
+	BatchSize     int  // maximum number of rows per INSERT statement
+	IncludeLabels bool
+	db            SQLExecer
+	w             io.Writer
+}
+
+// NewSQLWriter returns an *SQLWriter with default settings that executes statements with db (e.g., a *sql.DB or *sql.Tx).
+func NewSQLWriter(db SQLExecer, table string) *SQLWriter {
+	return &SQLWriter{
+		Table:         table,
+		Dialect:       SQLPostgres,
+		CreateTable:   true,
+		BatchSize:     1000,
+		IncludeLabels: false,
+		db:            db,
+	}
+}
+
+// NewSQLScriptWriter returns an *SQLWriter with default settings that renders statements to w as an SQL script.
+func NewSQLScriptWriter(w io.Writer, table string) *SQLWriter {
+	return &SQLWriter{
+		Table:         table,
+		Dialect:       SQLPostgres,
+		CreateTable:   true,
+		BatchSize:     1000,
+		IncludeLabels: false,
+		w:             w,
+	}
+}
+
+// Write writes df to w.Table, with one INSERT statement per batch of up to w.BatchSize rows.
+// If w.CreateTable is true, the table is first created with one column per DataFrame column,
+// typed according to w.Dialect (e.g., []float64 as DOUBLE PRECISION in Postgres). Unsupported types are written as TEXT.
+// Null values, NaN, and infinite floats are written as NULL.
+// If w.IncludeLabels is true, label levels are written as columns before the DataFrame columns.
+//
+// When executing statements, values are passed as arguments using the placeholders of w.Dialect.
+// Batches are made smaller if necessary to stay within the dialect's limit on placeholders per statement
+// (65535 for Postgres and MySQL, and 999 for SQLite).
+// When rendering a script, values are written as literals, and each statement ends with a semicolon and a newline.
+// Statements are not wrapped in a transaction; to write atomically, construct the SQLWriter with a *sql.Tx.
+func (w *SQLWriter) Write(df *DataFrame) error {
+	if df.err != nil {
+		return fmt.Errorf("writing sql: %v", df.err)
+	}
+	if (w.db == nil) == (w.w == nil) {
+		return fmt.Errorf("writing sql: must have either a database or an io.Writer")
+	}
+	if w.Table == "" {
+		return fmt.Errorf("writing sql: table name cannot be empty")
+	}
+	if w.BatchSize < 1 {
+		return fmt.Errorf("writing sql: batch size (%d) must be >= 1", w.BatchSize)
+	}
+	if w.Dialect < SQLPostgres || w.Dialect > SQLSQLite {
+		return fmt.Errorf("writing sql: unsupported dialect (%v)", w.Dialect)
+	}
+	containers := df.values
+	if w.IncludeLabels {
+		containers = append(df.labels, df.values...)
+	}
+	err := writeSQL(w, containers)
+	if err != nil {
+		return fmt.Errorf("writing sql: %v", err)
+	}
+	return nil
+}
+
+// -- compression
+
+// Compression is a compression format for reading and writing files.
+type Compression int
+
+const (
+	// NoCompression -> uncompressed
+	NoCompression Compression = iota
+	// Gzip -> gzip (.gz)
+	Gzip
+	// Zstd -> Zstandard (.zst)
+	Zstd
+	// Bzip2 -> bzip2 (.bz2)
+	Bzip2
+)
+
+// Decompress returns a reader that decompresses r according to its magic bytes.
+// If the magic bytes do not match a supported Compression, r is read as is.
+// The returned reader must be closed to release its resources. Closing it does not close r.
+func Decompress(r io.Reader) (io.ReadCloser, error) {
+	br := bufio.NewReader(r)
+	return newDecompressor(br, detectCompression(br))
+}
+
+// OpenFile opens the file at path and returns a reader that decompresses it according to its magic bytes or,
+// if they do not match a supported Compression, its extension (.gz, .zst, or .bz2).
+// The returned reader can be used by any Reader that reads from an io.Reader (e.g., NewCSVReader),
+// and must be closed to close the file.
+func OpenFile(path string) (io.ReadCloser, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("opening file: %v", err)
+	}
+	br := bufio.NewReader(f)
+	compression := detectCompression(br)
+	if compression == NoCompression {
+		compression = compressionFromExtension(path)
+	}
+	dr, err := newDecompressor(br, compression)
+	if err != nil {
+		f.Close()
+		return nil, fmt.Errorf("opening file: %s: %v", path, err)
+	}
+	return &multiCloser{Reader: dr, closers: []io.Closer{dr, f}}, nil
+}
+
+// ReadArchive reads every regular file in the tar or zip archive at path into a DataFrame, keyed by its name within the archive.
+// Tar archives may be compressed in any supported Compression, and so may each file within a tar or zip archive.
+// newReader returns the Reader used to read each file (e.g., func(r io.Reader) Reader { return NewCSVReader(r) }).
+func ReadArchive(path string, newReader func(io.Reader) Reader) (map[string]*DataFrame, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading archive: %v", err)
+	}
+	defer f.Close()
+	magic := make([]byte, 4)
+	n, _ := io.ReadFull(f, magic)
+	if bytes.Equal(magic[:n], zipMagic) {
+		info, err := f.Stat()
+		if err != nil {
+			return nil, fmt.Errorf("reading archive: %v", err)
+		}
+		return ReadZip(f, info.Size(), newReader)
+	}
+	_, err = f.Seek(0, io.SeekStart)
+	if err != nil {
+		return nil, fmt.Errorf("reading archive: %v", err)
+	}
+	return ReadTar(f, newReader)
+}
+
+// ReadTar reads every regular file in the (optionally compressed) tar archive in r into a DataFrame,
+// keyed by its name within the archive. Each file is decompressed according to its magic bytes.
+// newReader returns the Reader used to read each file (e.g., func(r io.Reader) Reader { return NewCSVReader(r) }).
+func ReadTar(r io.Reader, newReader func(io.Reader) Reader) (map[string]*DataFrame, error) {
+	dr, err := Decompress(r)
+	if err != nil {
+		return nil, fmt.Errorf("reading tar: %v", err)
+	}
+	defer dr.Close()
+	ret, err := readTar(dr, newReader)
+	if err != nil {
+		return nil, fmt.Errorf("reading tar: %v", err)
+	}
+	return ret, nil
+}
+
+// ReadZip reads every regular file in the zip archive in r (with size bytes) into a DataFrame,
+// keyed by its name within the archive. Each file is decompressed according to its magic bytes.
+// newReader returns the Reader used to read each file (e.g., func(r io.Reader) Reader { return NewCSVReader(r) }).
+func ReadZip(r io.ReaderAt, size int64, newReader func(io.Reader) Reader) (map[string]*DataFrame, error) {
+	zr, err := zip.NewReader(r, size)
+	if err != nil {
+		return nil, fmt.Errorf("reading zip: %v", err)
+	}
+	ret, err := readZip(zr, newReader)
+	if err != nil {
+		return nil, fmt.Errorf("reading zip: %v", err)
+	}
+	return ret, nil
+}
+
+// -- multiple files
+
+// MultiCSVReader reads every file matching a glob pattern, or every file within a directory, into a single DataFrame.
+type MultiCSVReader struct {
+	Configure          func(*CSVReader) // if not nil, configures the CSVReader for each file (e.g., LabelLevels or Schema)
+	PartitionKeys      bool             // if true, Hive-style partition keys (e.g., date=2020-03-01) in directory names are added to each row
+	PartitionsAsLabels bool             // if true, partition keys are added as label levels (default: columns)
+	Concurrency        int              // maximum number of files read at once
+	Name               string
+	pattern            string
+}
+
+// NewMultiCSVReader returns a MultiCSVReader with default settings.
+// pattern may be either a glob pattern (as in filepath.Glob) or a directory, which is searched recursively.
+func NewMultiCSVReader(pattern string) MultiCSVReader {
+	return MultiCSVReader{
+		PartitionKeys:      false,
+		PartitionsAsLabels: false,
+		Concurrency:        runtime.NumCPU(),
+		pattern:            pattern,
+	}
+}
+
+// Read reads each file concurrently with a CSVReader configured by r.Configure, and appends the results in lexical order of file path.
+// Files are decompressed as in OpenFile. Files and directories whose names begin with . or _ are skipped when searching a directory.
+// Every file must have the same label level names, column names, and types; otherwise, an error naming the mismatched file is returned.
+// If the CSVReader infers types, types are inferred from the first file, and every other file is cast to the same types.
+// If a non-null, non-blank value in another file cannot be cast to the type of its column, an error naming the file and row is returned.
+// If the CSVReader has no label levels, the default label level increments across all files.
+//
+// If r.PartitionKeys is true, every directory name of the form key=value in a file's path is added as a []string column
+// (or label level, if r.PartitionsAsLabels is true) named key, with value in every row from that file.
+// Every file must have the same partition keys.
+func (r MultiCSVReader) Read() (*DataFrame, error) {
+	paths, err := listFiles(r.pattern)
+	if err != nil {
+		return nil, fmt.Errorf("reading multiple files: %v", err)
+	}
+	concurrency := r.Concurrency
+	if concurrency < 1 {
+		concurrency = 1
+	}
+	defaultLabels := r.csvReader(nil).LabelLevels == 0
+	dfs := make([]*DataFrame, len(paths))
+	errs := make([]error, len(paths))
+	// the first file is read before the others, which are cast to the types inferred from it
+	var dtypes map[int]DType
+	dfs[0], dtypes, errs[0] = r.readFile(paths[0], nil)
+	if errs[0] == nil {
+		sem := make(chan struct{}, concurrency)
+		var wg sync.WaitGroup
+		for i := 1; i < len(paths); i++ {
+			wg.Add(1)
+			sem <- struct{}{}
+			go func(i int) {
+				defer func() {
+					<-sem
+					wg.Done()
+				}()
+				dfs[i], _, errs[i] = r.readFile(paths[i], dtypes)
+			}(i)
+		}
+		wg.Wait()
+	}
+	for i := range errs {
+		if errs[i] != nil {
+			return nil, fmt.Errorf("reading multiple files: %s: %v", paths[i], errs[i])
+		}
+	}
+	ret, err := appendFiles(paths, dfs, defaultLabels)
+	if err != nil {
+		return nil, fmt.Errorf("reading multiple files: %v", err)
+	}
+	if r.Name != "" {
+		ret.name = r.Name
+	}
+	return ret, nil
+}
+
+// csvReader returns a CSVReader for src, configured by r.Configure.
+func (r MultiCSVReader) csvReader(src io.Reader) *CSVReader {
+	ret := NewCSVReader(src)
+	if r.Configure != nil {
+		r.Configure(ret)
+	}
+	return ret
+}
+
+// readFile reads the file at path and adds its partition keys.
+// If the CSVReader infers types and dtypes is nil, types are inferred and returned, keyed by container position.
+// Otherwise, the containers are cast to dtypes.
+func (r MultiCSVReader) readFile(path string, dtypes map[int]DType) (*DataFrame, map[int]DType, error) {
+	f, err := OpenFile(path)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer f.Close()
+	csvReader := r.csvReader(f)
+	inferTypes := csvReader.InferTypes
+	csvReader.InferTypes = false
+	df, err := csvReader.Read()
+	if err != nil {
+		return nil, nil, err
+	}
+	if inferTypes {
+		containers := df.values
+		if csvReader.LabelLevels > 0 {
+			containers = append(df.labels, df.values...)
+		}
+		if dtypes == nil {
+			dtypes = make(map[int]DType, len(containers))
+			for k := range containers {
+				if _, ok := csvReader.Schema[containers[k].name]; !ok {
+					dtypes[k] = containers[k].inferTypeIn(csvReader.Location)
+					containers[k].castIn(dtypes[k], csvReader.Location)
+				}
+			}
+		} else {
+			for k, dtype := range dtypes {
+				// a file with a different number of containers is reported when the files are appended
+				if k >= len(containers) {
+					continue
+				}
+				err := containers[k].castStrings(dtype, csvReader.Location, 0)
+				if err != nil {
+					return nil, nil, err
+				}
+			}
+		}
+	}
+	if r.PartitionKeys {
+		err = df.addPartitionKeys(partitionKeys(path), r.PartitionsAsLabels)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+	return df, dtypes, nil
+}
+
+// -- Excel
+
+// XLSXReader reads one sheet of an Excel .xlsx workbook into a DataFrame.
+type XLSXReader struct {
+	Sheet       string // name of the sheet to read; if empty, the sheet at SheetIndex is read
+	SheetIndex  int    // position of the sheet to read, starting at 0 (default: first sheet)
+	HeaderRows  int
+	LabelLevels int
+	Range       string // if not empty, only cells within this range (e.g., B2:D10) are read (default: all cells in use)
+	Name        string
+	r           io.ReaderAt
+	size        int64
+}
+
+// NewXLSXReader returns an XLSXReader with default settings for the workbook in r, which is size bytes long.
+func NewXLSXReader(r io.ReaderAt, size int64) XLSXReader {
+	return XLSXReader{
+		HeaderRows:  1,
+		LabelLevels: 0,
+		r:           r,
+		size:        size,
+	}
+}
+
+// Read reads a sheet into a DataFrame, with the same r.HeaderRows and r.LabelLevels semantics as RecordReader.
+// If r.Range is empty, every cell between the first and last cell in use is read.
+// Every cell in a merged range is read as the value of its top-left cell (e.g., merged multi-level column headers).
+//
+// A column whose non-blank cells are all numbers is read as []float64, and one whose non-blank cells are all dates is read as []time.Time (in UTC).
+// All other columns are read as []string, with numbers and booleans formatted as text and dates formatted as RFC3339.
+// Blank cells are null.
+func (r XLSXReader) Read() (*DataFrame, error) {
+	rows, err := readXLSX(r.r, r.size, r.Sheet, r.SheetIndex, r.Range)
+	if err != nil {
+		return nil, fmt.Errorf("reading xlsx: %v", err)
+	}
+	if r.HeaderRows > len(rows) {
+		return nil, fmt.Errorf("reading xlsx: number of header rows (%d) exceeds number of rows (%d)", r.HeaderRows, len(rows))
+	}
+	if r.LabelLevels > len(rows[0]) {
+		return nil, fmt.Errorf("reading xlsx: number of label levels (%d) exceeds number of columns (%d)", r.LabelLevels, len(rows[0]))
+	}
+	records := make([][]string, len(rows))
+	for i := range rows {
+		records[i] = make([]string, len(rows[i]))
+		for k := range rows[i] {
+			records[i][k] = rows[i][k].text
+		}
+	}
+	vc, err := readRecords(records, false, r.HeaderRows)
+	if err != nil {
+		return nil, fmt.Errorf("reading xlsx: %v", err)
+	}
+	setXLSXTypes(vc, rows[r.HeaderRows:])
+	return containersToDF(vc, r.HeaderRows, r.LabelLevels, r.Name), nil
+}
+
+// XLSXWriter writes one or more DataFrames to an Excel .xlsx workbook.
+type XLSXWriter struct {
+	IncludeLabels bool // if true, label levels are written as frozen columns to the left of the values
+	w             io.Writer
+}
+
+// NewXLSXWriter returns a *XLSXWriter with default settings.
+func NewXLSXWriter(w io.Writer) *XLSXWriter {
+	return &XLSXWriter{
+		IncludeLabels: true,
+		w:             w,
+	}
+}
+
+// Write writes df to a workbook with a single sheet. See WriteSheets for details.
+func (w *XLSXWriter) Write(df *DataFrame) error {
+	return w.WriteSheets(df)
+}
+
+// WriteSheets writes each DataFrame to its own sheet in a single workbook, in order.
+// Each sheet is named after its DataFrame, or Sheet1, Sheet2, etc if the DataFrame has no name.
+// Sheet names must be unique, at most 31 characters, and may not contain any of []:*?/\.
+//
+// Column names are written as header rows, which are frozen. In all but the last header row,
+// adjacent columns that share the same name at that level (and every level above it) are written as a single merged cell.
+// Numeric values are written as numbers, booleans as booleans, and time.Time and civil.Date values as Excel dates
+// (using the wall clock time in the time.Time location). All other values are written as text.
+// Null values are written as blank cells.
+func (w *XLSXWriter) WriteSheets(dfs ...*DataFrame) error {
+	if len(dfs) == 0 {
+		return fmt.Errorf("writing xlsx: must supply at least one DataFrame")
+	}
+	for i := range dfs {
+		if dfs[i].err != nil {
+			return fmt.Errorf("writing xlsx: %v", dfs[i].err)
+		}
+	}
+	err := writeXLSX(w.w, dfs, w.IncludeLabels)
+	if err != nil {
+		return fmt.Errorf("writing xlsx: %v", err)
+	}
+	return nil
+}
+
+// -- fixed-width text
+
+// FixedWidthColumn is the position of one column in every line of fixed-width text.
+// Positions are counted in characters, starting at 0.
+type FixedWidthColumn struct {
+	Name  string // used as the column name only if there are no header rows
+	Start int    // position of the first character in the column
+	End   int    // position after the last character in the column
+}
+
+// FixedWidthReader reads fixed-width text into a DataFrame.
+type FixedWidthReader struct {
+	RecordReader
+	Columns []FixedWidthColumn // if empty, column positions are inferred from whitespace alignment
+	r       io.Reader
+}
+
+// NewFixedWidthReader creates a new FixedWidthReader with default settings.
+func NewFixedWidthReader(r io.Reader) *FixedWidthReader {
+	return &FixedWidthReader{
+		RecordReader: RecordReader{
+			HeaderRows:  1,
+			LabelLevels: 0,
+		},
+		r: r,
+	}
+}
+
+// Read splits every non-blank line into fields at the positions in r.Columns, trims the surrounding whitespace from each field,
+// and reads the resulting records with the embedded RecordReader
+// (so header rows, label levels, type inference, schema, and null handling are the same as in RecordReader).
+// If a line ends before a column, the field is blank.
+// If r.HeaderRows is 0 and every column in r.Columns has a Name, the names are used as the column names.
+//
+// If r.Columns is empty, a column is inferred for every span of positions that are not whitespace in at least one line,
+// so columns must be separated by at least one position that is whitespace in every line (including header rows).
+func (r *FixedWidthReader) Read() (*DataFrame, error) {
+	lines, err := readFixedWidthLines(r.r)
+	if err != nil {
+		return nil, fmt.Errorf("FixedWidthReader: %v", err)
+	}
+	columns := r.Columns
+	if len(columns) == 0 {
+		columns = inferFixedWidthColumns(lines)
+	}
+	records, err := splitFixedWidthLines(lines, columns)
+	if err != nil {
+		return nil, fmt.Errorf("FixedWidthReader: %v", err)
+	}
+	rr := r.RecordReader
+	if rr.HeaderRows == 0 && hasFixedWidthNames(columns) {
+		header := make([]string, len(columns))
+		for k := range columns {
+			header[k] = columns[k].Name
+		}
+		records = append([][]string{header}, records...)
+		rr.HeaderRows = 1
+	}
+	rr.records = records
+	df, err := rr.Read()
+	if err != nil {
+		return nil, fmt.Errorf("FixedWidthReader: %v", err)
+	}
+	return df, nil
+}
+
+// -- text tables
+
+// TableFormat configures how a MarkdownWriter, HTMLWriter, or LaTeXWriter renders a DataFrame.
+type TableFormat struct {
+	IncludeLabels bool              // if true, label levels are written to the left of the columns
+	MergeRepeats  bool              // if true, a label value that repeats the value above it (and whose prior label levels also repeat) is merged
+	NullString    string            // written in place of null values
+	NumberFormats map[string]string // fmt verbs (e.g., %.2f) for numeric values, keyed by column or label level name
+}
+
+func defaultTableFormat() TableFormat {
+	return TableFormat{
+		IncludeLabels: true,
+		MergeRepeats:  optionMergeRepeats,
+		NullString:    optionsNullPrinter,
+	}
+}
+
+// MarkdownWriter writes a DataFrame as a GitHub-flavored Markdown table.
+type MarkdownWriter struct {
+	TableFormat
+	w io.Writer
+}
+
+// NewMarkdownWriter returns a *MarkdownWriter with default settings.
+func NewMarkdownWriter(w io.Writer) *MarkdownWriter {
+	return &MarkdownWriter{
+		TableFormat: defaultTableFormat(),
+		w:           w,
+	}
+}
+
+// Write writes df as a Markdown table. Markdown supports only one header row,
+// so multi-level column names are written with their levels joined by the level separator (default: |).
+// Numeric columns are right-aligned. Merged label values are left blank.
+// Default label level names (e.g., *0) are not written.
+func (w *MarkdownWriter) Write(df *DataFrame) error {
+	t, err := renderTable(df, w.TableFormat)
+	if err != nil {
+		return fmt.Errorf("writing markdown: %v", err)
+	}
+	_, err = w.w.Write(t.markdown())
+	if err != nil {
+		return fmt.Errorf("writing markdown: %v", err)
+	}
+	return nil
+}
+
+// HTMLWriter writes a DataFrame as an HTML table.
+type HTMLWriter struct {
+	TableFormat
+	Class      string // if not empty, the class attribute of the <table> element
+	LabelClass string // if not empty, the class attribute of every label cell
+	NullClass  string // if not empty, the class attribute of every null cell
+	w          io.Writer
+}
+
+// NewHTMLWriter returns a *HTMLWriter with default settings.
+func NewHTMLWriter(w io.Writer) *HTMLWriter {
+	return &HTMLWriter{
+		TableFormat: defaultTableFormat(),
+		w:           w,
+	}
+}
+
+// Write writes df as an HTML <table>, with one header row per column level and the DataFrame name (if any) as the caption.
+// A column name shared by adjacent columns at every level down to the current one is written once with a colspan.
+// Label values are written as <th> cells, and merged label values are written once with a rowspan.
+// Every value is HTML-escaped. Default label level names (e.g., *0) are not written.
+func (w *HTMLWriter) Write(df *DataFrame) error {
+	t, err := renderTable(df, w.TableFormat)
+	if err != nil {
+		return fmt.Errorf("writing html: %v", err)
+	}
+	_, err = w.w.Write(t.html(df.name, w.Class, w.LabelClass, w.NullClass))
+	if err != nil {
+		return fmt.Errorf("writing html: %v", err)
+	}
+	return nil
+}
+
+// LaTeXWriter writes a DataFrame as a LaTeX tabular environment.
+type LaTeXWriter struct {
+	TableFormat
+	w io.Writer
+}
+
+// NewLaTeXWriter returns a *LaTeXWriter with default settings.
+func NewLaTeXWriter(w io.Writer) *LaTeXWriter {
+	return &LaTeXWriter{
+		TableFormat: defaultTableFormat(),
+		w:           w,
+	}
+}
+
+// Write writes df as a tabular environment, with one header row per column level.
+// A column name shared by adjacent columns at every level down to the current one is written once with \multicolumn.
+// Numeric columns are right-aligned, and label levels are separated from the columns by a vertical rule.
+// Merged label values are left blank. Special characters are escaped.
+// Default label level names (e.g., *0) are not written.
+func (w *LaTeXWriter) Write(df *DataFrame) error {
+	t, err := renderTable(df, w.TableFormat)
+	if err != nil {
+		return fmt.Errorf("writing latex: %v", err)
+	}
+	_, err = w.w.Write(t.latex())
+	if err != nil {
+		return fmt.Errorf("writing latex: %v", err)
+	}
+	return nil
+}
+
 // -- WRITERS
 
 // WriteMockCSV reads r, infers the types, and writes n mock rows to w.
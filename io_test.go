@@ -1,13 +1,33 @@
 package tada
 
 import (
+	"archive/tar"
+	"archive/zip"
 	"bytes"
+	"database/sql"
+	"database/sql/driver"
 	"encoding/csv"
+	"encoding/gob"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"math"
+	"math/big"
+	"os"
+	"path/filepath"
 	"reflect"
 	"strings"
 	"testing"
+	"time"
 
 	"cloud.google.com/go/civil"
+	"github.com/apache/arrow/go/arrow"
+	"github.com/apache/arrow/go/arrow/array"
+	"github.com/apache/arrow/go/arrow/ipc"
+	"github.com/apache/arrow/go/arrow/memory"
+	goparquet "github.com/fraugster/parquet-go"
+	"github.com/fraugster/parquet-go/parquetschema"
 	"github.com/ptiger10/tablediff"
 )
 
@@ -165,13 +185,59 @@ func TestSliceReader_Read(t *testing.T) {
 	}
 }
 
+func TestRecordReader_Read_schemaErrors(t *testing.T) {
+	schema := map[string]ColumnSchema{"bar": {DType: Float64}}
+	r := NewRecordReader([][]string{{"foo", "bar"}, {"a", "1"}, {"b", "c"}})
+	r.Schema = schema
+	_, err := r.Read()
+	want := `reading csv from records: schema: row 1, column bar: cannot parse "c" as Float64: ` +
+		`strconv.ParseFloat: parsing "c": invalid syntax`
+	if err == nil || err.Error() != want {
+		t.Errorf("RecordReader.Read() error = %v, want %v", err, want)
+	}
+
+	csv := NewCSVReader(strings.NewReader("foo,bar\na,1\nb,2\nc,d"))
+	csv.Schema = schema
+	iter := csv.Chunks(2)
+	for iter.Next() {
+	}
+	if iter.Err() == nil || !strings.Contains(iter.Err().Error(), "row 2, column bar") {
+		t.Errorf("CSVReader.Chunks() error = %v, want error in row 2, column bar", iter.Err())
+	}
+
+	csv = NewCSVReader(strings.NewReader("foo,bar\na,1\nb,2\nc,d"))
+	csv.InferTypes = true
+	iter = csv.Chunks(2)
+	for iter.Next() {
+	}
+	want = `CSVReader: chunk starting at row 2: row 2, column bar: cannot parse "d" as Float64`
+	if iter.Err() == nil || iter.Err().Error() != want {
+		t.Errorf("CSVReader.Chunks() error = %v, want %v", iter.Err(), want)
+	}
+}
+
+func TestRecordReader_Read_blankStringAsNull(t *testing.T) {
+	r := NewRecordReader([][]string{{"foo"}, {""}})
+	r.BlankStringAsNull = true
+	r.Read()
+	if _, ok := optionNullStrings.Read()[""]; ok {
+		t.Errorf("RecordReader.Read() with BlankStringAsNull changed the default null strings")
+	}
+}
+
 func TestRecordReader_Read(t *testing.T) {
+	tz, err := time.LoadLocation("America/Los_Angeles")
+	if err != nil {
+		t.Fatal(err)
+	}
 	type fields struct {
 		HeaderRows        int
 		LabelLevels       int
 		ByColumn          bool
 		BlankStringAsNull bool
 		InferTypes        bool
+		Schema            map[string]ColumnSchema
+		Location          *time.Location
 		records           [][]string
 	}
 	tests := []struct {
@@ -180,6 +246,53 @@ func TestRecordReader_Read(t *testing.T) {
 		want    *DataFrame
 		wantErr bool
 	}{
+		{"default location - inferred and schema",
+			fields{
+				HeaderRows: 1,
+				InferTypes: true,
+				Schema:     map[string]ColumnSchema{"bar": {DType: DateTime}},
+				Location:   tz,
+				records:    [][]string{{"foo", "bar", "baz"}, {"2020-03-08 12:00:00", "2020-03-08 12:00:00", "2020-03-08T12:00:00Z"}},
+			},
+			&DataFrame{values: []*valueContainer{
+				{slice: []time.Time{time.Date(2020, 3, 8, 12, 0, 0, 0, tz)}, isNull: []bool{false}, id: mockID, name: "foo",
+					cache: []string{"2020-03-08 12:00:00"}},
+				{slice: []time.Time{time.Date(2020, 3, 8, 12, 0, 0, 0, tz)}, isNull: []bool{false}, id: mockID, name: "bar"},
+				{slice: []time.Time{time.Date(2020, 3, 8, 12, 0, 0, 0, time.UTC)}, isNull: []bool{false}, id: mockID, name: "baz",
+					cache: []string{"2020-03-08T12:00:00Z"}}},
+				labels: []*valueContainer{
+					{slice: []int{0}, isNull: []bool{false}, id: mockID, name: "*0"}},
+				colLevelNames: []string{"*0"}},
+			false},
+		{"default location - daylight saving gap matches Localize",
+			fields{
+				HeaderRows: 1,
+				InferTypes: true,
+				Schema:     map[string]ColumnSchema{"bar": {DType: DateTime}},
+				Location:   tz,
+				records:    [][]string{{"foo", "bar"}, {"2020-03-08 02:30:00", "2020-03-08 02:30:00"}},
+			},
+			&DataFrame{values: []*valueContainer{
+				{slice: []time.Time{time.Date(2020, 3, 8, 3, 30, 0, 0, tz)}, isNull: []bool{false}, id: mockID, name: "foo",
+					cache: []string{"2020-03-08 02:30:00"}},
+				{slice: []time.Time{time.Date(2020, 3, 8, 3, 30, 0, 0, tz)}, isNull: []bool{false}, id: mockID, name: "bar"}},
+				labels: []*valueContainer{
+					{slice: []int{0}, isNull: []bool{false}, id: mockID, name: "*0"}},
+				colLevelNames: []string{"*0"}},
+			false},
+		{"no location - times without a time zone are not inferred",
+			fields{
+				HeaderRows: 1,
+				InferTypes: true,
+				records:    [][]string{{"foo"}, {"2020-03-08 12:00:00"}},
+			},
+			&DataFrame{values: []*valueContainer{
+				{slice: []string{"2020-03-08 12:00:00"}, isNull: []bool{false}, id: mockID, name: "foo",
+					cache: []string{"2020-03-08 12:00:00"}}},
+				labels: []*valueContainer{
+					{slice: []int{0}, isNull: []bool{false}, id: mockID, name: "*0"}},
+				colLevelNames: []string{"*0"}},
+			false},
 		{"empty space as null",
 			fields{
 				HeaderRows:        1,
@@ -227,6 +340,115 @@ func TestRecordReader_Read(t *testing.T) {
 					{slice: []int{0, 1}, isNull: []bool{false, false}, id: mockID, name: "*0"}},
 				colLevelNames: []string{"*0"}},
 			false},
+		{"schema",
+			fields{
+				HeaderRows:  1,
+				LabelLevels: 1,
+				InferTypes:  true,
+				Schema: map[string]ColumnSchema{
+					"foo":  {DType: Date, TimeLayout: "20060102"},
+					"bar":  {DType: Float64, NullStrings: []string{"n/a"}},
+					"baz":  {DType: DateTime, Location: time.FixedZone("UTC-8", -8*60*60)},
+					"quux": {DType: Float64},
+				},
+				records: [][]string{{"foo", "bar", "baz", "qux"}, {"20200101", "1", "2020-01-01", "2"}, {"20200102", "n/a", "(null)", "3"}},
+			},
+			&DataFrame{values: []*valueContainer{
+				{slice: []float64{1, 0}, isNull: []bool{false, true}, id: mockID, name: "bar"},
+				{slice: []time.Time{time.Date(2020, 1, 1, 0, 0, 0, 0, time.FixedZone("UTC-8", -8*60*60)), {}}, isNull: []bool{false, true}, id: mockID, name: "baz"},
+				{slice: []float64{2, 3}, isNull: []bool{false, false}, id: mockID, name: "qux", cache: []string{"2", "3"}}},
+				labels: []*valueContainer{
+					{slice: []civil.Date{{Year: 2020, Month: 1, Day: 1}, {Year: 2020, Month: 1, Day: 2}}, isNull: []bool{false, false}, id: mockID, name: "foo"}},
+				colLevelNames: []string{"*0"}},
+			false},
+		{"schema - int64 and bool",
+			fields{
+				HeaderRows: 1,
+				Schema: map[string]ColumnSchema{
+					"foo": {DType: Int64},
+					"bar": {DType: Bool},
+				},
+				records: [][]string{{"foo", "bar"}, {"9007199254740993", "true"}, {"(null)", "false"}},
+			},
+			&DataFrame{values: []*valueContainer{
+				{slice: []int64{9007199254740993, 0}, isNull: []bool{false, true}, id: mockID, name: "foo"},
+				{slice: []bool{true, false}, isNull: []bool{false, false}, id: mockID, name: "bar"}},
+				labels: []*valueContainer{
+					{slice: []int{0, 1}, isNull: []bool{false, false}, id: mockID, name: "*0"}},
+				colLevelNames: []string{"*0"}},
+			false},
+		{"schema - decimal",
+			fields{
+				HeaderRows: 1,
+				Schema:     map[string]ColumnSchema{"foo": {DType: Decimal}},
+				records:    [][]string{{"foo"}, {"19.99"}, {"(null)"}},
+			},
+			&DataFrame{values: []*valueContainer{
+				{slice: []*big.Rat{mockDecimal("19.99"), new(big.Rat)}, isNull: []bool{false, true}, id: mockID, name: "foo"}},
+				labels: []*valueContainer{
+					{slice: []int{0, 1}, isNull: []bool{false, false}, id: mockID, name: "*0"}},
+				colLevelNames: []string{"*0"}},
+			false},
+		{"schema - duration",
+			fields{
+				HeaderRows: 1,
+				Schema:     map[string]ColumnSchema{"foo": {DType: Duration}},
+				records:    [][]string{{"foo"}, {"1h30m"}, {"P1D"}, {"(null)"}},
+			},
+			&DataFrame{values: []*valueContainer{
+				{slice: []time.Duration{90 * time.Minute, 24 * time.Hour, 0}, isNull: []bool{false, false, true}, id: mockID, name: "foo"}},
+				labels: []*valueContainer{
+					{slice: []int{0, 1, 2}, isNull: []bool{false, false, false}, id: mockID, name: "*0"}},
+				colLevelNames: []string{"*0"}},
+			false},
+		{"fail - schema - duration does not parse",
+			fields{
+				HeaderRows: 1,
+				Schema:     map[string]ColumnSchema{"bar": {DType: Duration}},
+				records:    [][]string{{"foo", "bar"}, {"a", "P1Y"}},
+			},
+			nil,
+			true},
+		{"fail - schema - decimal does not parse",
+			fields{
+				HeaderRows: 1,
+				Schema:     map[string]ColumnSchema{"bar": {DType: Decimal}},
+				records:    [][]string{{"foo", "bar"}, {"a", "$1"}},
+			},
+			nil,
+			true},
+		{"fail - schema - int64 does not parse",
+			fields{
+				HeaderRows: 1,
+				Schema:     map[string]ColumnSchema{"bar": {DType: Int64}},
+				records:    [][]string{{"foo", "bar"}, {"a", "1.5"}},
+			},
+			nil,
+			true},
+		{"fail - schema - value does not parse",
+			fields{
+				HeaderRows: 1,
+				Schema:     map[string]ColumnSchema{"bar": {DType: Float64}},
+				records:    [][]string{{"foo", "bar"}, {"a", "1"}, {"b", "c"}},
+			},
+			nil,
+			true},
+		{"fail - schema - required column missing",
+			fields{
+				HeaderRows: 1,
+				Schema:     map[string]ColumnSchema{"baz": {DType: Float64, Required: true}},
+				records:    [][]string{{"foo", "bar"}, {"a", "1"}},
+			},
+			nil,
+			true},
+		{"fail - schema - unsupported dtype",
+			fields{
+				HeaderRows: 1,
+				Schema:     map[string]ColumnSchema{"bar": {DType: 100}},
+				records:    [][]string{{"foo", "bar"}, {"a", "1"}},
+			},
+			nil,
+			true},
 		{"fail - no rows",
 			fields{records: nil},
 			nil,
@@ -244,6 +466,8 @@ func TestRecordReader_Read(t *testing.T) {
 				ByColumn:          tt.fields.ByColumn,
 				BlankStringAsNull: tt.fields.BlankStringAsNull,
 				InferTypes:        tt.fields.InferTypes,
+				Schema:            tt.fields.Schema,
+				Location:          tt.fields.Location,
 				records:           tt.fields.records,
 			}
 			got, err := r.Read()
@@ -581,6 +805,121 @@ func (mat testMatrix) T() Matrix {
 	return mat
 }
 
+// fakeDriver is a database/sql driver that serves the fakeTable named by the data source name for every query.
+type fakeDriver struct{}
+
+type fakeTable struct {
+	columns   []string
+	scanTypes []reflect.Type
+	dbTypes   []string
+	rows      [][]driver.Value
+	err       error     // returned by Rows.Next after all rows, and by Stmt.Exec
+	execs     *[]string // if not nil, every executed statement and its arguments are recorded here
+}
+
+var fakeTables = map[string]fakeTable{}
+
+func init() {
+	sql.Register("tadafake", fakeDriver{})
+}
+
+// openFakeDB registers table and returns a *sql.DB that serves it.
+func openFakeDB(name string, table fakeTable) *sql.DB {
+	fakeTables[name] = table
+	db, _ := sql.Open("tadafake", name)
+	return db
+}
+
+func (fakeDriver) Open(name string) (driver.Conn, error) {
+	table, ok := fakeTables[name]
+	if !ok {
+		return nil, fmt.Errorf("table %s not found", name)
+	}
+	return fakeConn{table: table}, nil
+}
+
+type fakeConn struct {
+	table fakeTable
+}
+
+func (c fakeConn) Prepare(query string) (driver.Stmt, error) {
+	return fakeStmt{table: c.table, query: query}, nil
+}
+
+func (c fakeConn) Close() error {
+	return nil
+}
+
+func (c fakeConn) Begin() (driver.Tx, error) {
+	return nil, fmt.Errorf("transactions not supported")
+}
+
+type fakeStmt struct {
+	table fakeTable
+	query string
+}
+
+func (s fakeStmt) Close() error {
+	return nil
+}
+
+func (s fakeStmt) NumInput() int {
+	return -1
+}
+
+func (s fakeStmt) Exec(args []driver.Value) (driver.Result, error) {
+	if s.table.err != nil {
+		return nil, s.table.err
+	}
+	if s.table.execs != nil {
+		*s.table.execs = append(*s.table.execs, fmt.Sprint(s.query, " ", args))
+	}
+	return driver.RowsAffected(0), nil
+}
+
+func (s fakeStmt) Query(args []driver.Value) (driver.Rows, error) {
+	return &fakeRows{table: s.table}, nil
+}
+
+type fakeRows struct {
+	table fakeTable
+	pos   int
+}
+
+func (r *fakeRows) Columns() []string {
+	return r.table.columns
+}
+
+func (r *fakeRows) Close() error {
+	return nil
+}
+
+func (r *fakeRows) Next(dest []driver.Value) error {
+	if r.pos >= len(r.table.rows) {
+		if r.table.err != nil {
+			return r.table.err
+		}
+		return io.EOF
+	}
+	copy(dest, r.table.rows[r.pos])
+	r.pos++
+	return nil
+}
+
+func (r *fakeRows) ColumnTypeScanType(index int) reflect.Type {
+	if r.table.scanTypes == nil {
+		return reflect.TypeOf(new(interface{})).Elem()
+	}
+	return r.table.scanTypes[index]
+}
+
+func (r *fakeRows) ColumnTypeDatabaseTypeName(index int) string {
+	if r.table.dbTypes == nil {
+		return ""
+	}
+	return r.table.dbTypes[index]
+}
+
 func Test_valueContainer_UnmarshalJSON(t *testing.T) {
 	type args struct {
 		b []byte
@@ -642,6 +981,11 @@ func TestDataFrame_UnmarshalJSON(t *testing.T) {
 			&DataFrame{},
 			false,
 		},
+		{"fail - unsupported version",
+			args{[]byte(`{"version": 1000}`)},
+			&DataFrame{},
+			true,
+		},
 		{"err",
 			args{[]byte(`foo`)},
 			&DataFrame{},
@@ -661,125 +1005,436 @@ func TestDataFrame_UnmarshalJSON(t *testing.T) {
 	}
 }
 
-func TestStructReader_Read(t *testing.T) {
-	type fields struct {
-		sliceOfStructs interface{}
-		IsNull         [][]bool
-		LabelLevels    int
-		Name           string
+func TestDataFrame_MarshalJSON(t *testing.T) {
+	d := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	tz, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Fatal(err)
 	}
+	dst := time.Date(2020, 7, 1, 12, 0, 0, 0, tz)
 	tests := []struct {
-		name    string
-		fields  fields
-		want    *DataFrame
-		wantErr bool
+		name string
+		df   *DataFrame
+		want *DataFrame
 	}{
-		{"pass",
-			fields{
-				sliceOfStructs: []testStruct{{"foo", 1}, {"", 2}},
-				IsNull:         [][]bool{{false, false}, {true, false}},
-			},
+		{"pass - types preserved",
 			&DataFrame{
 				values: []*valueContainer{
-					{slice: []string{"foo", ""}, isNull: []bool{false, true}, id: mockID, name: "name"},
-					{slice: []int{1, 2}, isNull: []bool{false, false}, id: mockID, name: "age"}},
-				labels:        []*valueContainer{{slice: []int{0, 1}, isNull: []bool{false, false}, id: mockID, name: "*0"}},
-				name:          "",
+					{slice: []float64{1, math.NaN()}, isNull: []bool{false, true}, id: mockID, name: "foo|a"},
+					{slice: []time.Time{d, {}}, isNull: []bool{false, true}, id: mockID, name: "bar|b"},
+					{slice: []civil.Date{civil.DateOf(d), {}}, isNull: []bool{false, true}, id: mockID, name: "baz|c"},
+					{slice: []civil.Time{civil.TimeOf(d.Add(time.Hour)), {}}, isNull: []bool{false, true}, id: mockID, name: "qux|d"},
+					{slice: []bool{true, false}, isNull: []bool{false, false}, id: mockID, name: "quux|e"}},
+				labels: []*valueContainer{
+					{slice: []int{0, 1}, isNull: []bool{false, false}, id: mockID, name: "*0"},
+					{slice: []string{"a", "b"}, isNull: []bool{false, false}, id: mockID, name: "corge"}},
+				colLevelNames: []string{"*0", "*1"},
+				name:          "grault"},
+			&DataFrame{
+				values: []*valueContainer{
+					{slice: []float64{1, 0}, isNull: []bool{false, true}, id: mockID, name: "foo|a"},
+					{slice: []time.Time{d, {}}, isNull: []bool{false, true}, id: mockID, name: "bar|b"},
+					{slice: []civil.Date{civil.DateOf(d), {}}, isNull: []bool{false, true}, id: mockID, name: "baz|c"},
+					{slice: []civil.Time{civil.TimeOf(d.Add(time.Hour)), {}}, isNull: []bool{false, true}, id: mockID, name: "qux|d"},
+					{slice: []bool{true, false}, isNull: []bool{false, false}, id: mockID, name: "quux|e"}},
+				labels: []*valueContainer{
+					{slice: []int{0, 1}, isNull: []bool{false, false}, id: mockID, name: "*0"},
+					{slice: []string{"a", "b"}, isNull: []bool{false, false}, id: mockID, name: "corge"}},
+				colLevelNames: []string{"*0", "*1"},
+				name:          "grault"},
+		},
+		{"pass - named location preserved",
+			&DataFrame{
+				values: []*valueContainer{
+					{slice: []time.Time{d.In(tz), dst, {}}, isNull: []bool{false, false, true}, id: mockID, name: "foo"}},
+				labels: []*valueContainer{
+					{slice: []int{0, 1, 2}, isNull: []bool{false, false, false}, id: mockID, name: "*0"}},
+				colLevelNames: []string{"*0"}},
+			&DataFrame{
+				values: []*valueContainer{
+					{slice: []time.Time{d.In(tz), dst, {}}, isNull: []bool{false, false, true}, id: mockID, name: "foo"}},
+				labels: []*valueContainer{
+					{slice: []int{0, 1, 2}, isNull: []bool{false, false, false}, id: mockID, name: "*0"}},
+				colLevelNames: []string{"*0"}},
+		},
+		{"pass - categories preserved",
+			&DataFrame{
+				values: []*valueContainer{
+					{slice: newCategories([]string{"b", "a", ""}, []bool{false, false, true}), isNull: []bool{false, false, true}, id: mockID, name: "foo"}},
+				labels: []*valueContainer{
+					{slice: []int{0, 1, 2}, isNull: []bool{false, false, false}, id: mockID, name: "*0"}},
+				colLevelNames: []string{"*0"}},
+			&DataFrame{
+				values: []*valueContainer{
+					{slice: newCategories([]string{"b", "a", ""}, []bool{false, false, true}), isNull: []bool{false, false, true}, id: mockID, name: "foo"}},
+				labels: []*valueContainer{
+					{slice: []int{0, 1, 2}, isNull: []bool{false, false, false}, id: mockID, name: "*0"}},
 				colLevelNames: []string{"*0"}},
-			false,
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			r := StructReader{
-				sliceOfStructs: tt.fields.sliceOfStructs,
-				IsNull:         tt.fields.IsNull,
-				LabelLevels:    tt.fields.LabelLevels,
-				Name:           tt.fields.Name,
+			b, err := tt.df.MarshalJSON()
+			if err != nil {
+				t.Errorf("DataFrame.MarshalJSON() error = %v", err)
+				return
 			}
-			got, err := r.Read()
-			if (err != nil) != tt.wantErr {
-				t.Errorf("StructReader.Read() error = %v, wantErr %v", err, tt.wantErr)
+			got := new(DataFrame)
+			err = got.UnmarshalJSON(b)
+			if err != nil {
+				t.Errorf("DataFrame.MarshalJSON() -> UnmarshalJSON() error = %v", err)
 				return
 			}
 			if !EqualDataFrames(got, tt.want) {
-				t.Errorf("StructReader.Read() = %v, want %v", got, tt.want)
+				t.Errorf("DataFrame.MarshalJSON() -> UnmarshalJSON() = %v, want %v", got, tt.want)
 			}
 		})
 	}
 }
 
-func TestStructWriter_Write(t *testing.T) {
-	type fields struct {
-		sliceOfStructs interface{}
-		isNull         [][]bool
-		IncludeLabels  bool
-	}
-	type args struct {
-		df *DataFrame
-	}
+func TestSeries_MarshalJSON(t *testing.T) {
 	tests := []struct {
-		name    string
-		fields  fields
-		args    args
-		want    interface{}
-		wantErr bool
+		name string
+		s    *Series
+		want *Series
 	}{
 		{"pass",
-			fields{
-				sliceOfStructs: &[]testStruct{},
-			},
-			args{
-				&DataFrame{
-					values: []*valueContainer{
-						{slice: []string{"foo", ""}, isNull: []bool{false, false}, id: mockID, name: "name"},
-						{slice: []int{1, 2}, isNull: []bool{false, false}, id: mockID, name: "age"}},
-					labels:        []*valueContainer{{slice: []int{0, 1}, isNull: []bool{false, false}, id: mockID, name: "*0"}},
-					colLevelNames: []string{"*0"},
-				},
-			},
-			&[]testStruct{
-				{"foo", 1},
-				{"", 2},
-			},
-			false,
+			&Series{
+				values: &valueContainer{slice: []int64{1, 2}, isNull: []bool{false, false}, id: mockID, name: "foo"},
+				labels: []*valueContainer{{slice: []string{"a", "b"}, isNull: []bool{false, false}, id: mockID, name: "*0"}}},
+			&Series{
+				values: &valueContainer{slice: []int64{1, 2}, isNull: []bool{false, false}, id: mockID, name: "foo"},
+				labels: []*valueContainer{{slice: []string{"a", "b"}, isNull: []bool{false, false}, id: mockID, name: "*0"}}},
+		},
+		{"pass - categorical",
+			&Series{
+				values: &valueContainer{slice: newCategories([]string{"b", "a"}, []bool{false, false}), isNull: []bool{false, false}, id: mockID, name: "foo"},
+				labels: []*valueContainer{{slice: []string{"a", "b"}, isNull: []bool{false, false}, id: mockID, name: "*0"}}},
+			&Series{
+				values: &valueContainer{slice: newCategories([]string{"b", "a"}, []bool{false, false}), isNull: []bool{false, false}, id: mockID, name: "foo"},
+				labels: []*valueContainer{{slice: []string{"a", "b"}, isNull: []bool{false, false}, id: mockID, name: "*0"}}},
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			w := &StructWriter{
-				sliceOfStructs: tt.fields.sliceOfStructs,
-				isNull:         tt.fields.isNull,
-				IncludeLabels:  tt.fields.IncludeLabels,
+			b, err := json.Marshal(tt.s)
+			if err != nil {
+				t.Errorf("Series.MarshalJSON() error = %v", err)
+				return
 			}
-			if err := w.Write(tt.args.df); (err != nil) != tt.wantErr {
-				t.Errorf("StructWriter.Write() error = %v, wantErr %v", err, tt.wantErr)
+			got := new(Series)
+			err = json.Unmarshal(b, got)
+			if err != nil {
+				t.Errorf("Series.MarshalJSON() -> UnmarshalJSON() error = %v", err)
+				return
 			}
-			if !reflect.DeepEqual(w.sliceOfStructs, tt.want) {
-				t.Errorf("StructWriter.Write() -> = %v, want %v", w.sliceOfStructs, tt.want)
+			if !EqualSeries(got, tt.want) {
+				t.Errorf("Series.MarshalJSON() -> UnmarshalJSON() = %v, want %v", got, tt.want)
 			}
 		})
 	}
 }
 
-func TestWriteMockCSV(t *testing.T) {
-	got := `foo,bar
-10,fred
-100,corge`
-	want := `foo,bar
-0.5,baz
-0.5,baz
-`
-	b := new(bytes.Buffer)
+func TestSeries_UnmarshalJSON(t *testing.T) {
 	type args struct {
-		r *CSVReader
-		w *CSVWriter
-		n int
+		b []byte
 	}
 	tests := []struct {
 		name    string
 		args    args
-		want    string
+		want    *Series
+		wantErr bool
+	}{
+		{"pass - old format",
+			args{[]byte(`
+			{"values": {"slice": [1], "isNull": [false], "id": "1", "name": "foo"},
+			"labels": [{"slice": ["bar"], "isNull": [false], "id": "2", "name": "*0"}]}`)},
+			&Series{
+				values: &valueContainer{slice: []interface{}{float64(1)}, isNull: []bool{false}, name: "foo", id: "1"},
+				labels: []*valueContainer{{slice: []interface{}{"bar"}, isNull: []bool{false}, name: "*0", id: "2"}}},
+			false,
+		},
+		{"nil",
+			args{[]byte(`null`)},
+			&Series{},
+			false,
+		},
+		{"fail - unsupported version",
+			args{[]byte(`{"version": 1000}`)},
+			&Series{},
+			true,
+		},
+		{"fail - unsupported dtype",
+			args{[]byte(`{"version": 1, "values": {"slice": [1], "isNull": [false], "dtype": "foo"}}`)},
+			&Series{},
+			true,
+		},
+		{"fail - mismatched lengths",
+			args{[]byte(`{"version": 1, "values": {"slice": [1], "isNull": [false, false], "dtype": "[]int"}}`)},
+			&Series{},
+			true,
+		},
+		{"err",
+			args{[]byte(`foo`)},
+			&Series{},
+			true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := new(Series)
+			if err := s.UnmarshalJSON(tt.args.b); (err != nil) != tt.wantErr {
+				t.Errorf("Series.UnmarshalJSON() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !EqualSeries(s, tt.want) {
+				t.Errorf("Series.UnmarshalJSON() -> = %v, want %v", s, tt.want)
+			}
+		})
+	}
+}
+
+type testStructTagged struct {
+	ID       string `tada:"id,label"`
+	Name     string `json:"name" tada:"full_name"`
+	Internal int    `tada:"-"`
+	Ignored  int    `json:"-"`
+	Score    *float64
+}
+
+func TestStructReader_Read(t *testing.T) {
+	type fields struct {
+		sliceOfStructs interface{}
+		IsNull         [][]bool
+		LabelLevels    int
+		Name           string
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		want    *DataFrame
+		wantErr bool
+	}{
+		{"pass",
+			fields{
+				sliceOfStructs: []testStruct{{"foo", 1}, {"", 2}},
+				IsNull:         [][]bool{{false, false}, {true, false}},
+			},
+			&DataFrame{
+				values: []*valueContainer{
+					{slice: []string{"foo", ""}, isNull: []bool{false, true}, id: mockID, name: "name"},
+					{slice: []int{1, 2}, isNull: []bool{false, false}, id: mockID, name: "age"}},
+				labels:        []*valueContainer{{slice: []int{0, 1}, isNull: []bool{false, false}, id: mockID, name: "*0"}},
+				name:          "",
+				colLevelNames: []string{"*0"}},
+			false,
+		},
+		{"pass - tags",
+			fields{
+				sliceOfStructs: []testStructTagged{
+					{ID: "a", Name: "foo", Internal: 1, Ignored: 2, Score: &[]float64{1.5}[0]},
+					{ID: "b", Name: "bar"},
+				},
+			},
+			&DataFrame{
+				values: []*valueContainer{
+					{slice: []string{"foo", "bar"}, isNull: []bool{false, false}, id: mockID, name: "full_name"},
+					{slice: []float64{1.5, 0}, isNull: []bool{false, true}, id: mockID, name: "Score"}},
+				labels:        []*valueContainer{{slice: []string{"a", "b"}, isNull: []bool{false, false}, id: mockID, name: "id"}},
+				name:          "",
+				colLevelNames: []string{"*0"}},
+			false,
+		},
+		{"pass - nested",
+			fields{
+				sliceOfStructs: []testStructNested{{testStructEmbedded{1}, "foo", testStructAddress{"bar", 10}, time.Time{}}},
+				LabelLevels:    1,
+			},
+			&DataFrame{
+				values: []*valueContainer{
+					{slice: []string{"foo"}, isNull: []bool{false}, id: mockID, name: "name|"},
+					{slice: []string{"bar"}, isNull: []bool{false}, id: mockID, name: "address|city"},
+					{slice: []int{10}, isNull: []bool{false}, id: mockID, name: "address|Zip"},
+					{slice: []time.Time{{}}, isNull: []bool{true}, id: mockID, name: "Updated|"}},
+				labels:        []*valueContainer{{slice: []int{1}, isNull: []bool{false}, id: mockID, name: "id"}},
+				name:          "",
+				colLevelNames: []string{"*0", "*1"}},
+			false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := StructReader{
+				sliceOfStructs: tt.fields.sliceOfStructs,
+				IsNull:         tt.fields.IsNull,
+				LabelLevels:    tt.fields.LabelLevels,
+				Name:           tt.fields.Name,
+			}
+			got, err := r.Read()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("StructReader.Read() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !EqualDataFrames(got, tt.want) {
+				t.Errorf("StructReader.Read() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestStructWriter_Write(t *testing.T) {
+	type fields struct {
+		sliceOfStructs interface{}
+		isNull         [][]bool
+		IncludeLabels  bool
+	}
+	type args struct {
+		df *DataFrame
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		want    interface{}
+		wantErr bool
+	}{
+		{"pass",
+			fields{
+				sliceOfStructs: &[]testStruct{},
+			},
+			args{
+				&DataFrame{
+					values: []*valueContainer{
+						{slice: []string{"foo", ""}, isNull: []bool{false, false}, id: mockID, name: "name"},
+						{slice: []int{1, 2}, isNull: []bool{false, false}, id: mockID, name: "age"}},
+					labels:        []*valueContainer{{slice: []int{0, 1}, isNull: []bool{false, false}, id: mockID, name: "*0"}},
+					colLevelNames: []string{"*0"},
+				},
+			},
+			&[]testStruct{
+				{"foo", 1},
+				{"", 2},
+			},
+			false,
+		},
+		{"pass - tags",
+			fields{
+				sliceOfStructs: &[]testStructTagged{},
+			},
+			args{
+				&DataFrame{
+					values: []*valueContainer{
+						{slice: []string{"foo", "bar"}, isNull: []bool{false, false}, id: mockID, name: "full_name"},
+						{slice: []float64{1.5, 0}, isNull: []bool{false, true}, id: mockID, name: "Score"},
+						{slice: []int{1, 2}, isNull: []bool{false, false}, id: mockID, name: "Internal"}},
+					labels:        []*valueContainer{{slice: []string{"a", "b"}, isNull: []bool{false, false}, id: mockID, name: "id"}},
+					colLevelNames: []string{"*0"},
+				},
+			},
+			&[]testStructTagged{
+				{ID: "a", Name: "foo", Score: &[]float64{1.5}[0]},
+				{ID: "b", Name: "bar"},
+			},
+			false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := &StructWriter{
+				sliceOfStructs: tt.fields.sliceOfStructs,
+				isNull:         tt.fields.isNull,
+				IncludeLabels:  tt.fields.IncludeLabels,
+			}
+			if err := w.Write(tt.args.df); (err != nil) != tt.wantErr {
+				t.Errorf("StructWriter.Write() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(w.sliceOfStructs, tt.want) {
+				t.Errorf("StructWriter.Write() -> = %v, want %v", w.sliceOfStructs, tt.want)
+			}
+		})
+	}
+}
+
+func TestMapReader_Read(t *testing.T) {
+	type fields struct {
+		sliceOfMaps []map[string]interface{}
+		LabelLevels int
+		Name        string
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		want    *DataFrame
+		wantErr bool
+	}{
+		{"pass",
+			fields{
+				sliceOfMaps: []map[string]interface{}{
+					{"id": "a", "score": 1.5, "profile": map[string]interface{}{"city": "foo"}},
+					{"id": "b"},
+				},
+				LabelLevels: 1,
+				Name:        "baz",
+			},
+			&DataFrame{
+				values: []*valueContainer{
+					{slice: []string{"foo", ""}, isNull: []bool{false, true}, id: mockID, name: "profile|city"},
+					{slice: []float64{1.5, 0}, isNull: []bool{false, true}, id: mockID, name: "score|"}},
+				labels:        []*valueContainer{{slice: []string{"a", "b"}, isNull: []bool{false, false}, id: mockID, name: "id"}},
+				name:          "baz",
+				colLevelNames: []string{"*0", "*1"}},
+			false,
+		},
+		{"fail - too many label levels",
+			fields{
+				sliceOfMaps: []map[string]interface{}{{"id": "a"}},
+				LabelLevels: 2,
+			},
+			nil,
+			true,
+		},
+		{"fail - empty",
+			fields{sliceOfMaps: []map[string]interface{}{}},
+			nil,
+			true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := MapReader{
+				sliceOfMaps: tt.fields.sliceOfMaps,
+				LabelLevels: tt.fields.LabelLevels,
+				Name:        tt.fields.Name,
+			}
+			got, err := r.Read()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("MapReader.Read() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !EqualDataFrames(got, tt.want) {
+				t.Errorf("MapReader.Read() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWriteMockCSV(t *testing.T) {
+	got := `foo,bar
+10,fred
+100,corge`
+	want := `foo,bar
+0.5,baz
+0.5,baz
+`
+	b := new(bytes.Buffer)
+	type args struct {
+		r *CSVReader
+		w *CSVWriter
+		n int
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    string
 		wantErr bool
 	}{
 		{"pass",
@@ -840,6 +1495,2589 @@ func TestMatrixReader_Read(t *testing.T) {
 	}
 }
 
+// matrixRows returns the values in mat, by row.
+func matrixRows(mat Matrix) [][]float64 {
+	r, c := mat.Dims()
+	ret := make([][]float64, r)
+	for i := range ret {
+		ret[i] = make([]float64, c)
+		for j := range ret[i] {
+			ret[i][j] = mat.At(i, j)
+		}
+	}
+	return ret
+}
+
+func TestMatrixWriter_Write(t *testing.T) {
+	df := &DataFrame{
+		values: []*valueContainer{
+			{slice: []float64{1, 0, 3}, isNull: []bool{false, true, false}, id: mockID, name: "foo"},
+			{slice: []int{4, 5, 6}, isNull: []bool{false, false, false}, id: mockID, name: "bar"},
+			{slice: []string{"a", "b", "c"}, isNull: []bool{false, false, false}, id: mockID, name: "baz"},
+		},
+		labels:        []*valueContainer{{slice: []uint{7, 8, 9}, isNull: []bool{false, false, false}, id: mockID, name: "qux"}},
+		colLevelNames: []string{"*0"},
+	}
+	type fields struct {
+		Columns    []string
+		NullPolicy MatrixNullPolicy
+		FillValue  float64
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		df      *DataFrame
+		want    [][]float64
+		wantErr bool
+	}{
+		{"pass - drop rows", fields{Columns: []string{"foo", "bar", "qux"}, NullPolicy: MatrixNullDropRow}, df,
+			[][]float64{{1, 4, 7}, {3, 6, 9}}, false},
+		{"pass - fill", fields{Columns: []string{"bar", "foo"}, NullPolicy: MatrixNullFill, FillValue: -1}, df,
+			[][]float64{{4, 1}, {5, -1}, {6, 3}}, false},
+		{"pass - no nulls", fields{Columns: []string{"bar"}}, df,
+			[][]float64{{4}, {5}, {6}}, false},
+		{"fail - null", fields{Columns: []string{"foo"}}, df, nil, true},
+		{"fail - not numeric", fields{NullPolicy: MatrixNullDropRow}, df, nil, true},
+		{"fail - no column", fields{Columns: []string{"corge"}}, df, nil, true},
+		{"fail - unsupported policy", fields{NullPolicy: 3}, df, nil, true},
+		{"fail - DataFrame error", fields{}, &DataFrame{err: fmt.Errorf("foo")}, nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := &MatrixWriter{
+				Columns:    tt.fields.Columns,
+				NullPolicy: tt.fields.NullPolicy,
+				FillValue:  tt.fields.FillValue,
+			}
+			err := w.Write(tt.df)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("MatrixWriter.Write() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				return
+			}
+			if got := matrixRows(w.Matrix()); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("MatrixWriter.Write() -> %v, want %v", got, tt.want)
+			}
+		})
+	}
+	if !reflect.DeepEqual(df.values[0].slice, []float64{1, 0, 3}) {
+		t.Errorf("MatrixWriter.Write() changed original values: %v", df.values[0].slice)
+	}
+}
+
+func TestDataFrame_Matrix(t *testing.T) {
+	df := &DataFrame{
+		values: []*valueContainer{
+			{slice: []float64{1, 2, 3}, isNull: []bool{false, false, false}, id: mockID, name: "foo"},
+			{slice: []float64{4, 5, 6}, isNull: []bool{false, false, false}, id: mockID, name: "bar"},
+		},
+		labels:        []*valueContainer{{slice: []int{0, 1, 2}, isNull: []bool{false, false, false}, id: mockID, name: "*0"}},
+		colLevelNames: []string{"*0"},
+	}
+	mat, err := df.Matrix()
+	if err != nil {
+		t.Fatalf("DataFrame.Matrix() error = %v", err)
+	}
+	if r, c := mat.Dims(); r != 3 || c != 2 {
+		t.Errorf("DataFrame.Matrix() -> Dims() = %v, %v, want 3, 2", r, c)
+	}
+	if got, want := matrixRows(mat.T()), [][]float64{{1, 2, 3}, {4, 5, 6}}; !reflect.DeepEqual(got, want) {
+		t.Errorf("DataFrame.Matrix() -> T() = %v, want %v", got, want)
+	}
+	got, err := NewMatrixReader(mat).Read()
+	if err != nil {
+		t.Fatalf("DataFrame.Matrix() -> MatrixReader.Read() error = %v", err)
+	}
+	want := &DataFrame{
+		values: []*valueContainer{
+			{slice: []float64{1, 2, 3}, isNull: []bool{false, false, false}, id: mockID, name: "0"},
+			{slice: []float64{4, 5, 6}, isNull: []bool{false, false, false}, id: mockID, name: "1"},
+		},
+		labels:        []*valueContainer{{slice: []int{0, 1, 2}, isNull: []bool{false, false, false}, id: mockID, name: "*0"}},
+		colLevelNames: []string{"*0"},
+	}
+	if !EqualDataFrames(got, want) {
+		t.Errorf("DataFrame.Matrix() -> MatrixReader.Read() = %v, want %v", got, want)
+	}
+	_, err = df.Matrix("corge")
+	if err == nil {
+		t.Errorf("DataFrame.Matrix() error = nil, want error for missing column")
+	}
+}
+
+func TestSnapshotWriter_Write(t *testing.T) {
+	d := time.Date(2020, 1, 1, 12, 30, 0, 0, time.UTC)
+	tz, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Fatal(err)
+	}
+	df := &DataFrame{
+		labels: []*valueContainer{
+			{slice: []int{0, 1}, isNull: []bool{false, false}, id: mockID, name: "*0"},
+			{slice: []string{"a", ""}, isNull: []bool{false, true}, cache: []string{"a", ""}, id: "foo", name: "bar"},
+		},
+		values: []*valueContainer{
+			{slice: []float64{1.5, 0}, isNull: []bool{false, true}, id: mockID, name: "a|float"},
+			{slice: []int8{1, 2}, isNull: []bool{false, false}, id: mockID, name: "a|int8"},
+			{slice: []uint64{1, 2}, isNull: []bool{false, false}, id: mockID, name: "a|uint64"},
+			{slice: []bool{true, false}, isNull: []bool{false, false}, id: mockID, name: "b|bool"},
+			{slice: []time.Time{d, {}}, isNull: []bool{false, true}, id: mockID, name: "b|datetime"},
+			{slice: []time.Time{d.In(tz), time.Date(2020, 7, 1, 12, 0, 0, 0, tz)}, isNull: []bool{false, false}, id: mockID, name: "b|datetime in location"},
+			{slice: []civil.Date{{Year: 2020, Month: 1, Day: 1}, {}}, isNull: []bool{false, true}, id: mockID, name: "b|date"},
+			{slice: []civil.Time{{Hour: 12}, {}}, isNull: []bool{false, true}, id: mockID, name: "b|time"},
+			{slice: []civil.DateTime{civil.DateTimeOf(d), {}}, isNull: []bool{false, true}, id: mockID, name: "b|civil datetime"},
+			{slice: [][]byte{[]byte("foo"), nil}, isNull: []bool{false, true}, id: mockID, name: "c|bytes"},
+			{slice: []interface{}{d, nil}, isNull: []bool{false, true}, id: mockID, name: "c|interface"},
+			{slice: []interface{}{"foo", 1.5}, isNull: []bool{false, false}, id: mockID, name: "c|interface2"},
+			{slice: newCategories([]string{"foo", ""}, []bool{false, true}), isNull: []bool{false, true}, id: mockID, name: "c|categorical"},
+			{slice: []*big.Rat{mockDecimal("0.1"), new(big.Rat)}, isNull: []bool{false, true}, id: mockID, name: "c|decimal"},
+			{slice: []time.Duration{time.Hour, 0}, isNull: []bool{false, true}, id: mockID, name: "c|duration"},
+		},
+		name:          "baz",
+		colLevelNames: []string{"*0", "qux"},
+	}
+	empty := &DataFrame{
+		labels:        []*valueContainer{{slice: []int{}, isNull: []bool{}, id: mockID, name: "*0"}},
+		values:        []*valueContainer{{slice: []string{}, isNull: []bool{}, cache: []string{}, id: mockID, name: "foo"}},
+		colLevelNames: []string{"*0"},
+	}
+	tests := []struct {
+		name        string
+		compression Compression
+		df          *DataFrame
+		wantErr     bool
+	}{
+		{"pass - every type", NoCompression, df, false},
+		{"pass - compressed", Zstd, df, false},
+		{"pass - no rows", Gzip, empty, false},
+		{"fail - unsupported type", NoCompression,
+			&DataFrame{labels: []*valueContainer{{slice: []complex128{1}, isNull: []bool{false}, name: "*0"}}}, true},
+		{"fail - unsupported compression", Compression(-1), df, true},
+		{"fail - DataFrame error", NoCompression, &DataFrame{err: fmt.Errorf("foo")}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := new(bytes.Buffer)
+			w := NewSnapshotWriter(b)
+			w.Compression = tt.compression
+			err := w.Write(tt.df)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("SnapshotWriter.Write() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				return
+			}
+			got, err := NewSnapshotReader(b).Read()
+			if err != nil {
+				t.Errorf("SnapshotWriter.Write() -> SnapshotReader.Read() error = %v", err)
+				return
+			}
+			if !EqualDataFrames(got, tt.df) {
+				t.Errorf("SnapshotWriter.Write() -> SnapshotReader.Read() = %v, want %v", got, tt.df)
+			}
+		})
+	}
+}
+
+func TestSnapshotReader_Read(t *testing.T) {
+	encode := func(values ...interface{}) []byte {
+		b := new(bytes.Buffer)
+		enc := gob.NewEncoder(b)
+		for _, v := range values {
+			if err := enc.Encode(v); err != nil {
+				t.Fatal(err)
+			}
+		}
+		return b.Bytes()
+	}
+	header := snapshotHeader{Format: snapshotFormat, Version: snapshotVersion, NumLabels: 1}
+	tests := []struct {
+		name string
+		data []byte
+	}{
+		{"fail - not gob", []byte("foo")},
+		{"fail - not a snapshot", encode(snapshotHeader{Format: "foo"})},
+		{"fail - unsupported version", encode(snapshotHeader{Format: snapshotFormat, Version: snapshotVersion + 1})},
+		{"fail - missing container", encode(header)},
+		{"fail - unsupported type", encode(header, snapshotContainer{DType: "[]complex128", Len: 1, IsNull: []bool{false}})},
+		{"fail - missing slice", encode(header, snapshotContainer{DType: "[]int", Len: 1, IsNull: []bool{false}})},
+		{"fail - wrong slice type", encode(header, snapshotContainer{DType: "[]int", Len: 1, IsNull: []bool{false}}, []string{"foo"})},
+		{"fail - wrong length", encode(header, snapshotContainer{DType: "[]int", Len: 2, IsNull: []bool{false}}, []int{1})},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewSnapshotReader(bytes.NewReader(tt.data)).Read()
+			if err == nil {
+				t.Errorf("SnapshotReader.Read() error = nil, want error")
+			}
+		})
+	}
+}
+
+func TestArrowWriter_Write(t *testing.T) {
+	d := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	tz, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Fatal(err)
+	}
+	type fields struct {
+		Stream bool
+	}
+	type args struct {
+		df *DataFrame
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		want    *DataFrame
+		wantErr bool
+	}{
+		{"pass - file",
+			fields{Stream: false},
+			args{&DataFrame{
+				values: []*valueContainer{
+					{slice: []float64{1, 0}, isNull: []bool{false, true}, id: mockID, name: "foo|a"},
+					{slice: []string{"bar", ""}, isNull: []bool{false, true}, id: mockID, name: "baz|b"}},
+				labels:        []*valueContainer{{slice: []int{0, 1}, isNull: []bool{false, false}, id: mockID, name: "*0"}},
+				colLevelNames: []string{"*0", "*1"},
+				name:          "qux"}},
+			&DataFrame{
+				values: []*valueContainer{
+					{slice: []float64{1, 0}, isNull: []bool{false, true}, id: mockID, name: "foo|a"},
+					{slice: []string{"bar", ""}, isNull: []bool{false, true}, id: mockID, name: "baz|b"}},
+				labels:        []*valueContainer{{slice: []int{0, 1}, isNull: []bool{false, false}, id: mockID, name: "*0"}},
+				colLevelNames: []string{"*0", "*1"},
+				name:          "qux"},
+			false,
+		},
+		{"pass - stream",
+			fields{Stream: true},
+			args{&DataFrame{
+				values: []*valueContainer{
+					{slice: []time.Time{d, {}}, isNull: []bool{false, true}, id: mockID, name: "foo"},
+					{slice: []civil.Date{civil.DateOf(d), {}}, isNull: []bool{false, true}, id: mockID, name: "bar"},
+					{slice: []civil.Time{civil.TimeOf(d.Add(time.Hour)), {}}, isNull: []bool{false, true}, id: mockID, name: "baz"},
+					{slice: []bool{true, false}, isNull: []bool{false, false}, id: mockID, name: "qux"}},
+				labels: []*valueContainer{
+					{slice: []string{"a", "b"}, isNull: []bool{false, false}, id: mockID, name: "*0"},
+					{slice: []int64{1, 2}, isNull: []bool{false, false}, id: mockID, name: "corge"}},
+				colLevelNames: []string{"*0"}}},
+			&DataFrame{
+				values: []*valueContainer{
+					{slice: []time.Time{d, {}}, isNull: []bool{false, true}, id: mockID, name: "foo"},
+					{slice: []civil.Date{civil.DateOf(d), {}}, isNull: []bool{false, true}, id: mockID, name: "bar"},
+					{slice: []civil.Time{civil.TimeOf(d.Add(time.Hour)), {}}, isNull: []bool{false, true}, id: mockID, name: "baz"},
+					{slice: []bool{true, false}, isNull: []bool{false, false}, id: mockID, name: "qux"}},
+				labels: []*valueContainer{
+					{slice: []string{"a", "b"}, isNull: []bool{false, false}, id: mockID, name: "*0"},
+					{slice: []int64{1, 2}, isNull: []bool{false, false}, id: mockID, name: "corge"}},
+				colLevelNames: []string{"*0"}},
+			false,
+		},
+		{"pass - time zone preserved",
+			fields{Stream: false},
+			args{&DataFrame{
+				values: []*valueContainer{
+					{slice: []time.Time{d.In(tz), {}}, isNull: []bool{false, true}, id: mockID, name: "foo"},
+					{slice: []time.Time{d, d.In(tz)}, isNull: []bool{false, false}, id: mockID, name: "bar"}},
+				labels:        []*valueContainer{{slice: []int{0, 1}, isNull: []bool{false, false}, id: mockID, name: "*0"}},
+				colLevelNames: []string{"*0"}}},
+			&DataFrame{
+				values: []*valueContainer{
+					{slice: []time.Time{d.In(tz), {}}, isNull: []bool{false, true}, id: mockID, name: "foo"},
+					{slice: []time.Time{d, d}, isNull: []bool{false, false}, id: mockID, name: "bar"}},
+				labels:        []*valueContainer{{slice: []int{0, 1}, isNull: []bool{false, false}, id: mockID, name: "*0"}},
+				colLevelNames: []string{"*0"}},
+			false,
+		},
+		{"pass - decimals",
+			fields{Stream: false},
+			args{&DataFrame{
+				values: []*valueContainer{
+					{slice: []*big.Rat{mockDecimal("0.1"), mockDecimal("-12.345"), new(big.Rat)},
+						isNull: []bool{false, false, true}, id: mockID, name: "foo"}},
+				labels:        []*valueContainer{{slice: []int{0, 1, 2}, isNull: []bool{false, false, false}, id: mockID, name: "*0"}},
+				colLevelNames: []string{"*0"}}},
+			&DataFrame{
+				values: []*valueContainer{
+					{slice: []*big.Rat{mockDecimal("0.1"), mockDecimal("-12.345"), new(big.Rat)},
+						isNull: []bool{false, false, true}, id: mockID, name: "foo"}},
+				labels:        []*valueContainer{{slice: []int{0, 1, 2}, isNull: []bool{false, false, false}, id: mockID, name: "*0"}},
+				colLevelNames: []string{"*0"}},
+			false,
+		},
+		{"pass - slice types and locations restored",
+			fields{Stream: false},
+			args{&DataFrame{
+				values: []*valueContainer{
+					{slice: []time.Time{d.In(tz), {}}, isNull: []bool{false, true}, id: mockID, name: "foo"},
+					{slice: []int32{1, 0}, isNull: []bool{false, true}, id: mockID, name: "bar"},
+					{slice: []uint8{1, 2}, isNull: []bool{false, false}, id: mockID, name: "baz"},
+					{slice: []*big.Rat{mockDecimal("0.1"), new(big.Rat)}, isNull: []bool{false, true}, id: mockID, name: "qux"},
+					{slice: []time.Duration{time.Hour, 0}, isNull: []bool{false, true}, id: mockID, name: "quux"}},
+				labels:        []*valueContainer{{slice: []int{0, 1}, isNull: []bool{false, false}, id: mockID, name: "*0"}},
+				colLevelNames: []string{"*0"}}},
+			&DataFrame{
+				values: []*valueContainer{
+					{slice: []time.Time{d.In(tz), {}}, isNull: []bool{false, true}, id: mockID, name: "foo"},
+					{slice: []int32{1, 0}, isNull: []bool{false, true}, id: mockID, name: "bar"},
+					{slice: []uint8{1, 2}, isNull: []bool{false, false}, id: mockID, name: "baz"},
+					{slice: []*big.Rat{mockDecimal("0.1"), new(big.Rat)}, isNull: []bool{false, true}, id: mockID, name: "qux"},
+					{slice: []time.Duration{time.Hour, 0}, isNull: []bool{false, true}, id: mockID, name: "quux"}},
+				labels:        []*valueContainer{{slice: []int{0, 1}, isNull: []bool{false, false}, id: mockID, name: "*0"}},
+				colLevelNames: []string{"*0"}},
+			false,
+		},
+		{"pass - unsupported type written as string",
+			fields{Stream: false},
+			args{&DataFrame{
+				values: []*valueContainer{
+					{slice: []interface{}{"foo", 1}, isNull: []bool{false, false}, id: mockID, name: "foo"}},
+				labels:        []*valueContainer{{slice: []int{0, 1}, isNull: []bool{false, false}, id: mockID, name: "*0"}},
+				colLevelNames: []string{"*0"}}},
+			&DataFrame{
+				values: []*valueContainer{
+					{slice: []string{"foo", "1"}, isNull: []bool{false, false}, id: mockID, name: "foo"}},
+				labels:        []*valueContainer{{slice: []int{0, 1}, isNull: []bool{false, false}, id: mockID, name: "*0"}},
+				colLevelNames: []string{"*0"}},
+			false,
+		},
+		{"fail - dataframe has error",
+			fields{Stream: false},
+			args{&DataFrame{err: fmt.Errorf("foo")}},
+			nil,
+			true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := new(bytes.Buffer)
+			w := NewArrowWriter(b)
+			w.Stream = tt.fields.Stream
+			if err := w.Write(tt.args.df); (err != nil) != tt.wantErr {
+				t.Errorf("ArrowWriter.Write() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				return
+			}
+			r := NewArrowReader(b)
+			r.Stream = tt.fields.Stream
+			got, err := r.Read()
+			if err != nil {
+				t.Errorf("ArrowWriter.Write() -> ArrowReader.Read() error = %v", err)
+				return
+			}
+			if !EqualDataFrames(got, tt.want) {
+				t.Errorf("ArrowWriter.Write() -> ArrowReader.Read() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestArrowReader_Read(t *testing.T) {
+	d := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	// arrow file written without tada metadata
+	mem := memory.NewGoAllocator()
+	b1 := array.NewInt32Builder(mem)
+	b1.AppendValues([]int32{1, 2}, nil)
+	b2 := array.NewTimestampBuilder(mem, &arrow.TimestampType{Unit: arrow.Second, TimeZone: "UTC"})
+	b2.AppendValues([]arrow.Timestamp{arrow.Timestamp(d.Unix()), 0}, []bool{true, false})
+	b3 := array.NewInt64Builder(mem)
+	b3.AppendValues([]int64{math.MaxInt64, 3}, nil)
+	b4 := array.NewDurationBuilder(mem, &arrow.DurationType{Unit: arrow.Second})
+	b4.AppendValues([]arrow.Duration{90, 0}, []bool{true, false})
+	cols := []array.Interface{b1.NewArray(), b2.NewArray(), b3.NewArray(), b4.NewArray()}
+	schema := arrow.NewSchema([]arrow.Field{
+		{Name: "foo", Type: arrow.PrimitiveTypes.Int32},
+		{Name: "bar", Type: cols[1].DataType(), Nullable: true},
+		{Name: "baz", Type: arrow.PrimitiveTypes.Int64},
+		{Name: "qux", Type: cols[3].DataType(), Nullable: true}}, nil)
+	rec := array.NewRecord(schema, cols, 2)
+	foreign := new(bytes.Buffer)
+	fw, _ := ipc.NewFileWriter(&positionWriter{w: foreign}, ipc.WithSchema(schema))
+	fw.Write(rec)
+	fw.Close()
+
+	type fields struct {
+		Stream      bool
+		LabelLevels int
+		Name        string
+		r           io.Reader
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		want    *DataFrame
+		wantErr bool
+	}{
+		{"pass - no tada metadata",
+			fields{LabelLevels: 1, Name: "baz", r: bytes.NewReader(foreign.Bytes())},
+			&DataFrame{
+				values: []*valueContainer{
+					{slice: []time.Time{d, {}}, isNull: []bool{false, true}, id: mockID, name: "bar"},
+					{slice: []int64{math.MaxInt64, 3}, isNull: []bool{false, false}, id: mockID, name: "baz"},
+					{slice: []time.Duration{90 * time.Second, 0}, isNull: []bool{false, true}, id: mockID, name: "qux"}},
+				labels:        []*valueContainer{{slice: []int{1, 2}, isNull: []bool{false, false}, id: mockID, name: "foo"}},
+				colLevelNames: []string{"*0"},
+				name:          "baz"},
+			false,
+		},
+		{"pass - no tada metadata - not io.ReadSeeker",
+			fields{r: strings.NewReader(foreign.String())},
+			&DataFrame{
+				values: []*valueContainer{
+					{slice: []int{1, 2}, isNull: []bool{false, false}, id: mockID, name: "foo"},
+					{slice: []time.Time{d, {}}, isNull: []bool{false, true}, id: mockID, name: "bar"},
+					{slice: []int64{math.MaxInt64, 3}, isNull: []bool{false, false}, id: mockID, name: "baz"},
+					{slice: []time.Duration{90 * time.Second, 0}, isNull: []bool{false, true}, id: mockID, name: "qux"}},
+				labels:        []*valueContainer{{slice: []int{0, 1}, isNull: []bool{false, false}, id: mockID, name: "*0"}},
+				colLevelNames: []string{"*0"}},
+			false,
+		},
+		{"fail - too many label levels",
+			fields{LabelLevels: 5, r: bytes.NewReader(foreign.Bytes())},
+			nil,
+			true,
+		},
+		{"fail - not arrow file",
+			fields{r: strings.NewReader("foo")},
+			nil,
+			true,
+		},
+		{"fail - not arrow stream",
+			fields{Stream: true, r: strings.NewReader("foo")},
+			nil,
+			true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := ArrowReader{
+				Stream:      tt.fields.Stream,
+				LabelLevels: tt.fields.LabelLevels,
+				Name:        tt.fields.Name,
+				r:           tt.fields.r,
+			}
+			got, err := r.Read()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ArrowReader.Read() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !EqualDataFrames(got, tt.want) {
+				t.Errorf("ArrowReader.Read() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParquetWriter_Write(t *testing.T) {
+	d := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	tz, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name    string
+		df      *DataFrame
+		want    *DataFrame
+		wantErr bool
+	}{
+		{"pass - multi-level names",
+			&DataFrame{
+				values: []*valueContainer{
+					{slice: []float64{1, 0}, isNull: []bool{false, true}, id: mockID, name: "foo|a"},
+					{slice: []string{"bar", ""}, isNull: []bool{false, true}, id: mockID, name: "baz|b"}},
+				labels:        []*valueContainer{{slice: []int{0, 1}, isNull: []bool{false, false}, id: mockID, name: "*0"}},
+				colLevelNames: []string{"*0", "*1"},
+				name:          "qux"},
+			&DataFrame{
+				values: []*valueContainer{
+					{slice: []float64{1, 0}, isNull: []bool{false, true}, id: mockID, name: "foo|a"},
+					{slice: []string{"bar", ""}, isNull: []bool{false, true}, id: mockID, name: "baz|b"}},
+				labels:        []*valueContainer{{slice: []int{0, 1}, isNull: []bool{false, false}, id: mockID, name: "*0"}},
+				colLevelNames: []string{"*0", "*1"},
+				name:          "qux"},
+			false,
+		},
+		{"pass - time types and multiple labels",
+			&DataFrame{
+				values: []*valueContainer{
+					{slice: []time.Time{d, {}}, isNull: []bool{false, true}, id: mockID, name: "foo"},
+					{slice: []civil.Date{civil.DateOf(d), {}}, isNull: []bool{false, true}, id: mockID, name: "bar"},
+					{slice: []civil.Time{civil.TimeOf(d.Add(time.Hour)), {}}, isNull: []bool{false, true}, id: mockID, name: "baz"},
+					{slice: []bool{true, false}, isNull: []bool{false, false}, id: mockID, name: "qux"}},
+				labels: []*valueContainer{
+					{slice: []string{"a", "b"}, isNull: []bool{false, false}, id: mockID, name: "*0"},
+					{slice: []int64{1, 2}, isNull: []bool{false, false}, id: mockID, name: "corge"}},
+				colLevelNames: []string{"*0"}},
+			&DataFrame{
+				values: []*valueContainer{
+					{slice: []time.Time{d, {}}, isNull: []bool{false, true}, id: mockID, name: "foo"},
+					{slice: []civil.Date{civil.DateOf(d), {}}, isNull: []bool{false, true}, id: mockID, name: "bar"},
+					{slice: []civil.Time{civil.TimeOf(d.Add(time.Hour)), {}}, isNull: []bool{false, true}, id: mockID, name: "baz"},
+					{slice: []bool{true, false}, isNull: []bool{false, false}, id: mockID, name: "qux"}},
+				labels: []*valueContainer{
+					{slice: []string{"a", "b"}, isNull: []bool{false, false}, id: mockID, name: "*0"},
+					{slice: []int64{1, 2}, isNull: []bool{false, false}, id: mockID, name: "corge"}},
+				colLevelNames: []string{"*0"}},
+			false,
+		},
+		{"pass - slice types and locations restored",
+			&DataFrame{
+				values: []*valueContainer{
+					{slice: []time.Time{d.In(tz), {}}, isNull: []bool{false, true}, id: mockID, name: "foo"},
+					{slice: []int32{1, 0}, isNull: []bool{false, true}, id: mockID, name: "bar"},
+					{slice: []uint8{1, 2}, isNull: []bool{false, false}, id: mockID, name: "baz"},
+					{slice: []*big.Rat{mockDecimal("0.1"), new(big.Rat)}, isNull: []bool{false, true}, id: mockID, name: "qux"},
+					{slice: []time.Duration{time.Hour, 0}, isNull: []bool{false, true}, id: mockID, name: "quux"}},
+				labels:        []*valueContainer{{slice: []int{0, 1}, isNull: []bool{false, false}, id: mockID, name: "*0"}},
+				colLevelNames: []string{"*0"}},
+			&DataFrame{
+				values: []*valueContainer{
+					{slice: []time.Time{d.In(tz), {}}, isNull: []bool{false, true}, id: mockID, name: "foo"},
+					{slice: []int32{1, 0}, isNull: []bool{false, true}, id: mockID, name: "bar"},
+					{slice: []uint8{1, 2}, isNull: []bool{false, false}, id: mockID, name: "baz"},
+					{slice: []*big.Rat{mockDecimal("0.1"), new(big.Rat)}, isNull: []bool{false, true}, id: mockID, name: "qux"},
+					{slice: []time.Duration{time.Hour, 0}, isNull: []bool{false, true}, id: mockID, name: "quux"}},
+				labels:        []*valueContainer{{slice: []int{0, 1}, isNull: []bool{false, false}, id: mockID, name: "*0"}},
+				colLevelNames: []string{"*0"}},
+			false,
+		},
+		{"pass - decimals",
+			&DataFrame{
+				values: []*valueContainer{
+					{slice: []*big.Rat{mockDecimal("0.1"), mockDecimal("-12.345"), new(big.Rat)},
+						isNull: []bool{false, false, true}, id: mockID, name: "foo"},
+					{slice: []*big.Rat{mockDecimal("1e-40"), new(big.Rat), new(big.Rat)},
+						isNull: []bool{false, true, true}, id: mockID, name: "bar"}},
+				labels:        []*valueContainer{{slice: []int{0, 1, 2}, isNull: []bool{false, false, false}, id: mockID, name: "*0"}},
+				colLevelNames: []string{"*0"}},
+			&DataFrame{
+				values: []*valueContainer{
+					{slice: []*big.Rat{mockDecimal("0.1"), mockDecimal("-12.345"), new(big.Rat)},
+						isNull: []bool{false, false, true}, id: mockID, name: "foo"},
+					{slice: []*big.Rat{mockDecimal("1e-40"), new(big.Rat), new(big.Rat)},
+						isNull: []bool{false, true, true}, id: mockID, name: "bar"}},
+				labels:        []*valueContainer{{slice: []int{0, 1, 2}, isNull: []bool{false, false, false}, id: mockID, name: "*0"}},
+				colLevelNames: []string{"*0"}},
+			false,
+		},
+		{"pass - unsupported type written as string",
+			&DataFrame{
+				values: []*valueContainer{
+					{slice: []interface{}{"foo", 1}, isNull: []bool{false, false}, id: mockID, name: "foo"}},
+				labels:        []*valueContainer{{slice: []int{0, 1}, isNull: []bool{false, false}, id: mockID, name: "*0"}},
+				colLevelNames: []string{"*0"}},
+			&DataFrame{
+				values: []*valueContainer{
+					{slice: []string{"foo", "1"}, isNull: []bool{false, false}, id: mockID, name: "foo"}},
+				labels:        []*valueContainer{{slice: []int{0, 1}, isNull: []bool{false, false}, id: mockID, name: "*0"}},
+				colLevelNames: []string{"*0"}},
+			false,
+		},
+		{"fail - dataframe has error",
+			&DataFrame{err: fmt.Errorf("foo")},
+			nil,
+			true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := new(bytes.Buffer)
+			w := NewParquetWriter(b)
+			if err := w.Write(tt.df); (err != nil) != tt.wantErr {
+				t.Errorf("ParquetWriter.Write() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				return
+			}
+			got, err := NewParquetReader(bytes.NewReader(b.Bytes())).Read()
+			if err != nil {
+				t.Errorf("ParquetWriter.Write() -> ParquetReader.Read() error = %v", err)
+				return
+			}
+			if !EqualDataFrames(got, tt.want) {
+				t.Errorf("ParquetWriter.Write() -> ParquetReader.Read() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParquetReader_Read(t *testing.T) {
+	df := &DataFrame{
+		values: []*valueContainer{
+			{slice: []float64{1, 2}, isNull: []bool{false, false}, id: mockID, name: "foo"},
+			{slice: []string{"a", ""}, isNull: []bool{false, true}, id: mockID, name: "bar"},
+			{slice: []int32{1, 2}, isNull: []bool{false, false}, id: mockID, name: "quux"}},
+		labels:        []*valueContainer{{slice: []int{10, 11}, isNull: []bool{false, false}, id: mockID, name: "baz"}},
+		colLevelNames: []string{"*0"},
+		name:          "qux"}
+	b := new(bytes.Buffer)
+	NewParquetWriter(b).Write(df)
+	// parquet file written without tada metadata
+	sd, err := parquetschema.ParseSchemaDefinition(`message test { required int64 foo; required int32 bar; optional int64 baz (DECIMAL(10,2)); }`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	foreign := new(bytes.Buffer)
+	fw := goparquet.NewFileWriter(foreign, goparquet.WithSchemaDefinition(sd))
+	fw.AddData(map[string]interface{}{"foo": int64(math.MaxInt64), "bar": int32(1), "baz": int64(-12345)})
+	fw.AddData(map[string]interface{}{"foo": int64(3), "bar": int32(2)})
+	fw.Close()
+
+	type fields struct {
+		Columns     []string
+		LabelLevels int
+		Name        string
+		r           io.ReadSeeker
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		want    *DataFrame
+		wantErr bool
+	}{
+		{"pass - all columns",
+			fields{r: bytes.NewReader(b.Bytes())},
+			&DataFrame{
+				values: []*valueContainer{
+					{slice: []float64{1, 2}, isNull: []bool{false, false}, id: mockID, name: "foo"},
+					{slice: []string{"a", ""}, isNull: []bool{false, true}, id: mockID, name: "bar"},
+					{slice: []int32{1, 2}, isNull: []bool{false, false}, id: mockID, name: "quux"}},
+				labels:        []*valueContainer{{slice: []int{10, 11}, isNull: []bool{false, false}, id: mockID, name: "baz"}},
+				colLevelNames: []string{"*0"},
+				name:          "qux"},
+			false,
+		},
+		{"pass - projection keeps labels",
+			fields{Columns: []string{"baz", "bar"}, Name: "corge", r: bytes.NewReader(b.Bytes())},
+			&DataFrame{
+				values: []*valueContainer{
+					{slice: []string{"a", ""}, isNull: []bool{false, true}, id: mockID, name: "bar"}},
+				labels:        []*valueContainer{{slice: []int{10, 11}, isNull: []bool{false, false}, id: mockID, name: "baz"}},
+				colLevelNames: []string{"*0"},
+				name:          "corge"},
+			false,
+		},
+		{"pass - projection drops labels",
+			fields{Columns: []string{"foo"}, r: bytes.NewReader(b.Bytes())},
+			&DataFrame{
+				values: []*valueContainer{
+					{slice: []float64{1, 2}, isNull: []bool{false, false}, id: mockID, name: "foo"}},
+				labels:        []*valueContainer{{slice: []int{0, 1}, isNull: []bool{false, false}, id: mockID, name: "*0"}},
+				colLevelNames: []string{"*0"},
+				name:          "qux"},
+			false,
+		},
+		{"pass - projection restores slice types",
+			fields{Columns: []string{"quux"}, r: bytes.NewReader(b.Bytes())},
+			&DataFrame{
+				values: []*valueContainer{
+					{slice: []int32{1, 2}, isNull: []bool{false, false}, id: mockID, name: "quux"}},
+				labels:        []*valueContainer{{slice: []int{0, 1}, isNull: []bool{false, false}, id: mockID, name: "*0"}},
+				colLevelNames: []string{"*0"},
+				name:          "qux"},
+			false,
+		},
+		{"pass - no tada metadata",
+			fields{r: bytes.NewReader(foreign.Bytes())},
+			&DataFrame{
+				values: []*valueContainer{
+					{slice: []int64{math.MaxInt64, 3}, isNull: []bool{false, false}, id: mockID, name: "foo"},
+					{slice: []int{1, 2}, isNull: []bool{false, false}, id: mockID, name: "bar"},
+					{slice: []*big.Rat{mockDecimal("-123.45"), new(big.Rat)}, isNull: []bool{false, true}, id: mockID, name: "baz"}},
+				labels:        []*valueContainer{{slice: []int{0, 1}, isNull: []bool{false, false}, id: mockID, name: "*0"}},
+				colLevelNames: []string{"*0"}},
+			false,
+		},
+		{"fail - column not in file",
+			fields{Columns: []string{"corge"}, r: bytes.NewReader(b.Bytes())},
+			nil,
+			true,
+		},
+		{"fail - not parquet file",
+			fields{r: strings.NewReader("foo")},
+			nil,
+			true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := ParquetReader{
+				Columns:     tt.fields.Columns,
+				LabelLevels: tt.fields.LabelLevels,
+				Name:        tt.fields.Name,
+				r:           tt.fields.r,
+			}
+			got, err := r.Read()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ParquetReader.Read() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !EqualDataFrames(got, tt.want) {
+				t.Errorf("ParquetReader.Read() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestJSONLinesReader_Read(t *testing.T) {
+	type fields struct {
+		LabelLevels int
+		Name        string
+		InferTypes  bool
+		r           io.Reader
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		want    *DataFrame
+		wantErr bool
+	}{
+		{"pass - union of keys",
+			fields{InferTypes: true, r: strings.NewReader(
+				`{"foo": 1, "bar": "a"}` + "\n\n" + `{"foo": null, "baz": [1, 2]}` + "\n" + `{"foo": 2}` + "\n")},
+			&DataFrame{
+				values: []*valueContainer{
+					{slice: []float64{1, 0, 2}, isNull: []bool{false, true, false}, id: mockID, name: "foo", cache: []string{"1", "", "2"}},
+					{slice: []string{"a", "", ""}, isNull: []bool{false, true, true}, id: mockID, name: "bar", cache: []string{"a", "", ""}},
+					{slice: []string{"", "[1,2]", ""}, isNull: []bool{true, false, true}, id: mockID, name: "baz", cache: []string{"", "[1,2]", ""}}},
+				labels:        []*valueContainer{{slice: []int{0, 1, 2}, isNull: []bool{false, false, false}, id: mockID, name: "*0"}},
+				colLevelNames: []string{"*0"}},
+			false,
+		},
+		{"pass - nested objects",
+			fields{InferTypes: false, r: strings.NewReader(
+				`{"foo": {"bar": 1, "baz": true}, "qux": "a"}` + "\n" + `{"foo": {"bar": 2}}`)},
+			&DataFrame{
+				values: []*valueContainer{
+					{slice: []string{"1", "2"}, isNull: []bool{false, false}, id: mockID, name: "foo|bar"},
+					{slice: []string{"true", ""}, isNull: []bool{false, true}, id: mockID, name: "foo|baz"},
+					{slice: []string{"a", ""}, isNull: []bool{false, true}, id: mockID, name: "qux|"}},
+				labels:        []*valueContainer{{slice: []int{0, 1}, isNull: []bool{false, false}, id: mockID, name: "*0"}},
+				colLevelNames: []string{"*0", "*1"}},
+			false,
+		},
+		{"pass - labels and name",
+			fields{LabelLevels: 1, Name: "corge", InferTypes: true, r: strings.NewReader(
+				`{"foo": "a", "bar": 1}` + "\n" + `{"foo": "b", "bar": 2}`)},
+			&DataFrame{
+				values:        []*valueContainer{{slice: []float64{1, 2}, isNull: []bool{false, false}, id: mockID, name: "bar", cache: []string{"1", "2"}}},
+				labels:        []*valueContainer{{slice: []string{"a", "b"}, isNull: []bool{false, false}, id: mockID, name: "foo", cache: []string{"a", "b"}}},
+				colLevelNames: []string{"*0"},
+				name:          "corge"},
+			false,
+		},
+		{"fail - invalid json",
+			fields{r: strings.NewReader(`{"foo": 1`)},
+			nil,
+			true,
+		},
+		{"fail - not an object",
+			fields{r: strings.NewReader(`[1, 2]`)},
+			nil,
+			true,
+		},
+		{"fail - multiple values on one line",
+			fields{r: strings.NewReader(`{"foo": 1} {"foo": 2}`)},
+			nil,
+			true,
+		},
+		{"fail - no rows",
+			fields{r: strings.NewReader("\n")},
+			nil,
+			true,
+		},
+		{"fail - too many label levels",
+			fields{LabelLevels: 2, r: strings.NewReader(`{"foo": 1}`)},
+			nil,
+			true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := JSONLinesReader{
+				LabelLevels: tt.fields.LabelLevels,
+				Name:        tt.fields.Name,
+				InferTypes:  tt.fields.InferTypes,
+				r:           tt.fields.r,
+			}
+			got, err := r.Read()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("JSONLinesReader.Read() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !EqualDataFrames(got, tt.want) {
+				t.Errorf("JSONLinesReader.Read() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestJSONLinesWriter_Write(t *testing.T) {
+	d := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	type fields struct {
+		IncludeLabels bool
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		df      *DataFrame
+		want    string
+		wantErr bool
+	}{
+		{"pass",
+			fields{IncludeLabels: false},
+			&DataFrame{
+				values: []*valueContainer{
+					{slice: []float64{1, 0}, isNull: []bool{false, true}, id: mockID, name: "foo"},
+					{slice: []time.Time{d, d}, isNull: []bool{false, false}, id: mockID, name: "bar"},
+					{slice: []bool{true, false}, isNull: []bool{false, false}, id: mockID, name: "baz"}},
+				labels:        []*valueContainer{{slice: []int{0, 1}, isNull: []bool{false, false}, id: mockID, name: "*0"}},
+				colLevelNames: []string{"*0"}},
+			`{"foo":1,"bar":"2020-01-01T00:00:00Z","baz":true}` + "\n" +
+				`{"foo":null,"bar":"2020-01-01T00:00:00Z","baz":false}` + "\n",
+			false,
+		},
+		{"pass - decimal as exact number",
+			fields{IncludeLabels: false},
+			&DataFrame{
+				values: []*valueContainer{
+					{slice: []*big.Rat{mockDecimal("90071992547409.93"), big.NewRat(1, 3), nil}, isNull: []bool{false, false, true}, id: mockID, name: "foo"}},
+				labels:        []*valueContainer{{slice: []int{0, 1, 2}, isNull: []bool{false, false, false}, id: mockID, name: "*0"}},
+				colLevelNames: []string{"*0"}},
+			`{"foo":90071992547409.93}` + "\n" + `{"foo":0.3333333333333333}` + "\n" + `{"foo":null}` + "\n",
+			false,
+		},
+		{"pass - nested with labels",
+			fields{IncludeLabels: true},
+			&DataFrame{
+				values: []*valueContainer{
+					{slice: []string{"a"}, isNull: []bool{false}, id: mockID, name: "foo|bar"},
+					{slice: []civil.Date{civil.DateOf(d)}, isNull: []bool{false}, id: mockID, name: "foo|baz"},
+					{slice: []int{1}, isNull: []bool{false}, id: mockID, name: "qux|"}},
+				labels:        []*valueContainer{{slice: []int{0}, isNull: []bool{false}, id: mockID, name: "*0"}},
+				colLevelNames: []string{"*0", "*1"}},
+			`{"*0":0,"foo":{"bar":"a","baz":"2020-01-01"},"qux":1}` + "\n",
+			false,
+		},
+		{"fail - conflicting names",
+			fields{IncludeLabels: false},
+			&DataFrame{
+				values: []*valueContainer{
+					{slice: []string{"a"}, isNull: []bool{false}, id: mockID, name: "foo|"},
+					{slice: []string{"b"}, isNull: []bool{false}, id: mockID, name: "foo|bar"}},
+				labels:        []*valueContainer{{slice: []int{0}, isNull: []bool{false}, id: mockID, name: "*0"}},
+				colLevelNames: []string{"*0", "*1"}},
+			"",
+			true,
+		},
+		{"fail - dataframe has error",
+			fields{IncludeLabels: false},
+			&DataFrame{err: fmt.Errorf("foo")},
+			"",
+			true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := new(bytes.Buffer)
+			w := NewJSONLinesWriter(b)
+			w.IncludeLabels = tt.fields.IncludeLabels
+			if err := w.Write(tt.df); (err != nil) != tt.wantErr {
+				t.Errorf("JSONLinesWriter.Write() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got := b.String(); got != tt.want {
+				t.Errorf("JSONLinesWriter.Write() -> %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestJSONWriter_Write(t *testing.T) {
+	d := time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+	df := &DataFrame{
+		values: []*valueContainer{
+			{slice: []float64{1, 0}, isNull: []bool{false, true}, id: mockID, name: "foo|a"},
+			{slice: []time.Time{d, d}, isNull: []bool{false, false}, id: mockID, name: "foo|b"}},
+		labels:        []*valueContainer{{slice: []string{"x", "y"}, isNull: []bool{false, false}, id: mockID, name: "*0"}},
+		colLevelNames: []string{"*0", "*1"},
+		name:          "baz"}
+	type fields struct {
+		Orientation   JSONOrientation
+		IncludeLabels bool
+		TimeLayout    string
+		NestLevels    bool
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		df      *DataFrame
+		want    string
+		wantErr bool
+	}{
+		{"records",
+			fields{Orientation: JSONRecords, TimeLayout: time.RFC3339},
+			df,
+			`[{"foo|a":1,"foo|b":"2020-01-02T03:04:05Z"},{"foo|a":null,"foo|b":"2020-01-02T03:04:05Z"}]`,
+			false,
+		},
+		{"records - nested with labels",
+			fields{Orientation: JSONRecords, IncludeLabels: true, TimeLayout: "2006-01-02", NestLevels: true},
+			df,
+			`[{"*0":"x","foo":{"a":1,"b":"2020-01-02"}},{"*0":"y","foo":{"a":null,"b":"2020-01-02"}}]`,
+			false,
+		},
+		{"columns",
+			fields{Orientation: JSONColumns, TimeLayout: "2006-01-02"},
+			df,
+			`{"foo|a":[1,null],"foo|b":["2020-01-02","2020-01-02"]}`,
+			false,
+		},
+		{"columns - nested",
+			fields{Orientation: JSONColumns, TimeLayout: "2006-01-02", NestLevels: true},
+			df,
+			`{"foo":{"a":[1,null],"b":["2020-01-02","2020-01-02"]}}`,
+			false,
+		},
+		{"split",
+			fields{Orientation: JSONSplit, TimeLayout: "2006-01-02"},
+			df,
+			`{"name":"baz","labelNames":["*0"],"columns":["foo|a","foo|b"],"labels":[["x"],["y"]],` +
+				`"data":[[1,"2020-01-02"],[null,"2020-01-02"]]}`,
+			false,
+		},
+		{"split - nested",
+			fields{Orientation: JSONSplit, TimeLayout: "2006-01-02", NestLevels: true},
+			df,
+			`{"name":"baz","labelNames":["*0"],"columns":[["foo","a"],["foo","b"]],"labels":[["x"],["y"]],` +
+				`"data":[[1,"2020-01-02"],[null,"2020-01-02"]]}`,
+			false,
+		},
+		{"index",
+			fields{Orientation: JSONIndex, TimeLayout: "2006-01-02", NestLevels: true},
+			df,
+			`{"x":{"foo":{"a":1,"b":"2020-01-02"}},"y":{"foo":{"a":null,"b":"2020-01-02"}}}`,
+			false,
+		},
+		{"fail - index - duplicate labels",
+			fields{Orientation: JSONIndex},
+			&DataFrame{
+				values:        []*valueContainer{{slice: []float64{1, 2}, isNull: []bool{false, false}, id: mockID, name: "foo"}},
+				labels:        []*valueContainer{{slice: []string{"x", "x"}, isNull: []bool{false, false}, id: mockID, name: "*0"}},
+				colLevelNames: []string{"*0"}},
+			"",
+			true,
+		},
+		{"fail - unsupported orientation",
+			fields{Orientation: 100},
+			df,
+			"",
+			true,
+		},
+		{"fail - dataframe has error",
+			fields{Orientation: JSONRecords},
+			&DataFrame{err: fmt.Errorf("foo")},
+			"",
+			true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := new(bytes.Buffer)
+			w := &JSONWriter{
+				Orientation:   tt.fields.Orientation,
+				IncludeLabels: tt.fields.IncludeLabels,
+				TimeLayout:    tt.fields.TimeLayout,
+				NestLevels:    tt.fields.NestLevels,
+				w:             b,
+			}
+			if err := w.Write(tt.df); (err != nil) != tt.wantErr {
+				t.Errorf("JSONWriter.Write() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				return
+			}
+			if got := b.String(); got != tt.want {
+				t.Errorf("JSONWriter.Write() -> %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestJSONReader_Read(t *testing.T) {
+	type fields struct {
+		Orientation JSONOrientation
+		LabelLevels int
+		Name        string
+		InferTypes  bool
+		r           io.Reader
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		want    *DataFrame
+		wantErr bool
+	}{
+		{"records",
+			fields{Orientation: JSONRecords, LabelLevels: 1, r: strings.NewReader(
+				`[{"*0":"x","foo":{"a":1}},{"*0":"y","foo":{"a":null}}]`)},
+			&DataFrame{
+				values:        []*valueContainer{{slice: []string{"1", ""}, isNull: []bool{false, true}, id: mockID, name: "foo|a"}},
+				labels:        []*valueContainer{{slice: []string{"x", "y"}, isNull: []bool{false, false}, id: mockID, name: "*0"}},
+				colLevelNames: []string{"*0", "*1"}},
+			false,
+		},
+		{"columns",
+			fields{Orientation: JSONColumns, Name: "baz", r: strings.NewReader(
+				`{"foo":{"a":[1,null]},"bar":["x","y"]}`)},
+			&DataFrame{
+				values: []*valueContainer{
+					{slice: []string{"1", ""}, isNull: []bool{false, true}, id: mockID, name: "foo|a"},
+					{slice: []string{"x", "y"}, isNull: []bool{false, false}, id: mockID, name: "bar|"}},
+				labels:        []*valueContainer{{slice: []int{0, 1}, isNull: []bool{false, false}, id: mockID, name: "*0"}},
+				colLevelNames: []string{"*0", "*1"},
+				name:          "baz"},
+			false,
+		},
+		{"split",
+			fields{Orientation: JSONSplit, r: strings.NewReader(
+				`{"name":"baz","labelNames":["qux"],"columns":[["foo","a"],"foo|b"],"labels":[["x"],["y"]],` +
+					`"data":[[1,"2020-01-02"],[null,"2020-01-02"]]}`)},
+			&DataFrame{
+				values: []*valueContainer{
+					{slice: []string{"1", ""}, isNull: []bool{false, true}, id: mockID, name: "foo|a"},
+					{slice: []string{"2020-01-02", "2020-01-02"}, isNull: []bool{false, false}, id: mockID, name: "foo|b"}},
+				labels:        []*valueContainer{{slice: []string{"x", "y"}, isNull: []bool{false, false}, id: mockID, name: "qux"}},
+				colLevelNames: []string{"*0", "*1"},
+				name:          "baz"},
+			false,
+		},
+		{"index",
+			fields{Orientation: JSONIndex, r: strings.NewReader(
+				`{"x|1":{"foo":1},"y|2":{"foo":null,"bar":"z"}}`)},
+			&DataFrame{
+				values: []*valueContainer{
+					{slice: []string{"1", ""}, isNull: []bool{false, true}, id: mockID, name: "foo"},
+					{slice: []string{"", "z"}, isNull: []bool{true, false}, id: mockID, name: "bar"}},
+				labels: []*valueContainer{
+					{slice: []string{"x", "y"}, isNull: []bool{false, false}, id: mockID, name: "*0"},
+					{slice: []string{"1", "2"}, isNull: []bool{false, false}, id: mockID, name: "*1"}},
+				colLevelNames: []string{"*0"}},
+			false,
+		},
+		{"fail - records - not an array",
+			fields{Orientation: JSONRecords, r: strings.NewReader(`{"foo": 1}`)},
+			nil,
+			true,
+		},
+		{"fail - records - too many label levels",
+			fields{Orientation: JSONRecords, LabelLevels: 2, r: strings.NewReader(`[{"foo": 1}]`)},
+			nil,
+			true,
+		},
+		{"fail - columns - mismatched lengths",
+			fields{Orientation: JSONColumns, r: strings.NewReader(`{"foo": [1], "bar": [1, 2]}`)},
+			nil,
+			true,
+		},
+		{"fail - columns - not an array",
+			fields{Orientation: JSONColumns, r: strings.NewReader(`{"foo": 1}`)},
+			nil,
+			true,
+		},
+		{"fail - split - mismatched rows",
+			fields{Orientation: JSONSplit, r: strings.NewReader(`{"columns":["foo"],"labels":[],"data":[[1]]}`)},
+			nil,
+			true,
+		},
+		{"fail - split - mismatched columns",
+			fields{Orientation: JSONSplit, r: strings.NewReader(`{"labelNames":["*0"],"columns":["foo"],"labels":[[1]],"data":[[1,2]]}`)},
+			nil,
+			true,
+		},
+		{"fail - index - mismatched levels",
+			fields{Orientation: JSONIndex, r: strings.NewReader(`{"x":{"foo":1},"y|z":{"foo":2}}`)},
+			nil,
+			true,
+		},
+		{"fail - unsupported orientation",
+			fields{Orientation: 100, r: strings.NewReader(`{}`)},
+			nil,
+			true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := JSONReader{
+				Orientation: tt.fields.Orientation,
+				LabelLevels: tt.fields.LabelLevels,
+				Name:        tt.fields.Name,
+				InferTypes:  tt.fields.InferTypes,
+				r:           tt.fields.r,
+			}
+			got, err := r.Read()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("JSONReader.Read() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !EqualDataFrames(got, tt.want) {
+				t.Errorf("JSONReader.Read() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestJSONWriter_Write_roundTrip(t *testing.T) {
+	d := time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+	want := &DataFrame{
+		values: []*valueContainer{
+			{slice: []string{"1", ""}, isNull: []bool{false, true}, id: mockID, name: "foo|a"},
+			{slice: []string{"2020-01-02T03:04:05Z", "2020-01-02T03:04:05Z"}, isNull: []bool{false, false}, id: mockID, name: "foo|b"}},
+		labels:        []*valueContainer{{slice: []string{"x", "y"}, isNull: []bool{false, false}, id: mockID, name: "*0"}},
+		colLevelNames: []string{"*0", "*1"}}
+	for _, orientation := range []JSONOrientation{JSONRecords, JSONColumns, JSONSplit, JSONIndex} {
+		df := &DataFrame{
+			values: []*valueContainer{
+				{slice: []float64{1, 0}, isNull: []bool{false, true}, id: mockID, name: "foo|a"},
+				{slice: []time.Time{d, d}, isNull: []bool{false, false}, id: mockID, name: "foo|b"}},
+			labels:        []*valueContainer{{slice: []string{"x", "y"}, isNull: []bool{false, false}, id: mockID, name: "*0"}},
+			colLevelNames: []string{"*0", "*1"}}
+		b := new(bytes.Buffer)
+		w := NewJSONWriter(b)
+		w.Orientation = orientation
+		w.IncludeLabels = true
+		w.NestLevels = true
+		err := w.Write(df)
+		if err != nil {
+			t.Errorf("JSONWriter.Write() [orientation %v] error = %v", orientation, err)
+			continue
+		}
+		r := NewJSONReader(b)
+		r.Orientation = orientation
+		r.LabelLevels = 1
+		r.InferTypes = false
+		got, err := r.Read()
+		if err != nil {
+			t.Errorf("JSONWriter.Write() -> JSONReader.Read() [orientation %v] error = %v", orientation, err)
+			continue
+		}
+		if !EqualDataFrames(got, want) {
+			t.Errorf("JSONWriter.Write() -> JSONReader.Read() [orientation %v] = %v, want %v", orientation, got, want)
+		}
+	}
+}
+
+func TestSQLRowsReader_Read(t *testing.T) {
+	d := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	typed := fakeTable{
+		columns: []string{"foo", "bar", "baz", "qux", "corge"},
+		scanTypes: []reflect.Type{
+			reflect.TypeOf(sql.NullString{}), reflect.TypeOf(float64(0)), reflect.TypeOf(int64(0)),
+			reflect.TypeOf(sql.NullBool{}), reflect.TypeOf(time.Time{})},
+		rows: [][]driver.Value{
+			{"a", 1.5, int64(1), true, d},
+			{"b", nil, nil, nil, nil}},
+	}
+	untyped := fakeTable{
+		columns: []string{"foo", "bar", "baz", "qux"},
+		dbTypes: []string{"INTEGER", "real", "TEXT", "BOOLEAN"},
+		rows: [][]driver.Value{
+			{int64(1), 1.5, []byte("a"), true},
+			{nil, 2.5, "b", false}},
+	}
+	type fields struct {
+		LabelNames []string
+		Name       string
+		table      fakeTable
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		want    *DataFrame
+		wantErr bool
+	}{
+		{"pass - scan types",
+			fields{LabelNames: []string{"foo"}, Name: "grault", table: typed},
+			&DataFrame{
+				values: []*valueContainer{
+					{slice: []float64{1.5, 0}, isNull: []bool{false, true}, id: mockID, name: "bar"},
+					{slice: []int{1, 0}, isNull: []bool{false, true}, id: mockID, name: "baz"},
+					{slice: []bool{true, false}, isNull: []bool{false, true}, id: mockID, name: "qux"},
+					{slice: []time.Time{d, {}}, isNull: []bool{false, true}, id: mockID, name: "corge"}},
+				labels:        []*valueContainer{{slice: []string{"a", "b"}, isNull: []bool{false, false}, id: mockID, name: "foo"}},
+				colLevelNames: []string{"*0"},
+				name:          "grault"},
+			false,
+		},
+		{"pass - database type names",
+			fields{table: untyped},
+			&DataFrame{
+				values: []*valueContainer{
+					{slice: []int{1, 0}, isNull: []bool{false, true}, id: mockID, name: "foo"},
+					{slice: []float64{1.5, 2.5}, isNull: []bool{false, false}, id: mockID, name: "bar"},
+					{slice: []string{"a", "b"}, isNull: []bool{false, false}, id: mockID, name: "baz"},
+					{slice: []bool{true, false}, isNull: []bool{false, false}, id: mockID, name: "qux"}},
+				labels:        []*valueContainer{{slice: []int{0, 1}, isNull: []bool{false, false}, id: mockID, name: "*0"}},
+				colLevelNames: []string{"*0"}},
+			false,
+		},
+		{"pass - no rows",
+			fields{table: fakeTable{columns: []string{"foo"}, dbTypes: []string{"REAL"}}},
+			&DataFrame{
+				values:        []*valueContainer{{slice: []float64{}, isNull: []bool{}, id: mockID, name: "foo"}},
+				labels:        []*valueContainer{{slice: []int{}, isNull: []bool{}, id: mockID, name: "*0"}},
+				colLevelNames: []string{"*0"}},
+			false,
+		},
+		{"fail - label not found",
+			fields{LabelNames: []string{"quux"}, table: typed},
+			nil,
+			true,
+		},
+		{"fail - no columns besides labels",
+			fields{LabelNames: []string{"foo"}, table: fakeTable{columns: []string{"foo"}, rows: [][]driver.Value{{"a"}}}},
+			nil,
+			true,
+		},
+		{"fail - scan error",
+			fields{table: fakeTable{columns: []string{"foo"}, dbTypes: []string{"INTEGER"}, rows: [][]driver.Value{{"a"}}}},
+			nil,
+			true,
+		},
+		{"fail - rows error",
+			fields{table: fakeTable{columns: []string{"foo"}, rows: [][]driver.Value{{"a"}}, err: fmt.Errorf("foo")}},
+			nil,
+			true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db := openFakeDB(tt.name, tt.fields.table)
+			defer db.Close()
+			rows, err := db.Query("SELECT * FROM foo")
+			if err != nil {
+				t.Errorf("sql.DB.Query() error = %v", err)
+				return
+			}
+			r := NewSQLRowsReader(rows)
+			r.LabelNames = tt.fields.LabelNames
+			r.Name = tt.fields.Name
+			got, err := r.Read()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("SQLRowsReader.Read() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !EqualDataFrames(got, tt.want) {
+				t.Errorf("SQLRowsReader.Read() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSQLWriter_Write(t *testing.T) {
+	d := time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+	df := &DataFrame{
+		values: []*valueContainer{
+			{slice: []float64{1.5, 0}, isNull: []bool{false, true}, id: mockID, name: "foo"},
+			{slice: []string{"it's", "b"}, isNull: []bool{false, false}, id: mockID, name: "bar"},
+			{slice: []time.Time{d, d}, isNull: []bool{false, false}, id: mockID, name: "baz"},
+			{slice: []bool{true, false}, isNull: []bool{false, false}, id: mockID, name: "qux"}},
+		labels:        []*valueContainer{{slice: []int{0, 1}, isNull: []bool{false, false}, id: mockID, name: "*0"}},
+		colLevelNames: []string{"*0"}}
+	type fields struct {
+		Table         string
+		Dialect       SQLDialect
+		CreateTable   bool
+		BatchSize     int
+		IncludeLabels bool
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		df      *DataFrame
+		want    string
+		wantErr bool
+	}{
+		{"postgres",
+			fields{Table: "public.corge", Dialect: SQLPostgres, CreateTable: true, BatchSize: 1000, IncludeLabels: true},
+			df,
+			`CREATE TABLE "public"."corge" ("*0" BIGINT, "foo" DOUBLE PRECISION, "bar" TEXT, "baz" TIMESTAMP WITH TIME ZONE, "qux" BOOLEAN);` + "\n" +
+				`INSERT INTO "public"."corge" ("*0", "foo", "bar", "baz", "qux") VALUES ` +
+				`(0, 1.5, 'it''s', '2020-01-02 03:04:05+00:00', TRUE), (1, NULL, 'b', '2020-01-02 03:04:05+00:00', FALSE);` + "\n",
+			false,
+		},
+		{"mysql - batches",
+			fields{Table: "corge", Dialect: SQLMySQL, CreateTable: false, BatchSize: 1},
+			df,
+			"INSERT INTO `corge` (`foo`, `bar`, `baz`, `qux`) VALUES (1.5, 'it''s', '2020-01-02 03:04:05', TRUE);\n" +
+				"INSERT INTO `corge` (`foo`, `bar`, `baz`, `qux`) VALUES (NULL, 'b', '2020-01-02 03:04:05', FALSE);\n",
+			false,
+		},
+		{"sqlite - column types",
+			fields{Table: "corge", Dialect: SQLSQLite, CreateTable: true, BatchSize: 1000},
+			&DataFrame{
+				values: []*valueContainer{
+					{slice: []int64{1}, isNull: []bool{false}, id: mockID, name: "foo"},
+					{slice: []civil.Date{civil.DateOf(d)}, isNull: []bool{false}, id: mockID, name: "bar"},
+					{slice: []civil.Time{civil.TimeOf(d)}, isNull: []bool{false}, id: mockID, name: "baz"},
+					{slice: []interface{}{"a"}, isNull: []bool{false}, id: mockID, name: "qux"}},
+				labels:        []*valueContainer{{slice: []int{0}, isNull: []bool{false}, id: mockID, name: "*0"}},
+				colLevelNames: []string{"*0"}},
+			`CREATE TABLE "corge" ("foo" INTEGER, "bar" DATE, "baz" TEXT, "qux" TEXT);` + "\n" +
+				`INSERT INTO "corge" ("foo", "bar", "baz", "qux") VALUES (1, '2020-01-02', '03:04:05', 'a');` + "\n",
+			false,
+		},
+		{"non-finite floats as null",
+			fields{Table: "corge", Dialect: SQLPostgres, CreateTable: false, BatchSize: 1000},
+			&DataFrame{
+				values: []*valueContainer{
+					{slice: []float64{math.NaN(), math.Inf(1), math.Inf(-1)}, isNull: []bool{false, false, false}, id: mockID, name: "foo"}},
+				labels:        []*valueContainer{{slice: []int{0, 1, 2}, isNull: []bool{false, false, false}, id: mockID, name: "*0"}},
+				colLevelNames: []string{"*0"}},
+			`INSERT INTO "corge" ("foo") VALUES (NULL), (NULL), (NULL);` + "\n",
+			false,
+		},
+		{"fail - batch size",
+			fields{Table: "corge", BatchSize: 0},
+			df,
+			"",
+			true,
+		},
+		{"fail - no table",
+			fields{Table: "", BatchSize: 1},
+			df,
+			"",
+			true,
+		},
+		{"fail - unsupported dialect",
+			fields{Table: "corge", Dialect: 100, BatchSize: 1},
+			df,
+			"",
+			true,
+		},
+		{"fail - dataframe has error",
+			fields{Table: "corge", BatchSize: 1},
+			&DataFrame{err: fmt.Errorf("foo")},
+			"",
+			true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := new(bytes.Buffer)
+			w := NewSQLScriptWriter(b, tt.fields.Table)
+			w.Dialect = tt.fields.Dialect
+			w.CreateTable = tt.fields.CreateTable
+			w.BatchSize = tt.fields.BatchSize
+			w.IncludeLabels = tt.fields.IncludeLabels
+			if err := w.Write(tt.df); (err != nil) != tt.wantErr {
+				t.Errorf("SQLWriter.Write() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got := b.String(); got != tt.want {
+				t.Errorf("SQLWriter.Write() -> %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSQLWriter_Write_exec(t *testing.T) {
+	d := time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+	df := &DataFrame{
+		values: []*valueContainer{
+			{slice: []float64{1.5, 0, 3}, isNull: []bool{false, true, false}, id: mockID, name: "foo"},
+			{slice: []civil.Date{civil.DateOf(d), {}, civil.DateOf(d)}, isNull: []bool{false, true, false}, id: mockID, name: "bar"}},
+		labels:        []*valueContainer{{slice: []int{0, 1, 2}, isNull: []bool{false, false, false}, id: mockID, name: "*0"}},
+		colLevelNames: []string{"*0"}}
+	tests := []struct {
+		name    string
+		dialect SQLDialect
+		err     error
+		want    []string
+		wantErr bool
+	}{
+		{"exec - postgres",
+			SQLPostgres,
+			nil,
+			[]string{
+				`CREATE TABLE "corge" ("foo" DOUBLE PRECISION, "bar" DATE) []`,
+				`INSERT INTO "corge" ("foo", "bar") VALUES ($1, $2), ($3, $4) [1.5 2020-01-02 <nil> <nil>]`,
+				`INSERT INTO "corge" ("foo", "bar") VALUES ($1, $2) [3 2020-01-02]`},
+			false,
+		},
+		{"exec - mysql",
+			SQLMySQL,
+			nil,
+			[]string{
+				"CREATE TABLE `corge` (`foo` DOUBLE, `bar` DATE) []",
+				"INSERT INTO `corge` (`foo`, `bar`) VALUES (?, ?), (?, ?) [1.5 2020-01-02 <nil> <nil>]",
+				"INSERT INTO `corge` (`foo`, `bar`) VALUES (?, ?) [3 2020-01-02]"},
+			false,
+		},
+		{"fail - exec error",
+			SQLPostgres,
+			fmt.Errorf("foo"),
+			nil,
+			true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var execs []string
+			db := openFakeDB(tt.name, fakeTable{err: tt.err, execs: &execs})
+			defer db.Close()
+			w := NewSQLWriter(db, "corge")
+			w.Dialect = tt.dialect
+			w.BatchSize = 2
+			if err := w.Write(df); (err != nil) != tt.wantErr {
+				t.Errorf("SQLWriter.Write() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(execs, tt.want) {
+				t.Errorf("SQLWriter.Write() -> %v, want %v", execs, tt.want)
+			}
+		})
+	}
+}
+
+func TestSQLWriter_Write_placeholderLimit(t *testing.T) {
+	wide := func(numColumns int) *DataFrame {
+		values := make([]*valueContainer, numColumns)
+		for k := range values {
+			values[k] = &valueContainer{slice: []float64{1, 2, 3}, isNull: []bool{false, false, false}, id: mockID, name: fmt.Sprint(k)}
+		}
+		return &DataFrame{
+			values:        values,
+			labels:        []*valueContainer{{slice: []int{0, 1, 2}, isNull: []bool{false, false, false}, id: mockID, name: "*0"}},
+			colLevelNames: []string{"*0"}}
+	}
+	tests := []struct {
+		name        string
+		dialect     SQLDialect
+		df          *DataFrame
+		wantInserts int
+		wantErr     bool
+	}{
+		{"postgres - one batch", SQLPostgres, wide(500), 1, false},
+		{"sqlite - one row per batch", SQLSQLite, wide(500), 3, false},
+		{"fail - sqlite - too many columns", SQLSQLite, wide(1000), 0, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var execs []string
+			db := openFakeDB(tt.name, fakeTable{execs: &execs})
+			defer db.Close()
+			w := NewSQLWriter(db, "corge")
+			w.Dialect = tt.dialect
+			w.CreateTable = false
+			if err := w.Write(tt.df); (err != nil) != tt.wantErr {
+				t.Errorf("SQLWriter.Write() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if len(execs) != tt.wantInserts {
+				t.Errorf("SQLWriter.Write() -> %d statements, want %d", len(execs), tt.wantInserts)
+			}
+		})
+	}
+}
+
+func TestCSVReader_Chunks(t *testing.T) {
+	type fields struct {
+		LabelLevels int
+		InferTypes  bool
+		ByColumn    bool
+		data        string
+	}
+	tests := []struct {
+		name      string
+		fields    fields
+		chunkSize int
+		want      []*DataFrame
+		wantErr   bool
+	}{
+		{"pass - default labels continue",
+			fields{data: "foo,bar\n1,a\n2,b\n3,c"},
+			2,
+			[]*DataFrame{
+				{values: []*valueContainer{
+					{slice: []string{"1", "2"}, isNull: []bool{false, false}, id: mockID, name: "foo"},
+					{slice: []string{"a", "b"}, isNull: []bool{false, false}, id: mockID, name: "bar"}},
+					labels:        []*valueContainer{{slice: []int{0, 1}, isNull: []bool{false, false}, id: mockID, name: "*0"}},
+					colLevelNames: []string{"*0"}},
+				{values: []*valueContainer{
+					{slice: []string{"3"}, isNull: []bool{false}, id: mockID, name: "foo"},
+					{slice: []string{"c"}, isNull: []bool{false}, id: mockID, name: "bar"}},
+					labels:        []*valueContainer{{slice: []int{2}, isNull: []bool{false}, id: mockID, name: "*0"}},
+					colLevelNames: []string{"*0"}},
+			},
+			false,
+		},
+		{"pass - types inferred from first chunk",
+			fields{LabelLevels: 1, InferTypes: true, data: "foo,bar\na,1\nb,2\nc,\nd,3\ne,"},
+			3,
+			[]*DataFrame{
+				{values: []*valueContainer{
+					{slice: []float64{1, 2, 0}, isNull: []bool{false, false, true}, id: mockID, name: "bar", cache: []string{"1", "2", ""}}},
+					labels: []*valueContainer{
+						{slice: []string{"a", "b", "c"}, isNull: []bool{false, false, false}, id: mockID, name: "foo", cache: []string{"a", "b", "c"}}},
+					colLevelNames: []string{"*0"}},
+				{values: []*valueContainer{
+					{slice: []float64{3, 0}, isNull: []bool{false, true}, id: mockID, name: "bar", cache: []string{"3", ""}}},
+					labels: []*valueContainer{
+						{slice: []string{"d", "e"}, isNull: []bool{false, false}, id: mockID, name: "foo", cache: []string{"d", "e"}}},
+					colLevelNames: []string{"*0"}},
+			},
+			false,
+		},
+		{"fail - later chunk does not match inferred type",
+			fields{LabelLevels: 1, InferTypes: true, data: "foo,bar\na,1\nb,c"},
+			1,
+			[]*DataFrame{
+				{values: []*valueContainer{
+					{slice: []float64{1}, isNull: []bool{false}, id: mockID, name: "bar", cache: []string{"1"}}},
+					labels:        []*valueContainer{{slice: []string{"a"}, isNull: []bool{false}, id: mockID, name: "foo", cache: []string{"a"}}},
+					colLevelNames: []string{"*0"}},
+			},
+			true,
+		},
+		{"pass - no rows",
+			fields{data: "foo,bar"},
+			2,
+			nil,
+			false,
+		},
+		{"fail - chunk size",
+			fields{data: "foo,bar\n1,a"},
+			0,
+			nil,
+			true,
+		},
+		{"fail - by column",
+			fields{ByColumn: true, data: "foo,bar\n1,a"},
+			1,
+			nil,
+			true,
+		},
+		{"fail - no header",
+			fields{data: ""},
+			1,
+			nil,
+			true,
+		},
+		{"fail - wrong number of fields",
+			fields{data: "foo,bar\n1,a\n2"},
+			1,
+			[]*DataFrame{
+				{values: []*valueContainer{
+					{slice: []string{"1"}, isNull: []bool{false}, id: mockID, name: "foo"},
+					{slice: []string{"a"}, isNull: []bool{false}, id: mockID, name: "bar"}},
+					labels:        []*valueContainer{{slice: []int{0}, isNull: []bool{false}, id: mockID, name: "*0"}},
+					colLevelNames: []string{"*0"}},
+			},
+			true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := NewCSVReader(strings.NewReader(tt.fields.data))
+			r.LabelLevels = tt.fields.LabelLevels
+			r.InferTypes = tt.fields.InferTypes
+			r.ByColumn = tt.fields.ByColumn
+			iter := r.Chunks(tt.chunkSize)
+			var got []*DataFrame
+			for iter.Next() {
+				got = append(got, iter.DataFrame())
+			}
+			if err := iter.Err(); (err != nil) != tt.wantErr {
+				t.Errorf("CSVReader.Chunks() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if len(got) != len(tt.want) {
+				t.Errorf("CSVReader.Chunks() -> %d chunks, want %d", len(got), len(tt.want))
+				return
+			}
+			for i := range got {
+				if !EqualDataFrames(got[i], tt.want[i]) {
+					t.Errorf("CSVReader.Chunks() -> chunk %d = %v, want %v", i, got[i], tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func compressTestData(t *testing.T, data string, compression Compression) []byte {
+	b := new(bytes.Buffer)
+	if compression == NoCompression {
+		b.WriteString(data)
+		return b.Bytes()
+	}
+	w, err := newCompressor(b, compression)
+	if err != nil {
+		t.Fatalf("newCompressor() error = %v", err)
+	}
+	w.Write([]byte(data))
+	w.Close()
+	return b.Bytes()
+}
+
+func TestDecompress(t *testing.T) {
+	want := &DataFrame{
+		values:        []*valueContainer{{slice: []string{"1"}, isNull: []bool{false}, id: mockID, name: "foo"}},
+		labels:        []*valueContainer{{slice: []int{0}, isNull: []bool{false}, id: mockID, name: "*0"}},
+		colLevelNames: []string{"*0"}}
+	for _, compression := range []Compression{NoCompression, Gzip, Zstd, Bzip2} {
+		r, err := Decompress(bytes.NewReader(compressTestData(t, "foo\n1", compression)))
+		if err != nil {
+			t.Errorf("Decompress() [compression %v] error = %v", compression, err)
+			continue
+		}
+		got, err := NewCSVReader(r).Read()
+		r.Close()
+		if err != nil {
+			t.Errorf("Decompress() -> CSVReader.Read() [compression %v] error = %v", compression, err)
+			continue
+		}
+		if !EqualDataFrames(got, want) {
+			t.Errorf("Decompress() -> CSVReader.Read() [compression %v] = %v, want %v", compression, got, want)
+		}
+	}
+	// uncompressed data that begins with the bzip2 magic bytes, but not a block size
+	r, err := Decompress(strings.NewReader("BZhfoo\n1"))
+	if err != nil {
+		t.Fatalf("Decompress() [uncompressed, starts with BZh] error = %v", err)
+	}
+	got, _ := ioutil.ReadAll(r)
+	r.Close()
+	if string(got) != "BZhfoo\n1" {
+		t.Errorf("Decompress() [uncompressed, starts with BZh] -> %q, want %q", got, "BZhfoo\n1")
+	}
+}
+
+func TestCSVWriter_Write_compression(t *testing.T) {
+	df := &DataFrame{
+		values:        []*valueContainer{{slice: []string{"1", "2"}, isNull: []bool{false, false}, id: mockID, name: "foo"}},
+		labels:        []*valueContainer{{slice: []int{0, 1}, isNull: []bool{false, false}, id: mockID, name: "*0"}},
+		colLevelNames: []string{"*0"}}
+	for _, compression := range []Compression{NoCompression, Gzip, Zstd, Bzip2} {
+		b := new(bytes.Buffer)
+		w := NewCSVWriter(b)
+		w.Compression = compression
+		w.Comma = ';'
+		w.IncludeLabels = true
+		err := w.Write(df)
+		if err != nil {
+			t.Errorf("CSVWriter.Write() [compression %v] error = %v", compression, err)
+			continue
+		}
+		w.Flush()
+		r, err := Decompress(b)
+		if err != nil {
+			t.Errorf("CSVWriter.Write() -> Decompress() [compression %v] error = %v", compression, err)
+			continue
+		}
+		got, _ := ioutil.ReadAll(r)
+		r.Close()
+		want := "*0;foo\n0;1\n1;2\n"
+		if string(got) != want {
+			t.Errorf("CSVWriter.Write() [compression %v] -> %q, want %q", compression, got, want)
+		}
+	}
+	w := NewCSVWriter(new(bytes.Buffer))
+	w.Compression = 100
+	if err := w.Write(df); err == nil {
+		t.Errorf("CSVWriter.Write() with unsupported compression: error = nil, want error")
+	}
+}
+
+func TestOpenFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "tada")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	files := map[string][]byte{
+		"plain.csv":  compressTestData(t, "foo\n1", NoCompression),
+		"gz.csv":     compressTestData(t, "foo\n1", Gzip),
+		"zstd.zst":   compressTestData(t, "foo\n1", Zstd),
+		"bad.csv.gz": compressTestData(t, "foo\n1", NoCompression),
+	}
+	for name, b := range files {
+		ioutil.WriteFile(filepath.Join(dir, name), b, 0644)
+	}
+	tests := []struct {
+		name    string
+		path    string
+		want    string
+		wantErr bool
+	}{
+		{"pass - uncompressed", filepath.Join(dir, "plain.csv"), "foo\n1", false},
+		{"pass - magic bytes", filepath.Join(dir, "gz.csv"), "foo\n1", false},
+		{"pass - magic bytes and extension", filepath.Join(dir, "zstd.zst"), "foo\n1", false},
+		{"fail - extension does not match contents", filepath.Join(dir, "bad.csv.gz"), "", true},
+		{"fail - no file", filepath.Join(dir, "missing.csv"), "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, err := OpenFile(tt.path)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("OpenFile() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				return
+			}
+			got, _ := ioutil.ReadAll(r)
+			if err := r.Close(); err != nil {
+				t.Errorf("OpenFile() -> Close() error = %v", err)
+			}
+			if string(got) != tt.want {
+				t.Errorf("OpenFile() -> %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestReadArchive(t *testing.T) {
+	dir, err := ioutil.TempDir("", "tada")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	members := map[string][]byte{
+		"foo.csv":    compressTestData(t, "foo\n1", NoCompression),
+		"bar.csv.gz": compressTestData(t, "bar\n2", Gzip),
+	}
+	names := []string{"foo.csv", "bar.csv.gz"}
+
+	tarData := new(bytes.Buffer)
+	tw := tar.NewWriter(tarData)
+	tw.WriteHeader(&tar.Header{Name: "dir/", Typeflag: tar.TypeDir, Mode: 0755})
+	for _, name := range names {
+		tw.WriteHeader(&tar.Header{Name: name, Mode: 0644, Size: int64(len(members[name]))})
+		tw.Write(members[name])
+	}
+	tw.Close()
+	ioutil.WriteFile(filepath.Join(dir, "archive.tar.gz"), compressTestData(t, tarData.String(), Gzip), 0644)
+
+	zipData := new(bytes.Buffer)
+	zw := zip.NewWriter(zipData)
+	for _, name := range names {
+		f, _ := zw.Create(name)
+		f.Write(members[name])
+	}
+	zw.Close()
+	ioutil.WriteFile(filepath.Join(dir, "archive.zip"), zipData.Bytes(), 0644)
+
+	badZip := new(bytes.Buffer)
+	zw = zip.NewWriter(badZip)
+	f, _ := zw.Create("bad.csv")
+	f.Write([]byte("foo\n1,2"))
+	zw.Close()
+	ioutil.WriteFile(filepath.Join(dir, "bad.zip"), badZip.Bytes(), 0644)
+
+	want := map[string]*DataFrame{
+		"foo.csv": {
+			values:        []*valueContainer{{slice: []string{"1"}, isNull: []bool{false}, id: mockID, name: "foo"}},
+			labels:        []*valueContainer{{slice: []int{0}, isNull: []bool{false}, id: mockID, name: "*0"}},
+			colLevelNames: []string{"*0"}},
+		"bar.csv.gz": {
+			values:        []*valueContainer{{slice: []string{"2"}, isNull: []bool{false}, id: mockID, name: "bar"}},
+			labels:        []*valueContainer{{slice: []int{0}, isNull: []bool{false}, id: mockID, name: "*0"}},
+			colLevelNames: []string{"*0"}},
+	}
+	newReader := func(r io.Reader) Reader { return NewCSVReader(r) }
+	tests := []struct {
+		name    string
+		path    string
+		want    map[string]*DataFrame
+		wantErr bool
+	}{
+		{"pass - tar", filepath.Join(dir, "archive.tar.gz"), want, false},
+		{"pass - zip", filepath.Join(dir, "archive.zip"), want, false},
+		{"fail - bad file in archive", filepath.Join(dir, "bad.zip"), nil, true},
+		{"fail - no archive", filepath.Join(dir, "missing.zip"), nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ReadArchive(tt.path, newReader)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ReadArchive() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if len(got) != len(tt.want) {
+				t.Errorf("ReadArchive() -> %d files, want %d", len(got), len(tt.want))
+				return
+			}
+			for name := range tt.want {
+				if !EqualDataFrames(got[name], tt.want[name]) {
+					t.Errorf("ReadArchive() -> %s = %v, want %v", name, got[name], tt.want[name])
+				}
+			}
+		})
+	}
+}
+
+func TestMultiCSVReader_Read(t *testing.T) {
+	dir, err := ioutil.TempDir("", "tada")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	files := map[string][]byte{
+		"sales/date=2020-03-01/region=east/part-0.csv":    compressTestData(t, "foo\n1\n2", NoCompression),
+		"sales/date=2020-03-02/region=west/part-0.csv.gz": compressTestData(t, "foo\n3", Gzip),
+		"sales/_SUCCESS":           nil,
+		"sales/.hidden/part-0.csv": compressTestData(t, "bar\n1", NoCompression),
+		"mismatch/a.csv":           compressTestData(t, "foo\n1", NoCompression),
+		"mismatch/b.csv":           compressTestData(t, "bar\n1", NoCompression),
+		"bad/a.csv":                compressTestData(t, "foo\n1,2", NoCompression),
+		"conflict/foo=1/a.csv":     compressTestData(t, "foo\n1", NoCompression),
+		"flat/a.csv":               compressTestData(t, "foo\n1", NoCompression),
+		"flat/b.csv":               compressTestData(t, "foo\n2", NoCompression),
+		"flat/c.txt":               compressTestData(t, "foo\n3", NoCompression),
+	}
+	for name, b := range files {
+		path := filepath.Join(dir, name)
+		os.MkdirAll(filepath.Dir(path), 0755)
+		ioutil.WriteFile(path, b, 0644)
+	}
+	type fields struct {
+		Configure          func(*CSVReader)
+		PartitionKeys      bool
+		PartitionsAsLabels bool
+		Concurrency        int
+		Name               string
+		pattern            string
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		want    *DataFrame
+		wantErr bool
+	}{
+		{"pass - glob",
+			fields{pattern: filepath.Join(dir, "flat", "*.csv"), Concurrency: 2, Name: "baz"},
+			&DataFrame{
+				values:        []*valueContainer{{slice: []string{"1", "2"}, isNull: []bool{false, false}, id: mockID, name: "foo"}},
+				labels:        []*valueContainer{{slice: []int{0, 1}, isNull: []bool{false, false}, id: mockID, name: "*0"}},
+				name:          "baz",
+				colLevelNames: []string{"*0"}},
+			false},
+		{"pass - directory with partition columns",
+			fields{pattern: filepath.Join(dir, "sales"), PartitionKeys: true, Concurrency: 1},
+			&DataFrame{
+				values: []*valueContainer{
+					{slice: []string{"1", "2", "3"}, isNull: []bool{false, false, false}, id: mockID, name: "foo"},
+					{slice: []string{"2020-03-01", "2020-03-01", "2020-03-02"}, isNull: []bool{false, false, false}, id: mockID, name: "date"},
+					{slice: []string{"east", "east", "west"}, isNull: []bool{false, false, false}, id: mockID, name: "region"},
+				},
+				labels:        []*valueContainer{{slice: []int{0, 1, 2}, isNull: []bool{false, false, false}, id: mockID, name: "*0"}},
+				colLevelNames: []string{"*0"}},
+			false},
+		{"pass - partition labels",
+			fields{pattern: filepath.Join(dir, "sales", "*", "*", "*"), PartitionKeys: true, PartitionsAsLabels: true,
+				Configure: func(r *CSVReader) { r.InferTypes = true }},
+			&DataFrame{
+				values: []*valueContainer{
+					{slice: []float64{1, 2, 3}, isNull: []bool{false, false, false}, id: mockID, name: "foo"},
+				},
+				labels: []*valueContainer{
+					{slice: []int{0, 1, 2}, isNull: []bool{false, false, false}, id: mockID, name: "*0"},
+					{slice: []string{"2020-03-01", "2020-03-01", "2020-03-02"}, isNull: []bool{false, false, false}, id: mockID, name: "date"},
+					{slice: []string{"east", "east", "west"}, isNull: []bool{false, false, false}, id: mockID, name: "region"},
+				},
+				colLevelNames: []string{"*0"}},
+			false},
+		{"fail - mismatched columns", fields{pattern: filepath.Join(dir, "mismatch")}, nil, true},
+		{"fail - bad file", fields{pattern: filepath.Join(dir, "bad", "*.csv")}, nil, true},
+		{"fail - partition key conflicts with column", fields{pattern: filepath.Join(dir, "conflict"), PartitionKeys: true}, nil, true},
+		{"fail - no files", fields{pattern: filepath.Join(dir, "flat", "*.json")}, nil, true},
+		{"fail - bad pattern", fields{pattern: "["}, nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := MultiCSVReader{
+				Configure:          tt.fields.Configure,
+				PartitionKeys:      tt.fields.PartitionKeys,
+				PartitionsAsLabels: tt.fields.PartitionsAsLabels,
+				Concurrency:        tt.fields.Concurrency,
+				Name:               tt.fields.Name,
+				pattern:            tt.fields.pattern,
+			}
+			got, err := r.Read()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("MultiCSVReader.Read() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !EqualDataFrames(got, tt.want) {
+				t.Errorf("MultiCSVReader.Read() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMultiCSVReader_Read_mismatchNamesFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "tada")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	ioutil.WriteFile(filepath.Join(dir, "a.csv"), []byte("foo\n1"), 0644)
+	ioutil.WriteFile(filepath.Join(dir, "b.csv"), []byte("foo,bar\n1,2"), 0644)
+	_, err = NewMultiCSVReader(dir).Read()
+	if err == nil || !strings.Contains(err.Error(), "b.csv") {
+		t.Errorf("MultiCSVReader.Read() error = %v, want error naming b.csv", err)
+	}
+}
+
+func TestMultiCSVReader_Read_typesFromFirstFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "tada")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	ioutil.WriteFile(filepath.Join(dir, "a.csv"), []byte("foo,bar\n1,x"), 0644)
+	ioutil.WriteFile(filepath.Join(dir, "b.csv"), []byte("foo,bar\n,y"), 0644)
+	r := NewMultiCSVReader(dir)
+	r.Configure = func(r *CSVReader) { r.InferTypes = true }
+	got, err := r.Read()
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := &DataFrame{
+		values: []*valueContainer{
+			{slice: []float64{1, 0}, isNull: []bool{false, true}, id: mockID, name: "foo"},
+			{slice: []string{"x", "y"}, isNull: []bool{false, false}, id: mockID, name: "bar"}},
+		labels:        []*valueContainer{{slice: []int{0, 1}, isNull: []bool{false, false}, id: mockID, name: "*0"}},
+		colLevelNames: []string{"*0"}}
+	if !EqualDataFrames(got, want) {
+		t.Errorf("MultiCSVReader.Read() = %v, want %v", got, want)
+	}
+
+	ioutil.WriteFile(filepath.Join(dir, "c.csv"), []byte("foo,bar\nz,y"), 0644)
+	_, err = r.Read()
+	if err == nil || !strings.Contains(err.Error(), "c.csv: row 0, column foo") {
+		t.Errorf("MultiCSVReader.Read() error = %v, want error naming c.csv, row 0, column foo", err)
+	}
+}
+
+// xlsxTestData returns an xlsx workbook containing files, along with the parts that every workbook requires.
+func xlsxTestData(t *testing.T, sheets []string, files map[string]string) []byte {
+	workbook := `<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" ` +
+		`xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships"><sheets>`
+	rels := `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">`
+	for i, name := range sheets {
+		workbook += fmt.Sprintf(`<sheet name="%s" sheetId="%d" r:id="rId%d"/>`, name, i+1, i+1)
+		rels += fmt.Sprintf(`<Relationship Id="rId%d" Target="worksheets/sheet%d.xml"/>`, i+1, i+1)
+	}
+	if _, ok := files["xl/workbook.xml"]; !ok {
+		files["xl/workbook.xml"] = workbook + `</sheets></workbook>`
+	}
+	files["xl/_rels/workbook.xml.rels"] = rels + `</Relationships>`
+	b := new(bytes.Buffer)
+	zw := zip.NewWriter(b)
+	for name, content := range files {
+		f, err := zw.Create(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		f.Write([]byte(content))
+	}
+	zw.Close()
+	return b.Bytes()
+}
+
+func TestXLSXReader_Read(t *testing.T) {
+	sheet := func(rows string) string {
+		return `<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData>` + rows + `</sheetData></worksheet>`
+	}
+	styles := `<styleSheet><numFmts><numFmt numFmtId="164" formatCode="&quot;day&quot; 0"/></numFmts>` +
+		`<cellXfs><xf numFmtId="0"/><xf numFmtId="14"/><xf numFmtId="164"/></cellXfs></styleSheet>`
+	sharedStrings := `<sst><si><t>foo</t></si><si><r><t>ba</t></r><r><t>r</t></r></si></sst>`
+	data := xlsxTestData(t, []string{"first", "second"}, map[string]string{
+		"xl/styles.xml":        styles,
+		"xl/sharedStrings.xml": sharedStrings,
+		"xl/worksheets/sheet1.xml": sheet(
+			`<row r="2"><c r="B2" t="s"><v>0</v></c><c r="C2" t="s"><v>1</v></c><c r="D2" t="inlineStr"><is><t>baz</t></is></c></row>` +
+				`<row r="3"><c r="B3" t="s"><v>0</v></c><c r="C3" s="1"><v>43891</v></c><c r="D3" s="2"><v>1.5</v></c></row>` +
+				`<row r="4"><c r="B4" t="b"><v>1</v></c><c r="C4" s="1"><v>43891.75</v></c><c r="D4"/></row>`),
+		"xl/worksheets/sheet2.xml": sheet(`<row><c t="str"><v>qux</v></c></row><row><c><v>1</v></c></row>`),
+		"xl/worksheets/sheet3.xml": sheet(``),
+	})
+	emptySheet := xlsxTestData(t, []string{"empty"}, map[string]string{"xl/worksheets/sheet1.xml": sheet(``)})
+	merged := xlsxTestData(t, []string{"merged"}, map[string]string{
+		"xl/worksheets/sheet1.xml": `<worksheet><sheetData>` +
+			`<row r="1"><c r="A1" t="inlineStr"><is><t>foo</t></is></c></row>` +
+			`<row r="2"><c r="A2" t="inlineStr"><is><t>bar</t></is></c><c r="B2" t="inlineStr"><is><t>baz</t></is></c></row>` +
+			`<row r="3"><c r="A3"><v>1</v></c><c r="B3"><v>2</v></c></row>` +
+			`</sheetData><mergeCells><mergeCell ref="A1:B1"/></mergeCells></worksheet>`,
+	})
+	date1904 := xlsxTestData(t, []string{"1904"}, map[string]string{
+		"xl/workbook.xml": `<workbook xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">` +
+			`<workbookPr date1904="1"/><sheets><sheet name="1904" sheetId="1" r:id="rId1"/></sheets></workbook>`,
+		"xl/styles.xml":            styles,
+		"xl/worksheets/sheet1.xml": sheet(`<row><c t="inlineStr"><is><t>foo</t></is></c></row><row><c s="1"><v>0</v></c></row>`),
+	})
+	type fields struct {
+		Sheet       string
+		SheetIndex  int
+		HeaderRows  int
+		LabelLevels int
+		Range       string
+		Name        string
+		data        []byte
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		want    *DataFrame
+		wantErr bool
+	}{
+		{"pass - cells in use", fields{Sheet: "first", HeaderRows: 1, LabelLevels: 1, Name: "qux", data: data},
+			&DataFrame{
+				labels: []*valueContainer{
+					{slice: []string{"foo", "true"}, isNull: []bool{false, false}, id: mockID, name: "foo"},
+				},
+				values: []*valueContainer{
+					{slice: []time.Time{time.Date(2020, 3, 1, 0, 0, 0, 0, time.UTC), time.Date(2020, 3, 1, 18, 0, 0, 0, time.UTC)},
+						isNull: []bool{false, false}, id: mockID, name: "bar"},
+					{slice: []float64{1.5, 0}, isNull: []bool{false, true}, id: mockID, name: "baz"},
+				},
+				name:          "qux",
+				colLevelNames: []string{"*0"}},
+			false},
+		{"pass - range", fields{HeaderRows: 0, Range: "c3:e3", data: data},
+			&DataFrame{
+				labels: []*valueContainer{{slice: []int{0}, isNull: []bool{false}, id: mockID, name: "*0"}},
+				values: []*valueContainer{
+					{slice: []time.Time{time.Date(2020, 3, 1, 0, 0, 0, 0, time.UTC)}, isNull: []bool{false}, id: mockID, name: "0"},
+					{slice: []float64{1.5}, isNull: []bool{false}, id: mockID, name: "1"},
+					{slice: []string{""}, isNull: []bool{true}, id: mockID, name: "2"},
+				},
+				colLevelNames: []string{"*0"}},
+			false},
+		{"pass - sheet index and cells without references", fields{SheetIndex: 1, HeaderRows: 1, data: data},
+			&DataFrame{
+				labels:        []*valueContainer{{slice: []int{0}, isNull: []bool{false}, id: mockID, name: "*0"}},
+				values:        []*valueContainer{{slice: []float64{1}, isNull: []bool{false}, id: mockID, name: "qux"}},
+				colLevelNames: []string{"*0"}},
+			false},
+		{"pass - merged headers", fields{HeaderRows: 2, data: merged},
+			&DataFrame{
+				labels: []*valueContainer{{slice: []int{0}, isNull: []bool{false}, id: mockID, name: "*0"}},
+				values: []*valueContainer{
+					{slice: []float64{1}, isNull: []bool{false}, id: mockID, name: "foo|bar"},
+					{slice: []float64{2}, isNull: []bool{false}, id: mockID, name: "foo|baz"},
+				},
+				colLevelNames: []string{"*0", "*1"}},
+			false},
+		{"pass - 1904 date system", fields{HeaderRows: 1, data: date1904},
+			&DataFrame{
+				labels: []*valueContainer{{slice: []int{0}, isNull: []bool{false}, id: mockID, name: "*0"}},
+				values: []*valueContainer{
+					{slice: []time.Time{time.Date(1904, 1, 1, 0, 0, 0, 0, time.UTC)}, isNull: []bool{false}, id: mockID, name: "foo"},
+				},
+				colLevelNames: []string{"*0"}},
+			false},
+		{"fail - sheet name", fields{Sheet: "corge", data: data}, nil, true},
+		{"fail - sheet index", fields{SheetIndex: 2, data: data}, nil, true},
+		{"fail - range", fields{Range: "A1", data: data}, nil, true},
+		{"fail - range order", fields{Range: "B2:A1", data: data}, nil, true},
+		{"fail - too many header rows", fields{HeaderRows: 4, data: data}, nil, true},
+		{"fail - too many label levels", fields{HeaderRows: 1, LabelLevels: 4, data: data}, nil, true},
+		{"fail - empty sheet", fields{HeaderRows: 1, data: emptySheet}, nil, true},
+		{"fail - not xlsx", fields{HeaderRows: 1, data: []byte("foo")}, nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := XLSXReader{
+				Sheet:       tt.fields.Sheet,
+				SheetIndex:  tt.fields.SheetIndex,
+				HeaderRows:  tt.fields.HeaderRows,
+				LabelLevels: tt.fields.LabelLevels,
+				Range:       tt.fields.Range,
+				Name:        tt.fields.Name,
+				r:           bytes.NewReader(tt.fields.data),
+				size:        int64(len(tt.fields.data)),
+			}
+			got, err := r.Read()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("XLSXReader.Read() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !EqualDataFrames(got, tt.want) {
+				t.Errorf("XLSXReader.Read() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestXLSXWriter_Write(t *testing.T) {
+	df := &DataFrame{
+		labels: []*valueContainer{{slice: []string{"a", "b"}, isNull: []bool{false, false}, id: mockID, name: "id"}},
+		values: []*valueContainer{
+			{slice: []float64{1.5, 0}, isNull: []bool{false, true}, id: mockID, name: "float"},
+			{slice: []int{1, 2}, isNull: []bool{false, false}, id: mockID, name: "int"},
+			{slice: []string{"<foo>", ""}, isNull: []bool{false, true}, id: mockID, name: "string"},
+			{slice: []bool{true, false}, isNull: []bool{false, false}, id: mockID, name: "bool"},
+			{slice: []time.Time{time.Date(2020, 3, 1, 12, 30, 0, 0, time.FixedZone("EST", -5*3600)), {}},
+				isNull: []bool{false, true}, id: mockID, name: "datetime"},
+			{slice: []civil.Date{{Year: 2020, Month: 3, Day: 1}, {Year: 1900, Month: 3, Day: 1}},
+				isNull: []bool{false, false}, id: mockID, name: "date"},
+		},
+		name:          "foo",
+		colLevelNames: []string{"*0"},
+	}
+	want := &DataFrame{
+		labels: []*valueContainer{{slice: []string{"a", "b"}, isNull: []bool{false, false}, id: mockID, name: "id"}},
+		values: []*valueContainer{
+			{slice: []float64{1.5, 0}, isNull: []bool{false, true}, id: mockID, name: "float"},
+			{slice: []float64{1, 2}, isNull: []bool{false, false}, id: mockID, name: "int"},
+			{slice: []string{"<foo>", ""}, isNull: []bool{false, true}, id: mockID, name: "string"},
+			{slice: []string{"true", "false"}, isNull: []bool{false, false}, id: mockID, name: "bool"},
+			{slice: []time.Time{time.Date(2020, 3, 1, 12, 30, 0, 0, time.UTC), {}},
+				isNull: []bool{false, true}, id: mockID, name: "datetime"},
+			{slice: []time.Time{time.Date(2020, 3, 1, 0, 0, 0, 0, time.UTC), time.Date(1900, 3, 1, 0, 0, 0, 0, time.UTC)},
+				isNull: []bool{false, false}, id: mockID, name: "date"},
+		},
+		name:          "foo",
+		colLevelNames: []string{"*0"},
+	}
+	b := new(bytes.Buffer)
+	err := NewXLSXWriter(b).Write(df)
+	if err != nil {
+		t.Fatalf("XLSXWriter.Write() error = %v", err)
+	}
+	r := NewXLSXReader(bytes.NewReader(b.Bytes()), int64(b.Len()))
+	r.Sheet = "foo"
+	r.LabelLevels = 1
+	r.Name = "foo"
+	got, err := r.Read()
+	if err != nil {
+		t.Fatalf("XLSXWriter.Write() -> XLSXReader.Read() error = %v", err)
+	}
+	if !EqualDataFrames(got, want) {
+		t.Errorf("XLSXWriter.Write() -> XLSXReader.Read() = %v, want %v", got, want)
+	}
+	sheet := readZipMember(t, b.Bytes(), "xl/worksheets/sheet1.xml")
+	for _, s := range []string{
+		`<pane xSplit="1" ySplit="1" topLeftCell="B2" activePane="bottomRight" state="frozen"/>`,
+		`<c r="F2" s="3"><v>43891.520833333336</v></c>`,
+		`<c r="G2" s="2"><v>43891</v></c>`,
+		`&lt;foo&gt;`,
+	} {
+		if !strings.Contains(sheet, s) {
+			t.Errorf("XLSXWriter.Write() -> sheet1.xml does not contain %s: %s", s, sheet)
+		}
+	}
+}
+
+func TestXLSXWriter_Write_defaultLabelName(t *testing.T) {
+	df := &DataFrame{
+		labels:        []*valueContainer{{slice: []int{0}, isNull: []bool{false}, id: mockID, name: "*0"}},
+		values:        []*valueContainer{{slice: []string{"foo"}, isNull: []bool{false}, id: mockID, name: "bar"}},
+		colLevelNames: []string{"*0"},
+	}
+	b := new(bytes.Buffer)
+	err := NewXLSXWriter(b).Write(df)
+	if err != nil {
+		t.Fatalf("XLSXWriter.Write() error = %v", err)
+	}
+	sheet := readZipMember(t, b.Bytes(), "xl/worksheets/sheet1.xml")
+	if !strings.Contains(sheet, `<row r="1"><c r="B1" s="1" t="inlineStr"><is><t xml:space="preserve">bar</t></is></c></row>`) {
+		t.Errorf("XLSXWriter.Write() -> sheet1.xml does not have a blank label header: %s", sheet)
+	}
+	if strings.Contains(sheet, "*0") {
+		t.Errorf("XLSXWriter.Write() -> sheet1.xml contains default label name: %s", sheet)
+	}
+}
+
+func TestXLSXWriter_WriteSheets(t *testing.T) {
+	multiLevel := &DataFrame{
+		labels: []*valueContainer{{slice: []int{0}, isNull: []bool{false}, id: mockID, name: "*0"}},
+		values: []*valueContainer{
+			{slice: []float64{1}, isNull: []bool{false}, id: mockID, name: "a|x"},
+			{slice: []float64{2}, isNull: []bool{false}, id: mockID, name: "a|y"},
+			{slice: []float64{3}, isNull: []bool{false}, id: mockID, name: "b|x"},
+		},
+		name:          "multi",
+		colLevelNames: []string{"*0", "*1"},
+	}
+	unnamed := &DataFrame{
+		labels:        []*valueContainer{{slice: []int{0}, isNull: []bool{false}, id: mockID, name: "*0"}},
+		values:        []*valueContainer{{slice: []string{"foo"}, isNull: []bool{false}, id: mockID, name: "bar"}},
+		colLevelNames: []string{"*0"},
+	}
+	b := new(bytes.Buffer)
+	w := NewXLSXWriter(b)
+	w.IncludeLabels = false
+	err := w.WriteSheets(multiLevel, unnamed)
+	if err != nil {
+		t.Fatalf("XLSXWriter.WriteSheets() error = %v", err)
+	}
+	sheet := readZipMember(t, b.Bytes(), "xl/worksheets/sheet1.xml")
+	for _, s := range []string{
+		`<pane ySplit="2" topLeftCell="A3" activePane="bottomLeft" state="frozen"/>`,
+		`<mergeCells count="1"><mergeCell ref="A1:B1"/></mergeCells>`,
+	} {
+		if !strings.Contains(sheet, s) {
+			t.Errorf("XLSXWriter.WriteSheets() -> sheet1.xml does not contain %s: %s", s, sheet)
+		}
+	}
+	r := NewXLSXReader(bytes.NewReader(b.Bytes()), int64(b.Len()))
+	r.HeaderRows = 2
+	r.Name = "multi"
+	got, err := r.Read()
+	if err != nil {
+		t.Fatalf("XLSXWriter.WriteSheets() -> XLSXReader.Read() error = %v", err)
+	}
+	if !EqualDataFrames(got, multiLevel) {
+		t.Errorf("XLSXWriter.WriteSheets() -> XLSXReader.Read() = %v, want %v", got, multiLevel)
+	}
+	r = NewXLSXReader(bytes.NewReader(b.Bytes()), int64(b.Len()))
+	r.Sheet = "Sheet2"
+	got, err = r.Read()
+	if err != nil {
+		t.Fatalf("XLSXWriter.WriteSheets() -> XLSXReader.Read() error = %v", err)
+	}
+	want := &DataFrame{
+		labels:        []*valueContainer{{slice: []int{0}, isNull: []bool{false}, id: mockID, name: "*0"}},
+		values:        []*valueContainer{{slice: []string{"foo"}, isNull: []bool{false}, id: mockID, name: "bar"}},
+		colLevelNames: []string{"*0"},
+	}
+	if !EqualDataFrames(got, want) {
+		t.Errorf("XLSXWriter.WriteSheets() -> XLSXReader.Read() = %v, want %v", got, want)
+	}
+
+	tests := []struct {
+		name string
+		dfs  []*DataFrame
+	}{
+		{"fail - no DataFrames", nil},
+		{"fail - duplicate names", []*DataFrame{unnamed, {name: "sheet1"}}},
+		{"fail - invalid name", []*DataFrame{{name: "foo/bar"}}},
+		{"fail - long name", []*DataFrame{{name: strings.Repeat("a", 32)}}},
+		{"fail - DataFrame error", []*DataFrame{{err: fmt.Errorf("foo")}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := NewXLSXWriter(new(bytes.Buffer)).WriteSheets(tt.dfs...)
+			if err == nil {
+				t.Errorf("XLSXWriter.WriteSheets() error = nil, want error")
+			}
+		})
+	}
+}
+
+// readZipMember returns the contents of the file at name within the zip archive in data.
+func readZipMember(t *testing.T, data []byte, name string) string {
+	zr, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, f := range zr.File {
+		if f.Name == name {
+			rc, err := f.Open()
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer rc.Close()
+			b, _ := ioutil.ReadAll(rc)
+			return string(b)
+		}
+	}
+	t.Fatalf("%s not in archive", name)
+	return ""
+}
+
+func TestFixedWidthReader_Read(t *testing.T) {
+	data := "id   name      score\n" +
+		"a    joe doe      1.5\n" +
+		"\n" +
+		"b    (null)        10\r\n" +
+		"c    jane\n"
+	noHeader := "a001 1.5\nb002  10\n"
+	type fields struct {
+		RecordReader RecordReader
+		Columns      []FixedWidthColumn
+		r            io.Reader
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		want    *DataFrame
+		wantErr bool
+	}{
+		{"pass - column positions",
+			fields{RecordReader: RecordReader{HeaderRows: 1, LabelLevels: 1},
+				Columns: []FixedWidthColumn{{Start: 0, End: 5}, {Start: 5, End: 15}, {Start: 15, End: 21}},
+				r:       strings.NewReader(data)},
+			&DataFrame{
+				labels: []*valueContainer{
+					{slice: []string{"a", "b", "c"}, isNull: []bool{false, false, false}, id: mockID, name: "id"},
+				},
+				values: []*valueContainer{
+					{slice: []string{"joe doe", "(null)", "jane"}, isNull: []bool{false, true, false}, id: mockID, name: "name"},
+					{slice: []string{"1.5", "10", ""}, isNull: []bool{false, false, false}, id: mockID, name: "score"},
+				},
+				colLevelNames: []string{"*0"}},
+			false},
+		{"pass - inferred positions with types and blank strings as null",
+			fields{RecordReader: RecordReader{HeaderRows: 1, InferTypes: true, BlankStringAsNull: true, Name: "foo"},
+				r: strings.NewReader("id   score\na      1.5\nb\nc       10\n")},
+			&DataFrame{
+				labels: []*valueContainer{{slice: []int{0, 1, 2}, isNull: []bool{false, false, false}, id: mockID, name: "*0"}},
+				values: []*valueContainer{
+					{slice: []string{"a", "b", "c"}, isNull: []bool{false, false, false}, cache: []string{"a", "b", "c"}, id: mockID, name: "id"},
+					{slice: []float64{1.5, 0, 10}, isNull: []bool{false, true, false}, cache: []string{"1.5", "", "10"}, id: mockID, name: "score"},
+				},
+				name:          "foo",
+				colLevelNames: []string{"*0"}},
+			false},
+		{"pass - column names without header rows",
+			fields{RecordReader: RecordReader{HeaderRows: 0, Schema: map[string]ColumnSchema{"qux": {DType: Float64}}},
+				Columns: []FixedWidthColumn{{Name: "bar", Start: 0, End: 1}, {Name: "baz", Start: 1, End: 4}, {Name: "qux", Start: 4, End: 8}},
+				r:       strings.NewReader(noHeader)},
+			&DataFrame{
+				labels: []*valueContainer{{slice: []int{0, 1}, isNull: []bool{false, false}, id: mockID, name: "*0"}},
+				values: []*valueContainer{
+					{slice: []string{"a", "b"}, isNull: []bool{false, false}, id: mockID, name: "bar"},
+					{slice: []string{"001", "002"}, isNull: []bool{false, false}, id: mockID, name: "baz"},
+					{slice: []float64{1.5, 10}, isNull: []bool{false, false}, id: mockID, name: "qux"},
+				},
+				colLevelNames: []string{"*0"}},
+			false},
+		{"pass - unnamed columns without header rows",
+			fields{RecordReader: RecordReader{HeaderRows: 0},
+				r: strings.NewReader(noHeader)},
+			&DataFrame{
+				labels: []*valueContainer{{slice: []int{0, 1}, isNull: []bool{false, false}, id: mockID, name: "*0"}},
+				values: []*valueContainer{
+					{slice: []string{"a001", "b002"}, isNull: []bool{false, false}, id: mockID, name: "0"},
+					{slice: []string{"1.5", "10"}, isNull: []bool{false, false}, id: mockID, name: "1"},
+				},
+				colLevelNames: []string{"*0"}},
+			false},
+		{"fail - invalid column position",
+			fields{RecordReader: RecordReader{HeaderRows: 1}, Columns: []FixedWidthColumn{{Start: 5, End: 5}}, r: strings.NewReader(data)},
+			nil, true},
+		{"fail - schema",
+			fields{RecordReader: RecordReader{HeaderRows: 1, Schema: map[string]ColumnSchema{"name": {DType: Float64}}},
+				Columns: []FixedWidthColumn{{Start: 0, End: 5}, {Start: 5, End: 15}}, r: strings.NewReader(data)},
+			nil, true},
+		{"fail - no lines",
+			fields{RecordReader: RecordReader{HeaderRows: 1}, r: strings.NewReader(" \n\n")},
+			nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &FixedWidthReader{
+				RecordReader: tt.fields.RecordReader,
+				Columns:      tt.fields.Columns,
+				r:            tt.fields.r,
+			}
+			got, err := r.Read()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("FixedWidthReader.Read() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !EqualDataFrames(got, tt.want) {
+				t.Errorf("FixedWidthReader.Read() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// tableTestDataFrame returns a DataFrame with repeated labels, multi-level columns, null values, and special characters.
+func tableTestDataFrame() *DataFrame {
+	return &DataFrame{
+		labels: []*valueContainer{
+			{slice: []string{"a", "a", "b"}, isNull: []bool{false, false, false}, id: mockID, name: "grp"},
+			{slice: []string{"x", "x", "x"}, isNull: []bool{false, false, false}, id: mockID, name: "*1"},
+		},
+		values: []*valueContainer{
+			{slice: []float64{1.234, 0, 3}, isNull: []bool{false, true, false}, id: mockID, name: "v|1"},
+			{slice: []int{1, 2, 3}, isNull: []bool{false, false, false}, id: mockID, name: "v|2"},
+			{slice: []string{"<b>", "a|b", "50%_&"}, isNull: []bool{false, false, false}, id: mockID, name: "w|x"},
+		},
+		name:          "foo & bar",
+		colLevelNames: []string{"*0", "*1"},
+	}
+}
+
+func TestMarkdownWriter_Write(t *testing.T) {
+	tests := []struct {
+		name    string
+		format  TableFormat
+		df      *DataFrame
+		want    string
+		wantErr bool
+	}{
+		{"pass - labels and number format",
+			TableFormat{IncludeLabels: true, MergeRepeats: true, NullString: "(null)", NumberFormats: map[string]string{"v|1": "%.2f"}},
+			tableTestDataFrame(),
+			"| grp |  | v\\|1 | v\\|2 | w\\|x |\n" +
+				"|---|---|---:|---:|---|\n" +
+				"| a | x | 1.23 | 1 | <b> |\n" +
+				"|  |  | (null) | 2 | a\\|b |\n" +
+				"| b | x | 3.00 | 3 | 50%_& |\n",
+			false},
+		{"pass - no labels",
+			TableFormat{NullString: "n/a"},
+			tableTestDataFrame(),
+			"| v\\|1 | v\\|2 | w\\|x |\n" +
+				"|---:|---:|---|\n" +
+				"| 1.234 | 1 | <b> |\n" +
+				"| n/a | 2 | a\\|b |\n" +
+				"| 3 | 3 | 50%_& |\n",
+			false},
+		{"fail - number format for missing column",
+			TableFormat{NumberFormats: map[string]string{"corge": "%.2f"}}, tableTestDataFrame(), "", true},
+		{"fail - DataFrame error",
+			TableFormat{}, &DataFrame{err: fmt.Errorf("foo")}, "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := new(bytes.Buffer)
+			w := &MarkdownWriter{TableFormat: tt.format, w: b}
+			err := w.Write(tt.df)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("MarkdownWriter.Write() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got := b.String(); got != tt.want {
+				t.Errorf("MarkdownWriter.Write() -> %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestHTMLWriter_Write(t *testing.T) {
+	tests := []struct {
+		name       string
+		format     TableFormat
+		class      string
+		labelClass string
+		nullClass  string
+		df         *DataFrame
+		want       string
+		wantErr    bool
+	}{
+		{"pass - merged labels and classes",
+			defaultTableFormat(), "table", "label", "null",
+			tableTestDataFrame(),
+			"<table class=\"table\">\n" +
+				"<caption>foo &amp; bar</caption>\n" +
+				"<thead>\n" +
+				"<tr><th></th><th></th><th colspan=\"2\">v</th><th>w</th></tr>\n" +
+				"<tr><th>grp</th><th></th><th>1</th><th>2</th><th>x</th></tr>\n" +
+				"</thead>\n" +
+				"<tbody>\n" +
+				"<tr><th rowspan=\"2\" class=\"label\">a</th><th rowspan=\"2\" class=\"label\">x</th><td>1.234</td><td>1</td><td>&lt;b&gt;</td></tr>\n" +
+				"<tr><td class=\"null\">(null)</td><td>2</td><td>a|b</td></tr>\n" +
+				"<tr><th class=\"label\">b</th><th class=\"label\">x</th><td>3</td><td>3</td><td>50%_&amp;</td></tr>\n" +
+				"</tbody>\n" +
+				"</table>\n",
+			false},
+		{"pass - no merging",
+			TableFormat{IncludeLabels: true, NullString: ""}, "", "", "",
+			&DataFrame{
+				labels:        []*valueContainer{{slice: []int{0, 0}, isNull: []bool{false, false}, id: mockID, name: "*0"}},
+				values:        []*valueContainer{{slice: []string{"foo", ""}, isNull: []bool{false, true}, id: mockID, name: "bar"}},
+				colLevelNames: []string{"*0"},
+			},
+			"<table>\n" +
+				"<thead>\n" +
+				"<tr><th></th><th>bar</th></tr>\n" +
+				"</thead>\n" +
+				"<tbody>\n" +
+				"<tr><th>0</th><td>foo</td></tr>\n" +
+				"<tr><th>0</th><td></td></tr>\n" +
+				"</tbody>\n" +
+				"</table>\n",
+			false},
+		{"fail - number format for missing column",
+			TableFormat{NumberFormats: map[string]string{"corge": "%.2f"}}, "", "", "", tableTestDataFrame(), "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := new(bytes.Buffer)
+			w := &HTMLWriter{TableFormat: tt.format, Class: tt.class, LabelClass: tt.labelClass, NullClass: tt.nullClass, w: b}
+			err := w.Write(tt.df)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("HTMLWriter.Write() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got := b.String(); got != tt.want {
+				t.Errorf("HTMLWriter.Write() -> %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLaTeXWriter_Write(t *testing.T) {
+	tests := []struct {
+		name    string
+		format  TableFormat
+		df      *DataFrame
+		want    string
+		wantErr bool
+	}{
+		{"pass - multicolumn and escaping",
+			defaultTableFormat(),
+			tableTestDataFrame(),
+			"\\begin{tabular}{ll|rrl}\n" +
+				"\\hline\n" +
+				" &  & \\multicolumn{2}{c}{v} & w \\\\\n" +
+				"grp &  & 1 & 2 & x \\\\\n" +
+				"\\hline\n" +
+				"a & x & 1.234 & 1 & <b> \\\\\n" +
+				" &  & (null) & 2 & a|b \\\\\n" +
+				"b & x & 3 & 3 & 50\\%\\_\\& \\\\\n" +
+				"\\hline\n" +
+				"\\end{tabular}\n",
+			false},
+		{"pass - no labels",
+			TableFormat{NumberFormats: map[string]string{"v|2": "%03d"}},
+			tableTestDataFrame(),
+			"\\begin{tabular}{rrl}\n" +
+				"\\hline\n" +
+				"\\multicolumn{2}{c}{v} & w \\\\\n" +
+				"1 & 2 & x \\\\\n" +
+				"\\hline\n" +
+				"1.234 & 001 & <b> \\\\\n" +
+				" & 002 & a|b \\\\\n" +
+				"3 & 003 & 50\\%\\_\\& \\\\\n" +
+				"\\hline\n" +
+				"\\end{tabular}\n",
+			false},
+		{"fail - DataFrame error",
+			TableFormat{}, &DataFrame{err: fmt.Errorf("foo")}, "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := new(bytes.Buffer)
+			w := &LaTeXWriter{TableFormat: tt.format, w: b}
+			err := w.Write(tt.df)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("LaTeXWriter.Write() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got := b.String(); got != tt.want {
+				t.Errorf("LaTeXWriter.Write() -> %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
 func TestDataFrame_EqualRecords(t *testing.T) {
 	type fields struct {
 		labels        []*valueContainer
@@ -943,6 +4181,8 @@ func Test_valueContainer_alias(t *testing.T) {
 				[]bool{false},
 				"foo",
 				"123",
+				"[]string",
+				"",
 			},
 		},
 	}
@@ -984,7 +4224,7 @@ func Test_valueContainer_MarshalJSON(t *testing.T) {
 				"foo",
 				"123",
 			},
-			[]byte(`{"slice":["foo"],"isNull":[false],"name":"foo","id":"123"}`),
+			[]byte(`{"slice":["foo"],"isNull":[false],"name":"foo","id":"123","dtype":"[]string"}`),
 			false,
 		},
 	}
@@ -16,6 +16,8 @@ var optionWrapLines = false
 var optionWarnings = true
 var optionNullStrings = &nullStrings{list: map[string]bool{optionsNullPrinter: true}}
 var optionNaNIsNull = true
+var optionInferIntAndBool = false
+var optionDecimalPlaces = 16
 var optionPrefix = "*"
 var optionDateTimeFormats = []string{
 	"2006-01-02", "01-02-2006", "01/02/2006", "1/2/06", "1/2/2006", "2006-01-02 15:04:05 -0700 MST",
@@ -86,6 +88,19 @@ func SetOptionNaNStatus(set bool) {
 	optionNaNIsNull = set
 }
 
+// SetOptionInferIntAndBool changes whether type inference reads integer strings as []int64
+// and "true"/"false" strings as []bool, instead of []float64 and []string, respectively (default: false).
+func SetOptionInferIntAndBool(set bool) {
+	optionInferIntAndBool = set
+}
+
+// SetOptionDecimalPlaces changes the number of decimal places to which a Decimal value is rounded
+// when it is formatted as a string and cannot be represented exactly, such as 1/3 (default: 16).
+// Values such as 1.25 are always formatted exactly.
+func SetOptionDecimalPlaces(n int) {
+	optionDecimalPlaces = n
+}
+
 // PrintOptionMaxRows changes the max number of rows displayed when printing a Series or DataFrame to n
 // (default: 50).
 func PrintOptionMaxRows(n int) {
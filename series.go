@@ -3,6 +3,8 @@ package tada
 import (
 	"fmt"
 	"log"
+	"math"
+	"math/big"
 	"math/rand"
 	"reflect"
 	"time"
@@ -129,7 +131,9 @@ func (s *Series) numLevels() int {
 }
 
 // Cast casts the underlying container values (either label levels or Series values) to
-// []float64, []string, []time.Time (aka timezone-aware DateTime), []civil.Date, or []civil.Time.
+// []float64, []string, []time.Time (aka timezone-aware DateTime), []civil.Date, []civil.Time, []int64, []bool, []Category (dictionary-encoded strings),
+// []*big.Rat (Decimal), or []time.Duration (Duration).
+// Duration strings may be Go durations (e.g., "1h30m") or ISO 8601 durations (e.g., "PT1H30M").
 // To apply to Series values, supply empty string name ("") or the Series name.
 // Use cast to improve performance when calling multiple operations on values.
 func (s *Series) Cast(containerAsType map[string]DType) {
@@ -188,6 +192,31 @@ func (s *SeriesMutator) Subset(index []int) error {
 	return nil
 }
 
+// Mask returns only the rows in which mask is true, in their original order.
+// mask is a boolean Series (e.g., the result of Gt), which is aligned with s using the labels in s as an anchor (as in Lookup).
+// Rows in which mask is null, or which do not align with any row in mask, are treated as false.
+// Returns a new Series.
+func (s *Series) Mask(mask *Series) *Series {
+	s = s.Copy()
+	err := s.InPlace().Mask(mask)
+	if err != nil {
+		return seriesWithError(err)
+	}
+	return s
+}
+
+// Mask returns only the rows in which mask is true, in their original order.
+// mask is a boolean Series (e.g., the result of Gt), which is aligned with s using the labels in s as an anchor (as in Lookup).
+// Rows in which mask is null, or which do not align with any row in mask, are treated as false.
+// Modifies the underlying Series in place.
+func (s *SeriesMutator) Mask(mask *Series) error {
+	index, err := maskIndex(s.series.labels, mask)
+	if err != nil {
+		return fmt.Errorf("masking rows: %v", err)
+	}
+	return s.Subset(index)
+}
+
 // SwapLabels swaps the label levels with names i and j.
 // Returns a new Series.
 func (s *Series) SwapLabels(i, j string) *Series {
@@ -885,10 +914,27 @@ func (s *Series) Merge(other *Series, options ...JoinOption) (*DataFrame, error)
 
 // Add coerces other and s to float64 values, aligns other with s, and adds the values in aligned rows,
 // using the labels in s as an anchor.
-// If ignoreNulls is true, then missing or null values are treated as 0.
+// If either s or other has []*big.Rat (Decimal) values, both are instead coerced to *big.Rat
+// and the result is calculated exactly.
+// If either s or other has []time.Time (DateTime) or []time.Duration (Duration) values,
+// adding a Duration to a DateTime returns DateTime values, and adding two Durations returns Duration values
+// (adding two DateTimes returns an error).
+// If ignoreNulls is true, then missing or null values are treated as 0 (but null DateTime values remain null).
 // Otherwise, if a row in s does not align with any row in other,
 // or if row does align but either value is null, then the resulting value is null.
 func (s *Series) Add(other *Series, ignoreNulls bool) *Series {
+	if s.values.isDecimal() || other.values.isDecimal() {
+		return s.combineDecimalMath(other, ignoreNulls, func(v1 *big.Rat, v2 *big.Rat) *big.Rat {
+			return new(big.Rat).Add(v1, v2)
+		})
+	}
+	if s.values.isDateTime() || other.values.isDateTime() || s.values.isDuration() || other.values.isDuration() {
+		ret, err := s.combineTimeMath(other, ignoreNulls, false)
+		if err != nil {
+			return seriesWithError(fmt.Errorf("Add(): %v", err))
+		}
+		return ret
+	}
 	fn := func(v1 float64, v2 float64) float64 {
 		return v1 + v2
 	}
@@ -898,10 +944,27 @@ func (s *Series) Add(other *Series, ignoreNulls bool) *Series {
 // Subtract coerces other and s to float64 values, aligns other with s,
 // and subtracts the aligned values of other from s,
 // using the labels in s as an anchor.
-// If ignoreNulls is true, then missing or null values are treated as 0.
+// If either s or other has []*big.Rat (Decimal) values, both are instead coerced to *big.Rat
+// and the result is calculated exactly.
+// If either s or other has []time.Time (DateTime) or []time.Duration (Duration) values,
+// subtracting two DateTimes returns Duration values, subtracting a Duration from a DateTime returns DateTime values,
+// and subtracting two Durations returns Duration values (subtracting a DateTime from a Duration returns an error).
+// If ignoreNulls is true, then missing or null values are treated as 0 (but null DateTime values remain null).
 // Otherwise, if a row in s does not align with any row in other,
 // or if row does align but either value is null, then the resulting value is null.
 func (s *Series) Subtract(other *Series, ignoreNulls bool) *Series {
+	if s.values.isDecimal() || other.values.isDecimal() {
+		return s.combineDecimalMath(other, ignoreNulls, func(v1 *big.Rat, v2 *big.Rat) *big.Rat {
+			return new(big.Rat).Sub(v1, v2)
+		})
+	}
+	if s.values.isDateTime() || other.values.isDateTime() || s.values.isDuration() || other.values.isDuration() {
+		ret, err := s.combineTimeMath(other, ignoreNulls, true)
+		if err != nil {
+			return seriesWithError(fmt.Errorf("Subtract(): %v", err))
+		}
+		return ret
+	}
 	fn := func(v1 float64, v2 float64) float64 {
 		return v1 - v2
 	}
@@ -910,10 +973,17 @@ func (s *Series) Subtract(other *Series, ignoreNulls bool) *Series {
 
 // Multiply coerces other and s to float64 values, aligns other with s, and multiplies the values in aligned rows,
 // using the labels in s as an anchor.
+// If either s or other has []*big.Rat (Decimal) values, both are instead coerced to *big.Rat
+// and the result is calculated exactly.
 // If ignoreNulls is true, then missing or null values are treated as 0.
 // Otherwise, if a row in s does not align with any row in other,
 // or if row does align but either value is null, then the resulting value is null.
 func (s *Series) Multiply(other *Series, ignoreNulls bool) *Series {
+	if s.values.isDecimal() || other.values.isDecimal() {
+		return s.combineDecimalMath(other, ignoreNulls, func(v1 *big.Rat, v2 *big.Rat) *big.Rat {
+			return new(big.Rat).Mul(v1, v2)
+		})
+	}
 	fn := func(v1 float64, v2 float64) float64 {
 		return v1 * v2
 	}
@@ -924,10 +994,20 @@ func (s *Series) Multiply(other *Series, ignoreNulls bool) *Series {
 // and divides the aligned values of s by s,
 // using the labels in s as an anchor.
 // Dividing by 0 always returns a null value.
+// If either s or other has []*big.Rat (Decimal) values, both are instead coerced to *big.Rat
+// and the result is calculated exactly (see SetOptionDecimalPlaces for how inexact quotients are formatted).
 // If ignoreNulls is true, then missing or null values are treated as 0.
 // Otherwise, if a row in s does not align with any row in other,
 // or if row does align but either value is null, then the resulting value is null.
 func (s *Series) Divide(other *Series, ignoreNulls bool) *Series {
+	if s.values.isDecimal() || other.values.isDecimal() {
+		return s.combineDecimalMath(other, ignoreNulls, func(v1 *big.Rat, v2 *big.Rat) *big.Rat {
+			if v2.Sign() == 0 {
+				return nil
+			}
+			return new(big.Rat).Quo(v1, v2)
+		})
+	}
 	fn := func(v1 float64, v2 float64) float64 {
 		defer func() {
 			recover()
@@ -937,6 +1017,176 @@ func (s *Series) Divide(other *Series, ignoreNulls bool) *Series {
 	return s.combineMath(other, ignoreNulls, fn)
 }
 
+// -- COMPARISONS
+
+// Gt returns a boolean Series that is true in each row in which the Series value is greater than other.
+// other may be a scalar (e.g., 5, "foo", or a time.Time) or a *Series.
+// If other is a Series, it is aligned with s using the labels in s as an anchor (as in Lookup).
+//
+// The values are compared as time.Time if either has time values (including civil dates and times),
+// as time.Duration if either has time.Duration values, as *big.Rat if either has *big.Rat values,
+// as int64 if both have integer values, as float64 if either has numeric or bool values, and as strings otherwise.
+// A row is null if either value is null or cannot be coerced, or if it does not align with any row in other.
+// Combine the results with And, Or, and Not, and select the true rows with Mask (which treats null rows as false).
+func (s *Series) Gt(other interface{}) *Series {
+	ret, err := s.compareFunc(other, func(cmp int) bool { return cmp > 0 })
+	if err != nil {
+		return seriesWithError(fmt.Errorf("Gt(): %v", err))
+	}
+	return ret
+}
+
+// Ge returns a boolean Series that is true in each row in which the Series value is greater than or equal to other.
+// other may be a scalar or a *Series, and values are compared and aligned as in Gt.
+func (s *Series) Ge(other interface{}) *Series {
+	ret, err := s.compareFunc(other, func(cmp int) bool { return cmp >= 0 })
+	if err != nil {
+		return seriesWithError(fmt.Errorf("Ge(): %v", err))
+	}
+	return ret
+}
+
+// Lt returns a boolean Series that is true in each row in which the Series value is less than other.
+// other may be a scalar or a *Series, and values are compared and aligned as in Gt.
+func (s *Series) Lt(other interface{}) *Series {
+	ret, err := s.compareFunc(other, func(cmp int) bool { return cmp < 0 })
+	if err != nil {
+		return seriesWithError(fmt.Errorf("Lt(): %v", err))
+	}
+	return ret
+}
+
+// Le returns a boolean Series that is true in each row in which the Series value is less than or equal to other.
+// other may be a scalar or a *Series, and values are compared and aligned as in Gt.
+func (s *Series) Le(other interface{}) *Series {
+	ret, err := s.compareFunc(other, func(cmp int) bool { return cmp <= 0 })
+	if err != nil {
+		return seriesWithError(fmt.Errorf("Le(): %v", err))
+	}
+	return ret
+}
+
+// Eq returns a boolean Series that is true in each row in which the Series value is equal to other.
+// other may be a scalar or a *Series, and values are compared and aligned as in Gt.
+func (s *Series) Eq(other interface{}) *Series {
+	ret, err := s.compareFunc(other, func(cmp int) bool { return cmp == 0 })
+	if err != nil {
+		return seriesWithError(fmt.Errorf("Eq(): %v", err))
+	}
+	return ret
+}
+
+// Ne returns a boolean Series that is true in each row in which the Series value is not equal to other.
+// other may be a scalar or a *Series, and values are compared and aligned as in Gt.
+func (s *Series) Ne(other interface{}) *Series {
+	ret, err := s.compareFunc(other, func(cmp int) bool { return cmp != 0 })
+	if err != nil {
+		return seriesWithError(fmt.Errorf("Ne(): %v", err))
+	}
+	return ret
+}
+
+// Between returns a boolean Series that is true in each row in which the Series value is
+// greater than or equal to lower and less than or equal to upper.
+// lower and upper may each be a scalar or a *Series, and values are compared and aligned as in Gt.
+func (s *Series) Between(lower, upper interface{}) *Series {
+	ret := s.Ge(lower).And(s.Le(upper))
+	if ret.err != nil {
+		return seriesWithError(fmt.Errorf("Between(): %v", ret.err))
+	}
+	return ret
+}
+
+// IsIn returns a boolean Series that is true in each row in which the Series value is equal to any of the values,
+// which must be a slice (e.g., []string{"foo", "bar"}). Values are compared as in Gt, and null values are ignored.
+// A row is null if the Series value is null.
+func (s *Series) IsIn(values interface{}) *Series {
+	if values == nil || reflect.TypeOf(values).Kind() != reflect.Slice {
+		return seriesWithError(fmt.Errorf("IsIn(): values must be a slice, not %T", values))
+	}
+	isNull := []bool{}
+	if reflect.ValueOf(values).Len() > 0 {
+		var err error
+		isNull, err = setNullsFromInterface(values)
+		if err != nil {
+			return seriesWithError(fmt.Errorf("IsIn(): %v", err))
+		}
+	}
+	retVals, retIsNull := s.values.isIn(&valueContainer{slice: values, isNull: isNull})
+	return &Series{
+		values: newValueContainer(retVals, retIsNull, s.values.name),
+		labels: copyContainers(s.labels),
+	}
+}
+
+// IsNullMask returns a boolean Series that is true in each row in which the Series value is null.
+// Unlike IsNull, which returns only the null rows, IsNullMask returns every row and may be combined with other masks.
+func (s *Series) IsNullMask() *Series {
+	return &Series{
+		values: newValueContainer(copyNulls(s.values.isNull), make([]bool, s.Len()), s.values.name),
+		labels: copyContainers(s.labels),
+	}
+}
+
+// And coerces s and other to bool, aligns other with s using the labels in s as an anchor,
+// and returns a boolean Series that is true in each row in which both values are true.
+// A row is false if either value is false, and otherwise null if either value is null or if it does not align with any row in other.
+func (s *Series) And(other *Series) *Series {
+	if s.err != nil {
+		return s
+	}
+	ret, err := s.combineLogic(other, func(v1, null1, v2, null2 bool) (bool, bool) {
+		if (!v1 && !null1) || (!v2 && !null2) {
+			return false, false
+		}
+		if null1 || null2 {
+			return false, true
+		}
+		return true, false
+	})
+	if err != nil {
+		return seriesWithError(fmt.Errorf("And(): %v", err))
+	}
+	return ret
+}
+
+// Or coerces s and other to bool, aligns other with s using the labels in s as an anchor,
+// and returns a boolean Series that is true in each row in which either value is true.
+// A row is true if either value is true, and otherwise null if either value is null or if it does not align with any row in other.
+func (s *Series) Or(other *Series) *Series {
+	if s.err != nil {
+		return s
+	}
+	ret, err := s.combineLogic(other, func(v1, null1, v2, null2 bool) (bool, bool) {
+		if (v1 && !null1) || (v2 && !null2) {
+			return true, false
+		}
+		if null1 || null2 {
+			return false, true
+		}
+		return false, false
+	})
+	if err != nil {
+		return seriesWithError(fmt.Errorf("Or(): %v", err))
+	}
+	return ret
+}
+
+// Not coerces the Series values to bool and returns a boolean Series with each value negated. Null values remain null.
+func (s *Series) Not() *Series {
+	vals := s.values.copy().bool()
+	retVals := make([]bool, s.Len())
+	for i := range retVals {
+		if !vals.isNull[i] {
+			retVals[i] = !vals.slice[i]
+		}
+	}
+	return &Series{
+		values: newValueContainer(retVals, vals.isNull, s.values.name),
+		labels: copyContainers(s.labels),
+	}
+}
+
 // -- GROUPERS
 
 // GroupBy groups the Series rows that share the same stringified value
@@ -1038,6 +1288,80 @@ func (s *Series) Max() float64 {
 	return s.floatFunc(max)
 }
 
+// SumInt64 coerces the Series values to int64 and sums them exactly.
+// Values that cannot be represented exactly as int64 are ignored. Returns 0 if all values are null.
+// Returns an error if the sum overflows int64.
+func (s *Series) SumInt64() (int64, error) {
+	vals := s.values.copy()
+	output, null := sumInt64(vals.int64().slice, vals.isNull, makeIntRange(0, s.Len()))
+	if null {
+		// sumInt64 returns null either if every value is null or if the sum overflows
+		for i := range vals.isNull {
+			if !vals.isNull[i] {
+				return 0, fmt.Errorf("summing int64 values: sum overflows int64")
+			}
+		}
+	}
+	return output, nil
+}
+
+// MinInt64 coerces the Series values to int64 and calculates the minimum.
+func (s *Series) MinInt64() int64 {
+	return s.int64Func(minInt64)
+}
+
+// MaxInt64 coerces the Series values to int64 and calculates the maximum.
+func (s *Series) MaxInt64() int64 {
+	return s.int64Func(maxInt64)
+}
+
+// SumDecimal coerces the Series values to *big.Rat and sums them exactly.
+// Returns 0 if all values are null.
+func (s *Series) SumDecimal() *big.Rat {
+	return s.decimalFunc(sumDecimal)
+}
+
+// MeanDecimal coerces the Series values to *big.Rat and calculates the exact mean.
+func (s *Series) MeanDecimal() *big.Rat {
+	return s.decimalFunc(meanDecimal)
+}
+
+// MinDecimal coerces the Series values to *big.Rat and calculates the minimum.
+func (s *Series) MinDecimal() *big.Rat {
+	return s.decimalFunc(minDecimal)
+}
+
+// MaxDecimal coerces the Series values to *big.Rat and calculates the maximum.
+func (s *Series) MaxDecimal() *big.Rat {
+	return s.decimalFunc(maxDecimal)
+}
+
+// SumDuration coerces the Series values to time.Duration and sums them.
+// Returns 0 if all values are null or if the sum overflows.
+func (s *Series) SumDuration() time.Duration {
+	return s.durationFunc(sumDuration)
+}
+
+// MeanDuration coerces the Series values to time.Duration and calculates the mean.
+func (s *Series) MeanDuration() time.Duration {
+	return s.durationFunc(meanDuration)
+}
+
+// MedianDuration coerces the Series values to time.Duration and calculates the median.
+func (s *Series) MedianDuration() time.Duration {
+	return s.durationFunc(medianDuration)
+}
+
+// MinDuration coerces the Series values to time.Duration and calculates the minimum.
+func (s *Series) MinDuration() time.Duration {
+	return s.durationFunc(minDuration)
+}
+
+// MaxDuration coerces the Series values to time.Duration and calculates the maximum.
+func (s *Series) MaxDuration() time.Duration {
+	return s.durationFunc(maxDuration)
+}
+
 // Earliest coerces the Series values to time.Time and calculates the earliest timestamp.
 func (s *Series) Earliest() time.Time {
 	return s.timeFunc(earliest)
@@ -1056,6 +1380,33 @@ func (s *Series) floatFunc(floatFunction func([]float64, []bool, []int) (float64
 	return output
 }
 
+func (s *Series) int64Func(int64Function func([]int64, []bool, []int) (int64, bool)) int64 {
+	vals := s.values.copy()
+	output, _ := int64Function(
+		vals.int64().slice,
+		vals.isNull,
+		makeIntRange(0, s.Len()))
+	return output
+}
+
+func (s *Series) decimalFunc(decimalFunction func([]*big.Rat, []bool, []int) (*big.Rat, bool)) *big.Rat {
+	vals := s.values.copy()
+	output, _ := decimalFunction(
+		vals.decimal().slice,
+		vals.isNull,
+		makeIntRange(0, s.Len()))
+	return output
+}
+
+func (s *Series) durationFunc(durationFunction func([]time.Duration, []bool, []int) (time.Duration, bool)) time.Duration {
+	vals := s.values.copy()
+	output, _ := durationFunction(
+		vals.duration().slice,
+		vals.isNull,
+		makeIntRange(0, s.Len()))
+	return output
+}
+
 func (s *Series) stringFunc(stringFunction func([]string, []bool, []int) (string, bool)) string {
 	output, _ := stringFunction(
 		s.values.string().slice,
@@ -1100,6 +1451,56 @@ func (s *SeriesMutator) Resample(by Resampler) {
 	return
 }
 
+// Localize coerces the Series values to time.Time and replaces the location of each value with loc, keeping the same wall clock
+// (e.g., 2020-01-01 12:00 UTC localized to America/New_York is 2020-01-01 12:00 EST).
+// Use Localize to attach a time zone to naive times, which are read as UTC by default. civil.Date values are localized to midnight in loc.
+// A wall clock time that occurs twice in loc (when clocks fall back) resolves to the earlier time,
+// and a wall clock time that does not occur in loc (when clocks spring forward) is shifted forward by the length of the gap.
+// If loc is nil, time.UTC is used.
+//
+// Returns a new Series.
+func (s *Series) Localize(loc *time.Location) *Series {
+	s = s.Copy()
+	s.InPlace().Localize(loc)
+	return s
+}
+
+// Localize coerces the Series values to time.Time and replaces the location of each value with loc, keeping the same wall clock
+// (e.g., 2020-01-01 12:00 UTC localized to America/New_York is 2020-01-01 12:00 EST).
+// Use Localize to attach a time zone to naive times, which are read as UTC by default. civil.Date values are localized to midnight in loc.
+// A wall clock time that occurs twice in loc (when clocks fall back) resolves to the earlier time,
+// and a wall clock time that does not occur in loc (when clocks spring forward) is shifted forward by the length of the gap.
+// If loc is nil, time.UTC is used.
+//
+// Modifies the underlying Series in place.
+func (s *SeriesMutator) Localize(loc *time.Location) {
+	s.series.values.localize(loc)
+	return
+}
+
+// ConvertTimeZone coerces the Series values to time.Time and converts each value to the same instant in loc
+// (e.g., 2020-01-01 12:00 UTC converted to America/New_York is 2020-01-01 07:00 EST).
+// Values that are coerced from civil.Date or strings without a time zone are treated as UTC.
+// If loc is nil, time.UTC is used.
+//
+// Returns a new Series.
+func (s *Series) ConvertTimeZone(loc *time.Location) *Series {
+	s = s.Copy()
+	s.InPlace().ConvertTimeZone(loc)
+	return s
+}
+
+// ConvertTimeZone coerces the Series values to time.Time and converts each value to the same instant in loc
+// (e.g., 2020-01-01 12:00 UTC converted to America/New_York is 2020-01-01 07:00 EST).
+// Values that are coerced from civil.Date or strings without a time zone are treated as UTC.
+// If loc is nil, time.UTC is used.
+//
+// Modifies the underlying Series in place.
+func (s *SeriesMutator) ConvertTimeZone(loc *time.Location) {
+	s.series.values.convertTimeZone(loc)
+	return
+}
+
 // CumSum coerces the Series values to float64 and returns the cumulative sum at each row position.
 func (s *Series) CumSum() *Series {
 	isNull := make([]bool, s.Len())
@@ -1156,6 +1557,40 @@ func (s *Series) Bin(bins []float64, config *Binner) (*Series, error) {
 	}, nil
 }
 
+// BinDuration coerces the Series values to time.Duration and categorizes each row based on which bin interval it falls within.
+// bins should be a slice of sequential edges that form intervals (left exclusive, right inclusive), as in Bin().
+// If no custom labels are supplied in config, bin labels are auto-generated from the bin intervals (e.g., "1h0m0s-2h0m0s").
+// For default behavior, supply nil as config.
+func (s *Series) BinDuration(bins []time.Duration, config *Binner) (*Series, error) {
+	if config == nil {
+		config = &Binner{}
+	}
+	labels := config.Labels
+	if len(labels) == 0 && len(bins) > 0 {
+		labels = durationBinLabels(bins, config.AndLess, config.AndMore)
+	}
+	floatBins := make([]float64, len(bins))
+	for i := range bins {
+		floatBins[i] = float64(bins[i])
+	}
+	durations := s.values.copy().duration()
+	// Series values are binned as nanoseconds
+	floatVals := make([]float64, len(durations.slice))
+	for i := range durations.slice {
+		floatVals[i] = float64(durations.slice[i])
+	}
+	retSlice, err := cut(floatVals, durations.isNull, floatBins, false, false, config.AndLess, config.AndMore, labels)
+	if err != nil {
+		return nil, fmt.Errorf("BinDuration(): %v", err)
+	}
+	// ducks error because values are []string
+	nulls, _ := setNullsFromInterface(retSlice)
+	return &Series{
+		values: newValueContainer(retSlice, nulls, s.values.name),
+		labels: s.labels,
+	}, nil
+}
+
 // Percentile coerces the Series values to float64 returns the percentile rank of each value.
 // Uses the "exclusive" definition: a value's percentile is the % of all non-null values in the Series (including itself) that are below it.
 func (s *Series) Percentile() *Series {
@@ -1201,6 +1636,102 @@ func (s *Series) PercentileBin(bins []float64, config *Binner) (*Series, error)
 	}, nil
 }
 
+// AddScalar coerces the Series values to float64 and adds c to each value.
+// If the Series has []*big.Rat (Decimal) values, c is instead coerced to *big.Rat and the result is calculated exactly.
+// Null values remain null. Returns a new Series.
+func (s *Series) AddScalar(c float64) *Series {
+	return s.scalarMath(c,
+		func(v float64, c float64) float64 { return v + c },
+		func(v *big.Rat, c *big.Rat) *big.Rat { return new(big.Rat).Add(v, c) })
+}
+
+// SubtractScalar coerces the Series values to float64 and subtracts c from each value.
+// If the Series has []*big.Rat (Decimal) values, c is instead coerced to *big.Rat and the result is calculated exactly.
+// Null values remain null. Returns a new Series.
+func (s *Series) SubtractScalar(c float64) *Series {
+	return s.scalarMath(c,
+		func(v float64, c float64) float64 { return v - c },
+		func(v *big.Rat, c *big.Rat) *big.Rat { return new(big.Rat).Sub(v, c) })
+}
+
+// MultiplyScalar coerces the Series values to float64 and multiplies each value by c.
+// If the Series has []*big.Rat (Decimal) values, c is instead coerced to *big.Rat and the result is calculated exactly.
+// Null values remain null. Returns a new Series.
+func (s *Series) MultiplyScalar(c float64) *Series {
+	return s.scalarMath(c,
+		func(v float64, c float64) float64 { return v * c },
+		func(v *big.Rat, c *big.Rat) *big.Rat { return new(big.Rat).Mul(v, c) })
+}
+
+// DivideScalar coerces the Series values to float64 and divides each value by c.
+// Dividing by 0 always returns null values.
+// If the Series has []*big.Rat (Decimal) values, c is instead coerced to *big.Rat and the result is calculated exactly.
+// Null values remain null. Returns a new Series.
+func (s *Series) DivideScalar(c float64) *Series {
+	return s.scalarMath(c,
+		func(v float64, c float64) float64 { return v / c },
+		func(v *big.Rat, c *big.Rat) *big.Rat {
+			if c.Sign() == 0 {
+				return nil
+			}
+			return new(big.Rat).Quo(v, c)
+		})
+}
+
+// Abs coerces the Series values to float64 and returns the absolute value of each value.
+// If the Series has []*big.Rat (Decimal) values, the result is calculated exactly.
+// Null values remain null. Returns a new Series.
+func (s *Series) Abs() *Series {
+	var ret *valueContainer
+	if s.values.isDecimal() {
+		ret = s.values.decimalMath(func(v *big.Rat) *big.Rat { return new(big.Rat).Abs(v) })
+	} else {
+		ret = s.values.floatMath(math.Abs)
+	}
+	return &Series{values: ret, labels: copyContainers(s.labels)}
+}
+
+// Log coerces the Series values to float64 and returns the natural logarithm of each value.
+// Values that are 0 or negative become null, as do null values. Returns a new Series.
+func (s *Series) Log() *Series {
+	return &Series{values: s.values.floatMath(math.Log), labels: copyContainers(s.labels)}
+}
+
+// Exp coerces the Series values to float64 and returns e raised to the power of each value.
+// Results that overflow become null, as do null values. Returns a new Series.
+func (s *Series) Exp() *Series {
+	return &Series{values: s.values.floatMath(math.Exp), labels: copyContainers(s.labels)}
+}
+
+// Sqrt coerces the Series values to float64 and returns the square root of each value.
+// Negative values become null, as do null values. Returns a new Series.
+func (s *Series) Sqrt() *Series {
+	return &Series{values: s.values.floatMath(math.Sqrt), labels: copyContainers(s.labels)}
+}
+
+// Pow coerces the Series values to float64 and raises each value to the power of exp.
+// Results that are undefined or overflow become null, as do null values. Returns a new Series.
+func (s *Series) Pow(exp float64) *Series {
+	fn := func(v float64) float64 {
+		return math.Pow(v, exp)
+	}
+	return &Series{values: s.values.floatMath(fn), labels: copyContainers(s.labels)}
+}
+
+// Round coerces the Series values to float64 and rounds each value to the specified number of decimal places,
+// rounding half away from zero. If decimals is negative, values are rounded to the left of the decimal point (e.g., -2 rounds to the nearest 100).
+// If the Series has []*big.Rat (Decimal) values, the result is calculated exactly.
+// Null values remain null. Returns a new Series.
+func (s *Series) Round(decimals int) *Series {
+	var ret *valueContainer
+	if s.values.isDecimal() {
+		ret = s.values.decimalMath(func(v *big.Rat) *big.Rat { return roundDecimal(v, decimals) })
+	} else {
+		ret = s.values.floatMath(func(v float64) float64 { return roundFloat(v, decimals) })
+	}
+	return &Series{values: ret, labels: copyContainers(s.labels)}
+}
+
 // -- Slicers
 
 // GetValuesAsFloat64 coerces the Series values into []float64.
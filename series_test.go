@@ -5,12 +5,15 @@ import (
 	"errors"
 	"fmt"
 	"log"
+	"math"
+	"math/big"
 	"os"
 	"reflect"
 	"strings"
 	"testing"
 	"time"
 
+	"cloud.google.com/go/civil"
 	"github.com/ptiger10/tablediff"
 )
 
@@ -1683,6 +1686,74 @@ func TestSeries_Add(t *testing.T) {
 						cache: []string{"0", "1"},
 					}}},
 		},
+		{"decimal - exact - ignore missing",
+			fields{
+				values: &valueContainer{slice: []*big.Rat{mockDecimal("0.1"), mockDecimal("0.2")}, isNull: []bool{false, false}},
+				labels: []*valueContainer{{slice: []int{0, 1}, isNull: []bool{false, false}, id: mockID}}},
+			args{
+				other: &Series{values: &valueContainer{slice: []string{"0.7", "10"}, isNull: []bool{false, false}},
+					labels: []*valueContainer{{slice: []int{1, 10}, isNull: []bool{false, false}, id: mockID}}},
+				ignoreMissing: true},
+			&Series{
+				values: &valueContainer{slice: []*big.Rat{mockDecimal("0.1"), mockDecimal("0.9")}, isNull: []bool{false, false}, id: mockID},
+				labels: []*valueContainer{
+					{slice: []int{0, 1}, isNull: []bool{false, false}, id: mockID,
+						cache: []string{"0", "1"},
+					}}},
+		},
+		{"datetime + duration returns datetime - missing duration as 0",
+			fields{
+				values: &valueContainer{slice: []time.Time{time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)},
+					isNull: []bool{false, false}},
+				labels: []*valueContainer{{slice: []int{0, 1}, isNull: []bool{false, false}, id: mockID}}},
+			args{
+				other: &Series{values: &valueContainer{slice: []string{"1h30m", "PT1H"}, isNull: []bool{false, false}},
+					labels: []*valueContainer{{slice: []int{0, 10}, isNull: []bool{false, false}, id: mockID}}},
+				ignoreMissing: true},
+			&Series{
+				values: &valueContainer{slice: []time.Time{time.Date(2020, 1, 1, 1, 30, 0, 0, time.UTC), time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)},
+					isNull: []bool{false, false}, id: mockID},
+				labels: []*valueContainer{
+					{slice: []int{0, 1}, isNull: []bool{false, false}, id: mockID,
+						cache: []string{"0", "1"},
+					}}},
+		},
+		{"duration + datetime returns datetime",
+			fields{
+				values: &valueContainer{slice: []time.Duration{time.Hour, time.Minute}, isNull: []bool{false, true}},
+				labels: []*valueContainer{{slice: []int{0, 1}, isNull: []bool{false, false}, id: mockID}}},
+			args{
+				other: &Series{values: &valueContainer{slice: []time.Time{time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)},
+					isNull: []bool{false, false}},
+					labels: []*valueContainer{{slice: []int{0, 1}, isNull: []bool{false, false}, id: mockID}}},
+				ignoreMissing: true},
+			&Series{
+				values: &valueContainer{slice: []time.Time{time.Date(2020, 1, 1, 1, 0, 0, 0, time.UTC), time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)},
+					isNull: []bool{false, false}, id: mockID},
+				labels: []*valueContainer{{slice: []int{0, 1}, isNull: []bool{false, false}, id: mockID}}},
+		},
+		{"duration + duration",
+			fields{
+				values: &valueContainer{slice: []time.Duration{time.Hour, time.Minute}, isNull: []bool{false, false}},
+				labels: []*valueContainer{{slice: []int{0, 1}, isNull: []bool{false, false}, id: mockID}}},
+			args{
+				other: &Series{values: &valueContainer{slice: []time.Duration{time.Hour, time.Minute}, isNull: []bool{false, true}},
+					labels: []*valueContainer{{slice: []int{0, 1}, isNull: []bool{false, false}, id: mockID}}},
+				ignoreMissing: false},
+			&Series{
+				values: &valueContainer{slice: []time.Duration{2 * time.Hour, 0}, isNull: []bool{false, true}, id: mockID},
+				labels: []*valueContainer{{slice: []int{0, 1}, isNull: []bool{false, false}, id: mockID}}},
+		},
+		{"fail - datetime + datetime",
+			fields{
+				values: &valueContainer{slice: []time.Time{{}}, isNull: []bool{false}},
+				labels: []*valueContainer{{slice: []int{0}, isNull: []bool{false}, id: mockID}}},
+			args{
+				other: &Series{values: &valueContainer{slice: []time.Time{{}}, isNull: []bool{false}},
+					labels: []*valueContainer{{slice: []int{0}, isNull: []bool{false}, id: mockID}}},
+				ignoreMissing: true},
+			&Series{err: fmt.Errorf("Add(): cannot add two DateTime values")},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -1744,6 +1815,30 @@ func TestSeries_Subtract(t *testing.T) {
 						cache: []string{"0", "1"},
 					}}},
 		},
+		{"datetime - datetime returns duration",
+			fields{
+				values: &valueContainer{slice: []time.Time{time.Date(2020, 1, 2, 12, 0, 0, 0, time.UTC), {}}, isNull: []bool{false, true}},
+				labels: []*valueContainer{{slice: []int{0, 1}, isNull: []bool{false, false}, id: mockID}}},
+			args{
+				other: &Series{values: &valueContainer{
+					slice:  []time.Time{time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)},
+					isNull: []bool{false, false}},
+					labels: []*valueContainer{{slice: []int{0, 1}, isNull: []bool{false, false}, id: mockID}}},
+				ignoreMissing: true},
+			&Series{
+				values: &valueContainer{slice: []time.Duration{36 * time.Hour, 0}, isNull: []bool{false, true}, id: mockID},
+				labels: []*valueContainer{{slice: []int{0, 1}, isNull: []bool{false, false}, id: mockID}}},
+		},
+		{"fail - duration - datetime",
+			fields{
+				values: &valueContainer{slice: []time.Duration{time.Hour}, isNull: []bool{false}},
+				labels: []*valueContainer{{slice: []int{0}, isNull: []bool{false}, id: mockID}}},
+			args{
+				other: &Series{values: &valueContainer{slice: []time.Time{{}}, isNull: []bool{false}},
+					labels: []*valueContainer{{slice: []int{0}, isNull: []bool{false}, id: mockID}}},
+				ignoreMissing: true},
+			&Series{err: fmt.Errorf("Subtract(): cannot subtract a DateTime value from a Duration value")},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -1833,39 +1928,800 @@ func TestSeries_Divide(t *testing.T) {
 	tests := []struct {
 		name   string
 		fields fields
-		args   args
-		want   *Series
+		args   args
+		want   *Series
+	}{
+		{"ignore missing",
+			fields{
+				values: &valueContainer{slice: []float64{1, 2}, isNull: []bool{false, false}},
+				labels: []*valueContainer{{slice: []int{0, 1}, isNull: []bool{false, false}, id: mockID}}},
+			args{
+				other: &Series{values: &valueContainer{slice: []float64{4, 10}, isNull: []bool{false, false}},
+					labels: []*valueContainer{{slice: []int{1, 10}, isNull: []bool{false, false}, id: mockID}}},
+				ignoreNulls: true},
+			&Series{
+				values: &valueContainer{slice: []float64{1, .5}, isNull: []bool{false, false}, id: mockID},
+				labels: []*valueContainer{
+					{slice: []int{0, 1}, isNull: []bool{false, false},
+						cache: []string{"0", "1"}, id: mockID,
+					}}},
+		},
+		{"missing as null - divide by 0",
+			fields{
+				values: &valueContainer{slice: []float64{1, 2, 3}, isNull: []bool{false, false, false}},
+				labels: []*valueContainer{{slice: []int{0, 1, 2}, isNull: []bool{false, false, false}, id: mockID}}},
+			args{
+				other: &Series{values: &valueContainer{slice: []float64{0, 2, 10}, isNull: []bool{false, false, false}},
+					labels: []*valueContainer{{slice: []int{0, 1, 10}, isNull: []bool{false, false, false}, id: mockID}}},
+				ignoreNulls: false},
+			&Series{
+				values: &valueContainer{slice: []float64{0, 1, 0}, isNull: []bool{true, false, true}, id: mockID},
+				labels: []*valueContainer{
+					{slice: []int{0, 1, 2}, isNull: []bool{false, false, false},
+						cache: []string{"0", "1", "2"}, id: mockID,
+					}}},
+		},
+		{"decimal - exact - divide by 0",
+			fields{
+				values: &valueContainer{slice: []*big.Rat{mockDecimal("1"), mockDecimal("0.3"), mockDecimal("3")}, isNull: []bool{false, false, false}},
+				labels: []*valueContainer{{slice: []int{0, 1, 2}, isNull: []bool{false, false, false}, id: mockID}}},
+			args{
+				other: &Series{values: &valueContainer{slice: []float64{3, 0.1, 0}, isNull: []bool{false, false, false}},
+					labels: []*valueContainer{{slice: []int{0, 1, 2}, isNull: []bool{false, false, false}, id: mockID}}},
+				ignoreNulls: false},
+			&Series{
+				values: &valueContainer{slice: []*big.Rat{big.NewRat(1, 3), mockDecimal("3"), new(big.Rat)}, isNull: []bool{false, false, true}, id: mockID},
+				labels: []*valueContainer{
+					{slice: []int{0, 1, 2}, isNull: []bool{false, false, false}, id: mockID}}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &Series{
+				values: tt.fields.values,
+				labels: tt.fields.labels,
+				err:    tt.fields.err,
+			}
+			if got := s.Divide(tt.args.other, tt.args.ignoreNulls); !EqualSeries(got, tt.want) {
+				t.Errorf("Series.Divide() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSeries_AddScalar(t *testing.T) {
+	type fields struct {
+		values *valueContainer
+		labels []*valueContainer
+		err    error
+	}
+	type args struct {
+		c float64
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		want   *Series
+	}{
+		{"float", fields{
+			values: &valueContainer{slice: []float64{1, 2}, isNull: []bool{false, true}, name: "foo"},
+			labels: []*valueContainer{{slice: []int{0, 1}, isNull: []bool{false, false}, id: mockID}}},
+			args{10},
+			&Series{
+				values: &valueContainer{slice: []float64{11, 0}, isNull: []bool{false, true}, id: mockID, name: "foo"},
+				labels: []*valueContainer{{slice: []int{0, 1}, isNull: []bool{false, false}, id: mockID}}},
+		},
+		{"int coerced to float", fields{
+			values: &valueContainer{slice: []int{1, 2}, isNull: []bool{false, false}, name: "foo"},
+			labels: []*valueContainer{{slice: []int{0, 1}, isNull: []bool{false, false}, id: mockID}}},
+			args{0.5},
+			&Series{
+				values: &valueContainer{slice: []float64{1.5, 2.5}, isNull: []bool{false, false}, id: mockID, name: "foo"},
+				labels: []*valueContainer{{slice: []int{0, 1}, isNull: []bool{false, false}, id: mockID}}},
+		},
+		{"decimal - exact", fields{
+			values: &valueContainer{slice: []*big.Rat{mockDecimal("0.1"), mockDecimal("0.2")}, isNull: []bool{false, false}, name: "foo"},
+			labels: []*valueContainer{{slice: []int{0, 1}, isNull: []bool{false, false}, id: mockID}}},
+			args{0.2},
+			&Series{
+				values: &valueContainer{slice: []*big.Rat{mockDecimal("0.3"), mockDecimal("0.4")}, isNull: []bool{false, false}, id: mockID, name: "foo"},
+				labels: []*valueContainer{{slice: []int{0, 1}, isNull: []bool{false, false}, id: mockID}}},
+		},
+		{"NaN is null", fields{
+			values: &valueContainer{slice: []float64{1, 2}, isNull: []bool{false, false}, name: "foo"},
+			labels: []*valueContainer{{slice: []int{0, 1}, isNull: []bool{false, false}, id: mockID}}},
+			args{math.NaN()},
+			&Series{
+				values: &valueContainer{slice: []float64{0, 0}, isNull: []bool{true, true}, id: mockID, name: "foo"},
+				labels: []*valueContainer{{slice: []int{0, 1}, isNull: []bool{false, false}, id: mockID}}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &Series{
+				values: tt.fields.values,
+				labels: tt.fields.labels,
+				err:    tt.fields.err,
+			}
+			if got := s.AddScalar(tt.args.c); !EqualSeries(got, tt.want) {
+				t.Errorf("Series.AddScalar() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSeries_SubtractScalar(t *testing.T) {
+	type fields struct {
+		values *valueContainer
+		labels []*valueContainer
+		err    error
+	}
+	type args struct {
+		c float64
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		want   *Series
+	}{
+		{"float", fields{
+			values: &valueContainer{slice: []float64{1, 2}, isNull: []bool{false, false}, name: "foo"},
+			labels: []*valueContainer{{slice: []int{0, 1}, isNull: []bool{false, false}, id: mockID}}},
+			args{1.5},
+			&Series{
+				values: &valueContainer{slice: []float64{-0.5, 0.5}, isNull: []bool{false, false}, id: mockID, name: "foo"},
+				labels: []*valueContainer{{slice: []int{0, 1}, isNull: []bool{false, false}, id: mockID}}},
+		},
+		{"decimal - exact", fields{
+			values: &valueContainer{slice: []*big.Rat{mockDecimal("0.3"), new(big.Rat)}, isNull: []bool{false, true}, name: "foo"},
+			labels: []*valueContainer{{slice: []int{0, 1}, isNull: []bool{false, false}, id: mockID}}},
+			args{0.1},
+			&Series{
+				values: &valueContainer{slice: []*big.Rat{mockDecimal("0.2"), new(big.Rat)}, isNull: []bool{false, true}, id: mockID, name: "foo"},
+				labels: []*valueContainer{{slice: []int{0, 1}, isNull: []bool{false, false}, id: mockID}}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &Series{
+				values: tt.fields.values,
+				labels: tt.fields.labels,
+				err:    tt.fields.err,
+			}
+			if got := s.SubtractScalar(tt.args.c); !EqualSeries(got, tt.want) {
+				t.Errorf("Series.SubtractScalar() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSeries_MultiplyScalar(t *testing.T) {
+	type fields struct {
+		values *valueContainer
+		labels []*valueContainer
+		err    error
+	}
+	type args struct {
+		c float64
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		want   *Series
+	}{
+		{"float", fields{
+			values: &valueContainer{slice: []float64{0.5, 2}, isNull: []bool{false, false}, name: "foo"},
+			labels: []*valueContainer{{slice: []int{0, 1}, isNull: []bool{false, false}, id: mockID}}},
+			args{100},
+			&Series{
+				values: &valueContainer{slice: []float64{50, 200}, isNull: []bool{false, false}, id: mockID, name: "foo"},
+				labels: []*valueContainer{{slice: []int{0, 1}, isNull: []bool{false, false}, id: mockID}}},
+		},
+		{"decimal - exact", fields{
+			values: &valueContainer{slice: []*big.Rat{mockDecimal("0.1"), mockDecimal("3")}, isNull: []bool{false, false}, name: "foo"},
+			labels: []*valueContainer{{slice: []int{0, 1}, isNull: []bool{false, false}, id: mockID}}},
+			args{3},
+			&Series{
+				values: &valueContainer{slice: []*big.Rat{mockDecimal("0.3"), mockDecimal("9")}, isNull: []bool{false, false}, id: mockID, name: "foo"},
+				labels: []*valueContainer{{slice: []int{0, 1}, isNull: []bool{false, false}, id: mockID}}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &Series{
+				values: tt.fields.values,
+				labels: tt.fields.labels,
+				err:    tt.fields.err,
+			}
+			if got := s.MultiplyScalar(tt.args.c); !EqualSeries(got, tt.want) {
+				t.Errorf("Series.MultiplyScalar() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSeries_DivideScalar(t *testing.T) {
+	type fields struct {
+		values *valueContainer
+		labels []*valueContainer
+		err    error
+	}
+	type args struct {
+		c float64
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		want   *Series
+	}{
+		{"float", fields{
+			values: &valueContainer{slice: []float64{1, 2}, isNull: []bool{false, false}, name: "foo"},
+			labels: []*valueContainer{{slice: []int{0, 1}, isNull: []bool{false, false}, id: mockID}}},
+			args{4},
+			&Series{
+				values: &valueContainer{slice: []float64{0.25, 0.5}, isNull: []bool{false, false}, id: mockID, name: "foo"},
+				labels: []*valueContainer{{slice: []int{0, 1}, isNull: []bool{false, false}, id: mockID}}},
+		},
+		{"float - divide by 0", fields{
+			values: &valueContainer{slice: []float64{1, 0}, isNull: []bool{false, false}, name: "foo"},
+			labels: []*valueContainer{{slice: []int{0, 1}, isNull: []bool{false, false}, id: mockID}}},
+			args{0},
+			&Series{
+				values: &valueContainer{slice: []float64{0, 0}, isNull: []bool{true, true}, id: mockID, name: "foo"},
+				labels: []*valueContainer{{slice: []int{0, 1}, isNull: []bool{false, false}, id: mockID}}},
+		},
+		{"decimal - exact", fields{
+			values: &valueContainer{slice: []*big.Rat{mockDecimal("1"), mockDecimal("0.3")}, isNull: []bool{false, false}, name: "foo"},
+			labels: []*valueContainer{{slice: []int{0, 1}, isNull: []bool{false, false}, id: mockID}}},
+			args{3},
+			&Series{
+				values: &valueContainer{slice: []*big.Rat{big.NewRat(1, 3), mockDecimal("0.1")}, isNull: []bool{false, false}, id: mockID, name: "foo"},
+				labels: []*valueContainer{{slice: []int{0, 1}, isNull: []bool{false, false}, id: mockID}}},
+		},
+		{"decimal - divide by 0", fields{
+			values: &valueContainer{slice: []*big.Rat{mockDecimal("1")}, isNull: []bool{false}, name: "foo"},
+			labels: []*valueContainer{{slice: []int{0}, isNull: []bool{false}, id: mockID}}},
+			args{0},
+			&Series{
+				values: &valueContainer{slice: []*big.Rat{new(big.Rat)}, isNull: []bool{true}, id: mockID, name: "foo"},
+				labels: []*valueContainer{{slice: []int{0}, isNull: []bool{false}, id: mockID}}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &Series{
+				values: tt.fields.values,
+				labels: tt.fields.labels,
+				err:    tt.fields.err,
+			}
+			if got := s.DivideScalar(tt.args.c); !EqualSeries(got, tt.want) {
+				t.Errorf("Series.DivideScalar() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSeries_Abs(t *testing.T) {
+	type fields struct {
+		values *valueContainer
+		labels []*valueContainer
+		err    error
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		want   *Series
+	}{
+		{"float", fields{
+			values: &valueContainer{slice: []float64{-1.5, 2, -3}, isNull: []bool{false, false, true}, name: "foo"},
+			labels: []*valueContainer{{slice: []int{0, 1, 2}, isNull: []bool{false, false, false}, id: mockID}}},
+			&Series{
+				values: &valueContainer{slice: []float64{1.5, 2, 0}, isNull: []bool{false, false, true}, id: mockID, name: "foo"},
+				labels: []*valueContainer{{slice: []int{0, 1, 2}, isNull: []bool{false, false, false}, id: mockID}}},
+		},
+		{"decimal", fields{
+			values: &valueContainer{slice: []*big.Rat{mockDecimal("-0.1"), mockDecimal("2")}, isNull: []bool{false, false}, name: "foo"},
+			labels: []*valueContainer{{slice: []int{0, 1}, isNull: []bool{false, false}, id: mockID}}},
+			&Series{
+				values: &valueContainer{slice: []*big.Rat{mockDecimal("0.1"), mockDecimal("2")}, isNull: []bool{false, false}, id: mockID, name: "foo"},
+				labels: []*valueContainer{{slice: []int{0, 1}, isNull: []bool{false, false}, id: mockID}}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &Series{
+				values: tt.fields.values,
+				labels: tt.fields.labels,
+				err:    tt.fields.err,
+			}
+			if got := s.Abs(); !EqualSeries(got, tt.want) {
+				t.Errorf("Series.Abs() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSeries_Log(t *testing.T) {
+	type fields struct {
+		values *valueContainer
+		labels []*valueContainer
+		err    error
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		want   *Series
+	}{
+		{"pass", fields{
+			values: &valueContainer{slice: []float64{1, math.E, 0, -1, 1}, isNull: []bool{false, false, false, false, true}, name: "foo"},
+			labels: []*valueContainer{{slice: []int{0, 1, 2, 3, 4}, isNull: []bool{false, false, false, false, false}, id: mockID}}},
+			&Series{
+				values: &valueContainer{slice: []float64{0, 1, 0, 0, 0}, isNull: []bool{false, false, true, true, true}, id: mockID, name: "foo"},
+				labels: []*valueContainer{{slice: []int{0, 1, 2, 3, 4}, isNull: []bool{false, false, false, false, false}, id: mockID}}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &Series{
+				values: tt.fields.values,
+				labels: tt.fields.labels,
+				err:    tt.fields.err,
+			}
+			if got := s.Log(); !EqualSeries(got, tt.want) {
+				t.Errorf("Series.Log() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSeries_Exp(t *testing.T) {
+	type fields struct {
+		values *valueContainer
+		labels []*valueContainer
+		err    error
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		want   *Series
+	}{
+		{"pass", fields{
+			values: &valueContainer{slice: []float64{0, 1, 1000}, isNull: []bool{false, false, false}, name: "foo"},
+			labels: []*valueContainer{{slice: []int{0, 1, 2}, isNull: []bool{false, false, false}, id: mockID}}},
+			&Series{
+				values: &valueContainer{slice: []float64{1, math.E, 0}, isNull: []bool{false, false, true}, id: mockID, name: "foo"},
+				labels: []*valueContainer{{slice: []int{0, 1, 2}, isNull: []bool{false, false, false}, id: mockID}}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &Series{
+				values: tt.fields.values,
+				labels: tt.fields.labels,
+				err:    tt.fields.err,
+			}
+			if got := s.Exp(); !EqualSeries(got, tt.want) {
+				t.Errorf("Series.Exp() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSeries_Sqrt(t *testing.T) {
+	type fields struct {
+		values *valueContainer
+		labels []*valueContainer
+		err    error
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		want   *Series
+	}{
+		{"pass", fields{
+			values: &valueContainer{slice: []string{"4", "-1", "foo"}, isNull: []bool{false, false, false}, name: "foo"},
+			labels: []*valueContainer{{slice: []int{0, 1, 2}, isNull: []bool{false, false, false}, id: mockID}}},
+			&Series{
+				values: &valueContainer{slice: []float64{2, 0, 0}, isNull: []bool{false, true, true}, id: mockID, name: "foo"},
+				labels: []*valueContainer{{slice: []int{0, 1, 2}, isNull: []bool{false, false, false}, id: mockID}}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &Series{
+				values: tt.fields.values,
+				labels: tt.fields.labels,
+				err:    tt.fields.err,
+			}
+			if got := s.Sqrt(); !EqualSeries(got, tt.want) {
+				t.Errorf("Series.Sqrt() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSeries_Pow(t *testing.T) {
+	type fields struct {
+		values *valueContainer
+		labels []*valueContainer
+		err    error
+	}
+	type args struct {
+		exp float64
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		want   *Series
+	}{
+		{"positive", fields{
+			values: &valueContainer{slice: []float64{2, -3}, isNull: []bool{false, false}, name: "foo"},
+			labels: []*valueContainer{{slice: []int{0, 1}, isNull: []bool{false, false}, id: mockID}}},
+			args{3},
+			&Series{
+				values: &valueContainer{slice: []float64{8, -27}, isNull: []bool{false, false}, id: mockID, name: "foo"},
+				labels: []*valueContainer{{slice: []int{0, 1}, isNull: []bool{false, false}, id: mockID}}},
+		},
+		{"undefined", fields{
+			values: &valueContainer{slice: []float64{0, -4}, isNull: []bool{false, false}, name: "foo"},
+			labels: []*valueContainer{{slice: []int{0, 1}, isNull: []bool{false, false}, id: mockID}}},
+			args{-0.5},
+			&Series{
+				values: &valueContainer{slice: []float64{0, 0}, isNull: []bool{true, true}, id: mockID, name: "foo"},
+				labels: []*valueContainer{{slice: []int{0, 1}, isNull: []bool{false, false}, id: mockID}}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &Series{
+				values: tt.fields.values,
+				labels: tt.fields.labels,
+				err:    tt.fields.err,
+			}
+			if got := s.Pow(tt.args.exp); !EqualSeries(got, tt.want) {
+				t.Errorf("Series.Pow() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSeries_Round(t *testing.T) {
+	type fields struct {
+		values *valueContainer
+		labels []*valueContainer
+		err    error
+	}
+	type args struct {
+		decimals int
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		want   *Series
+	}{
+		{"float", fields{
+			values: &valueContainer{slice: []float64{1.25, -1.25, 1.24}, isNull: []bool{false, false, false}, name: "foo"},
+			labels: []*valueContainer{{slice: []int{0, 1, 2}, isNull: []bool{false, false, false}, id: mockID}}},
+			args{1},
+			&Series{
+				values: &valueContainer{slice: []float64{1.3, -1.3, 1.2}, isNull: []bool{false, false, false}, id: mockID, name: "foo"},
+				labels: []*valueContainer{{slice: []int{0, 1, 2}, isNull: []bool{false, false, false}, id: mockID}}},
+		},
+		{"float - negative decimals", fields{
+			values: &valueContainer{slice: []float64{1250, -149}, isNull: []bool{false, false}, name: "foo"},
+			labels: []*valueContainer{{slice: []int{0, 1}, isNull: []bool{false, false}, id: mockID}}},
+			args{-2},
+			&Series{
+				values: &valueContainer{slice: []float64{1300, -100}, isNull: []bool{false, false}, id: mockID, name: "foo"},
+				labels: []*valueContainer{{slice: []int{0, 1}, isNull: []bool{false, false}, id: mockID}}},
+		},
+		{"float - large values", fields{
+			values: &valueContainer{slice: []float64{1e300, -1e300, 0}, isNull: []bool{false, false, false}, name: "foo"},
+			labels: []*valueContainer{{slice: []int{0, 1, 2}, isNull: []bool{false, false, false}, id: mockID}}},
+			args{10},
+			&Series{
+				values: &valueContainer{slice: []float64{1e300, -1e300, 0}, isNull: []bool{false, false, false}, id: mockID, name: "foo"},
+				labels: []*valueContainer{{slice: []int{0, 1, 2}, isNull: []bool{false, false, false}, id: mockID}}},
+		},
+		{"float - large decimals", fields{
+			values: &valueContainer{slice: []float64{1.25, 0, 1e-320}, isNull: []bool{false, false, false}, name: "foo"},
+			labels: []*valueContainer{{slice: []int{0, 1, 2}, isNull: []bool{false, false, false}, id: mockID}}},
+			args{400},
+			&Series{
+				values: &valueContainer{slice: []float64{1.25, 0, 1e-320}, isNull: []bool{false, false, false}, id: mockID, name: "foo"},
+				labels: []*valueContainer{{slice: []int{0, 1, 2}, isNull: []bool{false, false, false}, id: mockID}}},
+		},
+		{"float - large negative decimals", fields{
+			values: &valueContainer{slice: []float64{1e300, -1e308, 0}, isNull: []bool{false, false, false}, name: "foo"},
+			labels: []*valueContainer{{slice: []int{0, 1, 2}, isNull: []bool{false, false, false}, id: mockID}}},
+			args{-309},
+			&Series{
+				values: &valueContainer{slice: []float64{0, 0, 0}, isNull: []bool{false, false, false}, id: mockID, name: "foo"},
+				labels: []*valueContainer{{slice: []int{0, 1, 2}, isNull: []bool{false, false, false}, id: mockID}}},
+		},
+		{"decimal", fields{
+			values: &valueContainer{slice: []*big.Rat{mockDecimal("0.125"), mockDecimal("-0.125"), big.NewRat(1, 3), new(big.Rat)},
+				isNull: []bool{false, false, false, true}, name: "foo"},
+			labels: []*valueContainer{{slice: []int{0, 1, 2, 3}, isNull: []bool{false, false, false, false}, id: mockID}}},
+			args{2},
+			&Series{
+				values: &valueContainer{slice: []*big.Rat{mockDecimal("0.13"), mockDecimal("-0.13"), mockDecimal("0.33"), new(big.Rat)},
+					isNull: []bool{false, false, false, true}, id: mockID, name: "foo"},
+				labels: []*valueContainer{{slice: []int{0, 1, 2, 3}, isNull: []bool{false, false, false, false}, id: mockID}}},
+		},
+		{"decimal - negative decimals", fields{
+			values: &valueContainer{slice: []*big.Rat{mockDecimal("150"), mockDecimal("-149.9")}, isNull: []bool{false, false}, name: "foo"},
+			labels: []*valueContainer{{slice: []int{0, 1}, isNull: []bool{false, false}, id: mockID}}},
+			args{-2},
+			&Series{
+				values: &valueContainer{slice: []*big.Rat{mockDecimal("200"), mockDecimal("-100")}, isNull: []bool{false, false}, id: mockID, name: "foo"},
+				labels: []*valueContainer{{slice: []int{0, 1}, isNull: []bool{false, false}, id: mockID}}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &Series{
+				values: tt.fields.values,
+				labels: tt.fields.labels,
+				err:    tt.fields.err,
+			}
+			if got := s.Round(tt.args.decimals); !EqualSeries(got, tt.want) {
+				t.Errorf("Series.Round() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSeries_Sum(t *testing.T) {
+	type fields struct {
+		values *valueContainer
+		labels []*valueContainer
+		err    error
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		want   float64
+	}{
+		{"pass", fields{values: &valueContainer{slice: []float64{1, 2}, isNull: []bool{false, false}}}, 3},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &Series{
+				values: tt.fields.values,
+				labels: tt.fields.labels,
+				err:    tt.fields.err,
+			}
+			if got := s.Sum(); got != tt.want {
+				t.Errorf("Series.Sum() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSeries_SumInt64(t *testing.T) {
+	type fields struct {
+		values *valueContainer
+		labels []*valueContainer
+		err    error
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		want    int64
+		wantErr bool
+	}{
+		{"pass", fields{values: &valueContainer{slice: []int64{9007199254740992, 1, 5}, isNull: []bool{false, false, true}}}, 9007199254740993, false},
+		{"coerced from string", fields{values: &valueContainer{slice: []string{"1", "2", "foo"}, isNull: []bool{false, false, false}}}, 3, false},
+		{"all null", fields{values: &valueContainer{slice: []int64{1}, isNull: []bool{true}}}, 0, false},
+		{"fail - overflow", fields{values: &valueContainer{slice: []int64{math.MaxInt64, 1}, isNull: []bool{false, false}}}, 0, true},
+		{"fail - negative overflow", fields{values: &valueContainer{slice: []int64{math.MinInt64, -1}, isNull: []bool{false, false}}}, 0, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &Series{
+				values: tt.fields.values,
+				labels: tt.fields.labels,
+				err:    tt.fields.err,
+			}
+			got, err := s.SumInt64()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Series.SumInt64() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("Series.SumInt64() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSeries_MinInt64(t *testing.T) {
+	type fields struct {
+		values *valueContainer
+		labels []*valueContainer
+		err    error
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		want   int64
+	}{
+		{"pass", fields{values: &valueContainer{slice: []int64{9007199254740993, 9007199254740992}, isNull: []bool{false, false}}}, 9007199254740992},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &Series{
+				values: tt.fields.values,
+				labels: tt.fields.labels,
+				err:    tt.fields.err,
+			}
+			if got := s.MinInt64(); got != tt.want {
+				t.Errorf("Series.MinInt64() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSeries_MaxInt64(t *testing.T) {
+	type fields struct {
+		values *valueContainer
+		labels []*valueContainer
+		err    error
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		want   int64
+	}{
+		{"pass", fields{values: &valueContainer{slice: []int64{9007199254740993, 9007199254740992}, isNull: []bool{false, false}}}, 9007199254740993},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &Series{
+				values: tt.fields.values,
+				labels: tt.fields.labels,
+				err:    tt.fields.err,
+			}
+			if got := s.MaxInt64(); got != tt.want {
+				t.Errorf("Series.MaxInt64() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSeries_SumDecimal(t *testing.T) {
+	type fields struct {
+		values *valueContainer
+		labels []*valueContainer
+		err    error
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		want   string
+	}{
+		{"pass", fields{values: &valueContainer{slice: []*big.Rat{mockDecimal("0.1"), mockDecimal("0.2"), new(big.Rat)}, isNull: []bool{false, false, true}}}, "0.3"},
+		{"coerced from float", fields{values: &valueContainer{slice: []float64{0.1, 0.2}, isNull: []bool{false, false}}}, "0.3"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &Series{
+				values: tt.fields.values,
+				labels: tt.fields.labels,
+				err:    tt.fields.err,
+			}
+			if got := s.SumDecimal(); formatDecimal(got) != tt.want {
+				t.Errorf("Series.SumDecimal() = %v, want %v", formatDecimal(got), tt.want)
+			}
+		})
+	}
+}
+
+func TestSeries_SumDuration(t *testing.T) {
+	type fields struct {
+		values *valueContainer
+		labels []*valueContainer
+		err    error
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		want   time.Duration
+	}{
+		{"pass", fields{values: &valueContainer{slice: []time.Duration{time.Hour, time.Minute, time.Second}, isNull: []bool{false, false, true}}},
+			time.Hour + time.Minute},
+		{"coerced from string", fields{values: &valueContainer{slice: []string{"1h", "PT1M"}, isNull: []bool{false, false}}},
+			time.Hour + time.Minute},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &Series{
+				values: tt.fields.values,
+				labels: tt.fields.labels,
+				err:    tt.fields.err,
+			}
+			if got := s.SumDuration(); got != tt.want {
+				t.Errorf("Series.SumDuration() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSeries_MedianDuration(t *testing.T) {
+	type fields struct {
+		values *valueContainer
+		labels []*valueContainer
+		err    error
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		want   time.Duration
+	}{
+		{"pass", fields{values: &valueContainer{slice: []time.Duration{time.Hour, 3 * time.Hour, 2 * time.Hour, 0}, isNull: []bool{false, false, false, true}}},
+			2 * time.Hour},
+		{"coerced from string - unparseable is null", fields{values: &valueContainer{slice: []string{"1h", "foo"}, isNull: []bool{false, false}}},
+			time.Hour},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &Series{
+				values: tt.fields.values,
+				labels: tt.fields.labels,
+				err:    tt.fields.err,
+			}
+			if got := s.MedianDuration(); got != tt.want {
+				t.Errorf("Series.MedianDuration() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSeries_MeanDecimal(t *testing.T) {
+	type fields struct {
+		values *valueContainer
+		labels []*valueContainer
+		err    error
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		want   string
+	}{
+		{"pass", fields{values: &valueContainer{slice: []*big.Rat{mockDecimal("10.01"), mockDecimal("10.02")}, isNull: []bool{false, false}}}, "10.015"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &Series{
+				values: tt.fields.values,
+				labels: tt.fields.labels,
+				err:    tt.fields.err,
+			}
+			if got := s.MeanDecimal(); formatDecimal(got) != tt.want {
+				t.Errorf("Series.MeanDecimal() = %v, want %v", formatDecimal(got), tt.want)
+			}
+		})
+	}
+}
+
+func TestSeries_MinDecimal(t *testing.T) {
+	type fields struct {
+		values *valueContainer
+		labels []*valueContainer
+		err    error
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		want   string
 	}{
-		{"ignore missing",
-			fields{
-				values: &valueContainer{slice: []float64{1, 2}, isNull: []bool{false, false}},
-				labels: []*valueContainer{{slice: []int{0, 1}, isNull: []bool{false, false}, id: mockID}}},
-			args{
-				other: &Series{values: &valueContainer{slice: []float64{4, 10}, isNull: []bool{false, false}},
-					labels: []*valueContainer{{slice: []int{1, 10}, isNull: []bool{false, false}, id: mockID}}},
-				ignoreNulls: true},
-			&Series{
-				values: &valueContainer{slice: []float64{1, .5}, isNull: []bool{false, false}, id: mockID},
-				labels: []*valueContainer{
-					{slice: []int{0, 1}, isNull: []bool{false, false},
-						cache: []string{"0", "1"}, id: mockID,
-					}}},
-		},
-		{"missing as null - divide by 0",
-			fields{
-				values: &valueContainer{slice: []float64{1, 2, 3}, isNull: []bool{false, false, false}},
-				labels: []*valueContainer{{slice: []int{0, 1, 2}, isNull: []bool{false, false, false}, id: mockID}}},
-			args{
-				other: &Series{values: &valueContainer{slice: []float64{0, 2, 10}, isNull: []bool{false, false, false}},
-					labels: []*valueContainer{{slice: []int{0, 1, 10}, isNull: []bool{false, false, false}, id: mockID}}},
-				ignoreNulls: false},
-			&Series{
-				values: &valueContainer{slice: []float64{0, 1, 0}, isNull: []bool{true, false, true}, id: mockID},
-				labels: []*valueContainer{
-					{slice: []int{0, 1, 2}, isNull: []bool{false, false, false},
-						cache: []string{"0", "1", "2"}, id: mockID,
-					}}},
-		},
+		{"pass", fields{values: &valueContainer{slice: []*big.Rat{mockDecimal("10.01"), mockDecimal("10.001")}, isNull: []bool{false, false}}}, "10.001"},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -1874,14 +2730,14 @@ func TestSeries_Divide(t *testing.T) {
 				labels: tt.fields.labels,
 				err:    tt.fields.err,
 			}
-			if got := s.Divide(tt.args.other, tt.args.ignoreNulls); !EqualSeries(got, tt.want) {
-				t.Errorf("Series.Divide() = %v, want %v", got, tt.want)
+			if got := s.MinDecimal(); formatDecimal(got) != tt.want {
+				t.Errorf("Series.MinDecimal() = %v, want %v", formatDecimal(got), tt.want)
 			}
 		})
 	}
 }
 
-func TestSeries_Sum(t *testing.T) {
+func TestSeries_MaxDecimal(t *testing.T) {
 	type fields struct {
 		values *valueContainer
 		labels []*valueContainer
@@ -1890,9 +2746,9 @@ func TestSeries_Sum(t *testing.T) {
 	tests := []struct {
 		name   string
 		fields fields
-		want   float64
+		want   string
 	}{
-		{"pass", fields{values: &valueContainer{slice: []float64{1, 2}, isNull: []bool{false, false}}}, 3},
+		{"pass", fields{values: &valueContainer{slice: []*big.Rat{mockDecimal("10.01"), mockDecimal("10.001")}, isNull: []bool{false, false}}}, "10.01"},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -1901,8 +2757,8 @@ func TestSeries_Sum(t *testing.T) {
 				labels: tt.fields.labels,
 				err:    tt.fields.err,
 			}
-			if got := s.Sum(); got != tt.want {
-				t.Errorf("Series.Sum() = %v, want %v", got, tt.want)
+			if got := s.MaxDecimal(); formatDecimal(got) != tt.want {
+				t.Errorf("Series.MaxDecimal() = %v, want %v", formatDecimal(got), tt.want)
 			}
 		})
 	}
@@ -2351,6 +3207,59 @@ func TestSeries_Bin(t *testing.T) {
 	}
 }
 
+func TestSeries_BinDuration(t *testing.T) {
+	type fields struct {
+		values *valueContainer
+		labels []*valueContainer
+		err    error
+	}
+	type args struct {
+		bins   []time.Duration
+		config *Binner
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		want    *Series
+		wantErr bool
+	}{
+		{"pass", fields{
+			values: &valueContainer{slice: []string{"30m", "1h30m", "PT3H"}, isNull: []bool{false, false, false}, id: mockID},
+			labels: []*valueContainer{{slice: []int{0, 1, 2}, isNull: []bool{false, false, false}, id: mockID, name: "qux"}}},
+			args{
+				bins: []time.Duration{time.Hour, 2 * time.Hour}, config: &Binner{AndLess: true, AndMore: true}},
+			&Series{
+				values: &valueContainer{slice: []string{"<=1h0m0s", "1h0m0s-2h0m0s", ">2h0m0s"}, isNull: []bool{false, false, false}, id: mockID},
+				labels: []*valueContainer{{slice: []int{0, 1, 2}, isNull: []bool{false, false, false}, id: mockID, name: "qux"}}},
+			false,
+		},
+		{"fail - too many labels", fields{
+			values: &valueContainer{slice: []time.Duration{time.Hour}, isNull: []bool{false}},
+			labels: []*valueContainer{{slice: []int{0}, isNull: []bool{false}, id: mockID, name: "qux"}}},
+			args{
+				bins: []time.Duration{time.Hour, 2 * time.Hour}, config: &Binner{Labels: []string{"foo", "bar"}}},
+			nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &Series{
+				values: tt.fields.values,
+				labels: tt.fields.labels,
+				err:    tt.fields.err,
+			}
+			got, err := s.BinDuration(tt.args.bins, tt.args.config)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Series.BinDuration() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !EqualSeries(got, tt.want) {
+				t.Errorf("Series.BinDuration() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
 func TestSeries_CumSum(t *testing.T) {
 	type fields struct {
 		values *valueContainer
@@ -2797,6 +3706,318 @@ func TestSeries_Resample(t *testing.T) {
 	}
 }
 
+func TestSeries_Localize(t *testing.T) {
+	tz, err := time.LoadLocation("America/Los_Angeles")
+	if err != nil {
+		log.Fatal(err)
+	}
+	type fields struct {
+		values *valueContainer
+		labels []*valueContainer
+		err    error
+	}
+	type args struct {
+		loc *time.Location
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		want   *Series
+	}{
+		{"pass - round trips to civil.Date", fields{
+			values: &valueContainer{slice: []string{"2020-11-01 23:30:00"}, id: mockID, name: "foo", isNull: []bool{false}},
+			labels: []*valueContainer{{slice: []float64{1}, id: mockID, name: "bar", isNull: []bool{false}}}},
+			args{tz},
+			&Series{
+				values: &valueContainer{slice: []time.Time{time.Date(2020, 11, 1, 23, 30, 0, 0, tz)}, id: mockID, name: "foo", isNull: []bool{false}},
+				labels: []*valueContainer{{slice: []float64{1}, id: mockID, name: "bar", isNull: []bool{false}}}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &Series{
+				values: tt.fields.values,
+				labels: tt.fields.labels,
+				err:    tt.fields.err,
+			}
+			got := s.Localize(tt.args.loc)
+			if !EqualSeries(got, tt.want) {
+				t.Errorf("Series.Localize() = %v, want %v", got, tt.want)
+			}
+			got.Cast(map[string]DType{"": Date})
+			if date := got.values.slice.([]civil.Date)[0]; date != (civil.Date{Year: 2020, Month: 11, Day: 1}) {
+				t.Errorf("Series.Localize() -> Cast(Date) = %v, want 2020-11-01", date)
+			}
+		})
+	}
+}
+
+func TestSeries_ConvertTimeZone(t *testing.T) {
+	tz, err := time.LoadLocation("America/Los_Angeles")
+	if err != nil {
+		log.Fatal(err)
+	}
+	type fields struct {
+		values *valueContainer
+		labels []*valueContainer
+		err    error
+	}
+	type args struct {
+		loc *time.Location
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		want   *Series
+	}{
+		{"pass", fields{
+			values: &valueContainer{slice: []time.Time{time.Date(2020, 1, 2, 6, 0, 0, 0, time.UTC)}, id: mockID, name: "foo", isNull: []bool{false}},
+			labels: []*valueContainer{{slice: []float64{1}, id: mockID, name: "bar", isNull: []bool{false}}}},
+			args{tz},
+			&Series{
+				values: &valueContainer{slice: []time.Time{time.Date(2020, 1, 1, 22, 0, 0, 0, tz)}, id: mockID, name: "foo", isNull: []bool{false}},
+				labels: []*valueContainer{{slice: []float64{1}, id: mockID, name: "bar", isNull: []bool{false}}}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &Series{
+				values: tt.fields.values,
+				labels: tt.fields.labels,
+				err:    tt.fields.err,
+			}
+			if got := s.ConvertTimeZone(tt.args.loc); !EqualSeries(got, tt.want) {
+				t.Errorf("Series.ConvertTimeZone() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSeries_Gt(t *testing.T) {
+	type fields struct {
+		values *valueContainer
+		labels []*valueContainer
+		err    error
+	}
+	type args struct {
+		other interface{}
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		want   *Series
+	}{
+		{"scalar - null is null", fields{
+			values: &valueContainer{slice: []float64{1, 2, 3}, isNull: []bool{false, false, true}, id: mockID, name: "foo"},
+			labels: []*valueContainer{{slice: []int{0, 1, 2}, isNull: []bool{false, false, false}, id: mockID, name: "*0"}}},
+			args{1},
+			&Series{values: &valueContainer{slice: []bool{false, true, false}, isNull: []bool{false, false, true}, id: mockID, name: "foo"},
+				labels: []*valueContainer{{slice: []int{0, 1, 2}, isNull: []bool{false, false, false}, id: mockID, name: "*0"}}}},
+		{"aligned Series - missing is null", fields{
+			values: &valueContainer{slice: []float64{1, 2, 3}, isNull: []bool{false, false, false}, id: mockID, name: "foo"},
+			labels: []*valueContainer{{slice: []int{0, 1, 2}, isNull: []bool{false, false, false}, id: mockID, name: "*0"}}},
+			args{&Series{values: &valueContainer{slice: []float64{5, 0}, isNull: []bool{false, false}, id: mockID, name: "bar"},
+				labels: []*valueContainer{{slice: []int{2, 0}, isNull: []bool{false, false}, id: mockID, name: "*0"}}}},
+			&Series{values: &valueContainer{slice: []bool{true, false, false}, isNull: []bool{false, true, false}, id: mockID, name: "foo"},
+				labels: []*valueContainer{{slice: []int{0, 1, 2}, isNull: []bool{false, false, false}, id: mockID, name: "*0",
+					cache: []string{"0", "1", "2"}}}}},
+		{"datetime", fields{
+			values: &valueContainer{slice: []time.Time{time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)}, isNull: []bool{false}, id: mockID, name: "foo"},
+			labels: []*valueContainer{{slice: []int{0}, isNull: []bool{false}, id: mockID, name: "*0"}}},
+			args{time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC)},
+			&Series{values: &valueContainer{slice: []bool{true}, isNull: []bool{false}, id: mockID, name: "foo"},
+				labels: []*valueContainer{{slice: []int{0}, isNull: []bool{false}, id: mockID, name: "*0"}}}},
+		{"fail - slice", fields{
+			values: &valueContainer{slice: []float64{1}, isNull: []bool{false}, id: mockID, name: "foo"},
+			labels: []*valueContainer{{slice: []int{0}, isNull: []bool{false}, id: mockID, name: "*0"}}},
+			args{[]float64{1}},
+			&Series{err: fmt.Errorf("Gt(): other must be a scalar or *Series, not []float64")}},
+		{"fail - nil", fields{
+			values: &valueContainer{slice: []float64{1}, isNull: []bool{false}, id: mockID, name: "foo"},
+			labels: []*valueContainer{{slice: []int{0}, isNull: []bool{false}, id: mockID, name: "*0"}}},
+			args{nil},
+			&Series{err: fmt.Errorf("Gt(): other cannot be nil")}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &Series{
+				values: tt.fields.values,
+				labels: tt.fields.labels,
+				err:    tt.fields.err,
+			}
+			if got := s.Gt(tt.args.other); !EqualSeries(got, tt.want) {
+				t.Errorf("Series.Gt() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSeries_comparisons(t *testing.T) {
+	s := &Series{
+		values: &valueContainer{slice: []int64{1, 2, 3}, isNull: []bool{false, false, false}, id: mockID, name: "foo"},
+		labels: []*valueContainer{{slice: []int{0, 1, 2}, isNull: []bool{false, false, false}, id: mockID, name: "*0"}}}
+	tests := []struct {
+		name string
+		got  *Series
+		want []bool
+	}{
+		{"Ge", s.Ge(2), []bool{false, true, true}},
+		{"Lt", s.Lt(2), []bool{true, false, false}},
+		{"Le", s.Le(2), []bool{true, true, false}},
+		{"Eq", s.Eq(2), []bool{false, true, false}},
+		{"Ne", s.Ne(2), []bool{true, false, true}},
+		{"Between", s.Between(2, 3.5), []bool{false, true, true}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			want := &Series{values: &valueContainer{slice: tt.want, isNull: []bool{false, false, false}, id: mockID, name: "foo"},
+				labels: []*valueContainer{{slice: []int{0, 1, 2}, isNull: []bool{false, false, false}, id: mockID, name: "*0"}}}
+			if !EqualSeries(tt.got, want) {
+				t.Errorf("Series.%v() = %v, want %v", tt.name, tt.got, want)
+			}
+		})
+	}
+}
+
+func TestSeries_IsIn(t *testing.T) {
+	type fields struct {
+		values *valueContainer
+		labels []*valueContainer
+		err    error
+	}
+	type args struct {
+		values interface{}
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		want   *Series
+	}{
+		{"pass", fields{
+			values: &valueContainer{slice: []string{"foo", "bar", ""}, isNull: []bool{false, false, true}, id: mockID, name: "foo"},
+			labels: []*valueContainer{{slice: []int{0, 1, 2}, isNull: []bool{false, false, false}, id: mockID, name: "*0"}}},
+			args{[]string{"bar", "baz"}},
+			&Series{values: &valueContainer{slice: []bool{false, true, false}, isNull: []bool{false, false, true}, id: mockID, name: "foo"},
+				labels: []*valueContainer{{slice: []int{0, 1, 2}, isNull: []bool{false, false, false}, id: mockID, name: "*0"}}}},
+		{"empty values", fields{
+			values: &valueContainer{slice: []string{"foo", "bar", ""}, isNull: []bool{false, false, true}, id: mockID, name: "foo"},
+			labels: []*valueContainer{{slice: []int{0, 1, 2}, isNull: []bool{false, false, false}, id: mockID, name: "*0"}}},
+			args{[]string{}},
+			&Series{values: &valueContainer{slice: []bool{false, false, false}, isNull: []bool{false, false, true}, id: mockID, name: "foo"},
+				labels: []*valueContainer{{slice: []int{0, 1, 2}, isNull: []bool{false, false, false}, id: mockID, name: "*0"}}}},
+		{"fail - not slice", fields{
+			values: &valueContainer{slice: []string{"foo", "bar", ""}, isNull: []bool{false, false, true}, id: mockID, name: "foo"},
+			labels: []*valueContainer{{slice: []int{0, 1, 2}, isNull: []bool{false, false, false}, id: mockID, name: "*0"}}},
+			args{"foo"},
+			&Series{err: fmt.Errorf("IsIn(): values must be a slice, not string")}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &Series{
+				values: tt.fields.values,
+				labels: tt.fields.labels,
+				err:    tt.fields.err,
+			}
+			if got := s.IsIn(tt.args.values); !EqualSeries(got, tt.want) {
+				t.Errorf("Series.IsIn() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSeries_IsNullMask(t *testing.T) {
+	s := &Series{
+		values: &valueContainer{slice: []float64{1, 0, 3}, isNull: []bool{false, true, false}, id: mockID, name: "foo"},
+		labels: []*valueContainer{{slice: []int{0, 1, 2}, isNull: []bool{false, false, false}, id: mockID, name: "*0"}}}
+	want := &Series{
+		values: &valueContainer{slice: []bool{false, true, false}, isNull: []bool{false, false, false}, id: mockID, name: "foo"},
+		labels: []*valueContainer{{slice: []int{0, 1, 2}, isNull: []bool{false, false, false}, id: mockID, name: "*0"}}}
+	if got := s.IsNullMask(); !EqualSeries(got, want) {
+		t.Errorf("Series.IsNullMask() = %v, want %v", got, want)
+	}
+}
+
+func TestSeries_logic(t *testing.T) {
+	// true, false, null
+	s := &Series{
+		values: &valueContainer{slice: []bool{true, true, true, false, false, false, false, false, false},
+			isNull: []bool{false, false, false, false, false, false, true, true, true}, id: mockID, name: "foo"},
+		labels: []*valueContainer{{slice: []int{0, 1, 2, 3, 4, 5, 6, 7, 8}, isNull: make([]bool, 9), id: mockID, name: "*0"}}}
+	other := &Series{
+		values: &valueContainer{slice: []bool{true, false, false, true, false, false, true, false, false},
+			isNull: []bool{false, false, true, false, false, true, false, false, true}, id: mockID, name: "bar"},
+		labels: []*valueContainer{{slice: []int{0, 1, 2, 3, 4, 5, 6, 7, 8}, isNull: make([]bool, 9), id: mockID, name: "*0"}}}
+	tests := []struct {
+		name       string
+		got        *Series
+		want       []bool
+		wantIsNull []bool
+	}{
+		{"And", s.And(other),
+			[]bool{true, false, false, false, false, false, false, false, false},
+			[]bool{false, false, true, false, false, false, true, false, true}},
+		{"Or", s.Or(other),
+			[]bool{true, true, true, true, false, false, true, false, false},
+			[]bool{false, false, false, false, false, true, false, true, true}},
+		{"Not", s.Not(),
+			[]bool{false, false, false, true, true, true, false, false, false},
+			[]bool{false, false, false, false, false, false, true, true, true}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			want := &Series{values: &valueContainer{slice: tt.want, isNull: tt.wantIsNull, id: mockID, name: "foo"},
+				labels: []*valueContainer{{slice: []int{0, 1, 2, 3, 4, 5, 6, 7, 8}, isNull: make([]bool, 9), id: mockID, name: "*0"}}}
+			if !EqualSeries(tt.got, want) {
+				t.Errorf("Series.%v() = %v, want %v", tt.name, tt.got.values, want.values)
+			}
+		})
+	}
+}
+
+func TestSeries_Mask(t *testing.T) {
+	type fields struct {
+		values *valueContainer
+		labels []*valueContainer
+		err    error
+	}
+	type args struct {
+		mask *Series
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		want   *Series
+	}{
+		{"null is false", fields{
+			values: &valueContainer{slice: []float64{1, 2, 3}, isNull: []bool{false, false, false}, id: mockID, name: "foo"},
+			labels: []*valueContainer{{slice: []int{0, 1, 2}, isNull: []bool{false, false, false}, id: mockID, name: "*0"}}},
+			args{&Series{values: &valueContainer{slice: []bool{true, true, true}, isNull: []bool{false, true, false}, id: mockID, name: "foo"},
+				labels: []*valueContainer{{slice: []int{0, 1, 2}, isNull: []bool{false, false, false}, id: mockID, name: "*0"}}}},
+			&Series{values: &valueContainer{slice: []float64{1, 3}, isNull: []bool{false, false}, id: mockID, name: "foo"},
+				labels: []*valueContainer{{slice: []int{0, 2}, isNull: []bool{false, false}, id: mockID, name: "*0"}}}},
+		{"fail - mask has error", fields{
+			values: &valueContainer{slice: []float64{1, 2, 3}, isNull: []bool{false, false, false}, id: mockID, name: "foo"},
+			labels: []*valueContainer{{slice: []int{0, 1, 2}, isNull: []bool{false, false, false}, id: mockID, name: "*0"}}},
+			args{&Series{err: fmt.Errorf("foo")}},
+			&Series{err: fmt.Errorf("masking rows: mask: foo")}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &Series{
+				values: tt.fields.values,
+				labels: tt.fields.labels,
+				err:    tt.fields.err,
+			}
+			if got := s.Mask(tt.args.mask); !EqualSeries(got, tt.want) {
+				t.Errorf("Series.Mask() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
 func TestSeries_ValueCounts(t *testing.T) {
 	type fields struct {
 		values *valueContainer
@@ -30,6 +30,7 @@
 package tada
 
 import (
+	"math/big"
 	"time"
 )
 
@@ -46,6 +47,9 @@ type valueContainerAlias struct {
 	IsNull []bool      `json:"isNull"`
 	Name   string      `json:"name"`
 	ID     string      `json:"id"`
+	DType  string      `json:"dtype,omitempty"`
+	// Location is the name of the location shared by all []time.Time values, if any
+	Location string `json:"location,omitempty"`
 }
 
 var tadaID = "tadaID_"
@@ -80,12 +84,19 @@ type DataFrame struct {
 }
 
 type dataFrameAlias struct {
+	Version       int               `json:"version,omitempty"`
 	Labels        []*valueContainer `json:"labels"`
 	Values        []*valueContainer `json:"values"`
 	Name          string            `json:"name"`
 	ColLevelNames []string          `json:"colLevelNames"`
 }
 
+type seriesAlias struct {
+	Version int               `json:"version,omitempty"`
+	Labels  []*valueContainer `json:"labels"`
+	Values  *valueContainer   `json:"values"`
+}
+
 // A DataFrameIterator iterates over the rows in a DataFrame.
 type DataFrameIterator struct {
 	current int
@@ -158,15 +169,64 @@ type dateTimeValueContainer struct {
 	index  []int
 }
 
+type int64ValueContainer struct {
+	slice  []int64
+	isNull []bool
+	index  []int
+}
+
+type boolValueContainer struct {
+	slice  []bool
+	isNull []bool
+	index  []int
+}
+
+type durationValueContainer struct {
+	slice  []time.Duration
+	isNull []bool
+	index  []int
+}
+
+type decimalValueContainer struct {
+	slice  []*big.Rat
+	isNull []bool
+	index  []int
+}
+
+type categoryValueContainer struct {
+	slice  []string
+	rank   []int
+	isNull []bool
+	index  []int
+}
+
+// A Category is one value in a categorical (dictionary-encoded) container, as created by casting to the Categorical DType.
+// Every Category in a container shares a dictionary of the container's distinct values,
+// so each Category stores only its position (code) in the dictionary.
+type Category struct {
+	code int32
+	dict *categoryDictionary
+}
+
+// a categoryDictionary holds the distinct values of a categorical container, in sorted order.
+type categoryDictionary struct {
+	values []string
+	codes  map[string]int32
+}
+
 // A Sorter supplies details to the Sort() function.
 // `Name` specifies the container (either label or column name) to sort.
 // If `Descending` is true, values are sorted in descending order.
 // `DType` specifies the data type to which values will be coerced before they are sorted (default: float64).
+// With the Bool DType, false is sorted before true.
+// With the Categorical DType, values are sorted in the order of `CategoryOrder` (if provided),
+// and any values not in `CategoryOrder` are sorted after them in ascending order.
 // Null values are always sorted to the bottom.
 type Sorter struct {
-	Name       string
-	Descending bool
-	DType      DType
+	Name          string
+	Descending    bool
+	DType         DType
+	CategoryOrder []string
 }
 
 // An Element is one {value, null status} pair in either a Series or DataFrame.
@@ -217,6 +277,16 @@ const (
 	Time
 	// Date -> civil.Date
 	Date
+	// Int64 -> int64
+	Int64
+	// Bool -> bool
+	Bool
+	// Categorical -> Category
+	Categorical
+	// Decimal -> *big.Rat
+	Decimal
+	// Duration -> time.Duration
+	Duration
 )
 
 // A JoinOption configures a lookup or merge function.
@@ -241,6 +311,8 @@ type joinConfig struct {
 // `ByWeek` returns the first day of the most recent week (starting on `StartOfWeek`) relative to timestamp.
 // Otherwise, truncates the timestamp `ByDuration`.
 // If `Location` is not provided, time.UTC is used as the default location.
+// Duration values are truncated `ByDuration`, or to a multiple of 24 hours (`ByDay`) or 7 days (`ByWeek`).
+// Because years and months do not have a fixed length, resampling Duration values `ByYear` or `ByMonth` returns null values.
 type Resampler struct {
 	ByYear      bool
 	ByMonth     bool
@@ -2,8 +2,12 @@ package tada
 
 import (
 	"fmt"
+	"math"
+	"math/big"
 	"reflect"
+	"sort"
 	"strconv"
+	"strings"
 	"time"
 
 	"cloud.google.com/go/civil"
@@ -60,6 +64,167 @@ func (vc dateTimeValueContainer) Swap(i, j int) {
 	vc.index[i], vc.index[j] = vc.index[j], vc.index[i]
 }
 
+func (vc int64ValueContainer) Less(i, j int) bool {
+	if vc.slice[i] < vc.slice[j] {
+		return true
+	}
+	return false
+}
+
+func (vc int64ValueContainer) Len() int {
+	return len(vc.slice)
+}
+
+func (vc int64ValueContainer) Swap(i, j int) {
+	vc.slice[i], vc.slice[j] = vc.slice[j], vc.slice[i]
+	vc.isNull[i], vc.isNull[j] = vc.isNull[j], vc.isNull[i]
+	vc.index[i], vc.index[j] = vc.index[j], vc.index[i]
+}
+
+func (vc boolValueContainer) Less(i, j int) bool {
+	if !vc.slice[i] && vc.slice[j] {
+		return true
+	}
+	return false
+}
+
+func (vc boolValueContainer) Len() int {
+	return len(vc.slice)
+}
+
+func (vc boolValueContainer) Swap(i, j int) {
+	vc.slice[i], vc.slice[j] = vc.slice[j], vc.slice[i]
+	vc.isNull[i], vc.isNull[j] = vc.isNull[j], vc.isNull[i]
+	vc.index[i], vc.index[j] = vc.index[j], vc.index[i]
+}
+
+func (vc durationValueContainer) Less(i, j int) bool {
+	if vc.slice[i] < vc.slice[j] {
+		return true
+	}
+	return false
+}
+
+func (vc durationValueContainer) Len() int {
+	return len(vc.slice)
+}
+
+func (vc durationValueContainer) Swap(i, j int) {
+	vc.slice[i], vc.slice[j] = vc.slice[j], vc.slice[i]
+	vc.isNull[i], vc.isNull[j] = vc.isNull[j], vc.isNull[i]
+	vc.index[i], vc.index[j] = vc.index[j], vc.index[i]
+}
+
+func (vc decimalValueContainer) Less(i, j int) bool {
+	if vc.slice[i].Cmp(vc.slice[j]) < 0 {
+		return true
+	}
+	return false
+}
+
+func (vc decimalValueContainer) Len() int {
+	return len(vc.slice)
+}
+
+func (vc decimalValueContainer) Swap(i, j int) {
+	vc.slice[i], vc.slice[j] = vc.slice[j], vc.slice[i]
+	vc.isNull[i], vc.isNull[j] = vc.isNull[j], vc.isNull[i]
+	vc.index[i], vc.index[j] = vc.index[j], vc.index[i]
+}
+
+func (vc categoryValueContainer) Less(i, j int) bool {
+	if vc.rank[i] != vc.rank[j] {
+		return vc.rank[i] < vc.rank[j]
+	}
+	if vc.slice[i] < vc.slice[j] {
+		return true
+	}
+	return false
+}
+
+func (vc categoryValueContainer) Len() int {
+	return len(vc.slice)
+}
+
+func (vc categoryValueContainer) Swap(i, j int) {
+	vc.slice[i], vc.slice[j] = vc.slice[j], vc.slice[i]
+	vc.rank[i], vc.rank[j] = vc.rank[j], vc.rank[i]
+	vc.isNull[i], vc.isNull[j] = vc.isNull[j], vc.isNull[i]
+	vc.index[i], vc.index[j] = vc.index[j], vc.index[i]
+}
+
+// -- categories
+
+// String returns the value of c, or "" if c is null.
+func (c Category) String() string {
+	if c.dict == nil || c.code < 0 {
+		return ""
+	}
+	return c.dict.values[c.code]
+}
+
+// Code returns the position of c in its dictionary of categories, or -1 if c is null.
+func (c Category) Code() int {
+	if c.dict == nil {
+		return -1
+	}
+	return int(c.code)
+}
+
+// Categories returns a copy of the dictionary of distinct values shared by c and the other values in its container, in sorted order.
+func (c Category) Categories() []string {
+	if c.dict == nil {
+		return nil
+	}
+	return append([]string{}, c.dict.values...)
+}
+
+// codeOf returns the code of val in dict, and false if dict is nil or does not contain val.
+func (dict *categoryDictionary) codeOf(val string) (int32, bool) {
+	if dict == nil {
+		return 0, false
+	}
+	code, ok := dict.codes[val]
+	return code, ok
+}
+
+// newCategories dictionary-encodes vals. Null values have code -1 and are excluded from the dictionary.
+func newCategories(vals []string, isNull []bool) []Category {
+	dict := &categoryDictionary{codes: make(map[string]int32)}
+	for i := range vals {
+		if isNull[i] {
+			continue
+		}
+		if _, ok := dict.codes[vals[i]]; !ok {
+			dict.codes[vals[i]] = 0
+			dict.values = append(dict.values, vals[i])
+		}
+	}
+	sort.Strings(dict.values)
+	for code, v := range dict.values {
+		dict.codes[v] = int32(code)
+	}
+	ret := make([]Category, len(vals))
+	for i := range vals {
+		ret[i].dict = dict
+		if isNull[i] {
+			ret[i].code = -1
+		} else {
+			ret[i].code = dict.codes[vals[i]]
+		}
+	}
+	return ret
+}
+
+// categoryStrings returns the value of every Category in arr. Values share memory with the dictionary.
+func categoryStrings(arr []Category) []string {
+	ret := make([]string, len(arr))
+	for i := range arr {
+		ret[i] = arr[i].String()
+	}
+	return ret
+}
+
 // converters
 
 func convertStringToFloat(val string, originalBool bool) (float64, bool) {
@@ -77,6 +242,34 @@ func convertBoolToFloat(val bool) float64 {
 	return 0
 }
 
+// String returns the name of dtype.
+func (dtype DType) String() string {
+	switch dtype {
+	case String:
+		return "String"
+	case Float64:
+		return "Float64"
+	case DateTime:
+		return "DateTime"
+	case Time:
+		return "Time"
+	case Date:
+		return "Date"
+	case Int64:
+		return "Int64"
+	case Bool:
+		return "Bool"
+	case Categorical:
+		return "Categorical"
+	case Decimal:
+		return "Decimal"
+	case Duration:
+		return "Duration"
+	default:
+		return fmt.Sprintf("DType(%d)", int(dtype))
+	}
+}
+
 func (vc *valueContainer) cast(dtype DType) {
 	if vc.isString() {
 		vc.setCache()
@@ -117,6 +310,33 @@ func (vc *valueContainer) cast(dtype DType) {
 			}
 			vc.slice = ret
 		}
+	case Int64:
+		_, ok := vc.slice.([]int64)
+		if !ok {
+			vc.slice = vc.int64().slice
+		}
+	case Bool:
+		_, ok := vc.slice.([]bool)
+		if !ok {
+			vc.slice = vc.bool().slice
+		}
+	case Categorical:
+		_, ok := vc.slice.([]Category)
+		if !ok {
+			vc.slice = newCategories(vc.string().slice, vc.isNull)
+			// values are looked up in the dictionary instead
+			vc.resetCache()
+		}
+	case Decimal:
+		_, ok := vc.slice.([]*big.Rat)
+		if !ok {
+			vc.slice = vc.decimal().slice
+		}
+	case Duration:
+		_, ok := vc.slice.([]time.Duration)
+		if !ok {
+			vc.slice = vc.duration().slice
+		}
 	}
 	return
 }
@@ -152,6 +372,28 @@ func (vc *valueContainer) float64() floatValueContainer {
 			newVals[i] = convertBoolToFloat(arr[i])
 		}
 
+	case []Category:
+		arr := vc.slice.([]Category)
+		for i := range arr {
+			newVals[i], isNull[i] = convertStringToFloat(arr[i].String(), isNull[i])
+		}
+
+	case []*big.Rat:
+		arr := vc.slice.([]*big.Rat)
+		for i := range arr {
+			if arr[i] == nil {
+				newVals[i], isNull[i] = 0, true
+				continue
+			}
+			newVals[i], _ = arr[i].Float64()
+		}
+
+	case []time.Duration:
+		arr := vc.slice.([]time.Duration)
+		for i := range arr {
+			newVals[i] = float64(arr[i])
+		}
+
 	case []interface{}:
 		arr := vc.slice.([]interface{})
 		for i := range arr {
@@ -191,12 +433,489 @@ func (vc *valueContainer) float64() floatValueContainer {
 	return ret
 }
 
+// returns an integer-valued float as int64, or null if val is not an integer or is out of range
+func convertFloatToInt64(val float64, originalBool bool) (int64, bool) {
+	if math.IsNaN(val) || val != math.Trunc(val) || val < math.MinInt64 || val >= math.MaxInt64 {
+		return 0, true
+	}
+	return int64(val), originalBool
+}
+
+func convertStringToInt64(val string, originalBool bool) (int64, bool) {
+	parsedVal, err := strconv.ParseInt(val, 10, 64)
+	if err == nil {
+		return parsedVal, originalBool
+	}
+	parsedFloat, err := strconv.ParseFloat(val, 64)
+	if err == nil {
+		return convertFloatToInt64(parsedFloat, originalBool)
+	}
+	return 0, true
+}
+
+func convertBoolToInt64(val bool) int64 {
+	if val {
+		return 1
+	}
+	return 0
+}
+
+// if already []int64, returns shared values, not new values.
+// Values that cannot be represented exactly as int64 (e.g., 1.5, or unparseable strings) are null.
+func (vc *valueContainer) int64() int64ValueContainer {
+	newVals := make([]int64, reflect.ValueOf(vc.slice).Len())
+	isNull := vc.isNull
+	switch vc.slice.(type) {
+	case []int64:
+		newVals = vc.slice.([]int64)
+
+	case []float64:
+		arr := vc.slice.([]float64)
+		for i := range arr {
+			newVals[i], isNull[i] = convertFloatToInt64(arr[i], isNull[i])
+		}
+
+	case []string:
+		arr := vc.slice.([]string)
+		for i := range arr {
+			newVals[i], isNull[i] = convertStringToInt64(arr[i], isNull[i])
+		}
+
+	case [][]byte:
+		arr := vc.slice.([][]byte)
+		for i := range arr {
+			newVals[i], isNull[i] = convertStringToInt64(string(arr[i]), isNull[i])
+		}
+
+	case []bool:
+		arr := vc.slice.([]bool)
+		for i := range arr {
+			newVals[i] = convertBoolToInt64(arr[i])
+		}
+
+	case []Category:
+		arr := vc.slice.([]Category)
+		for i := range arr {
+			newVals[i], isNull[i] = convertStringToInt64(arr[i].String(), isNull[i])
+		}
+
+	case []*big.Rat:
+		arr := vc.slice.([]*big.Rat)
+		for i := range arr {
+			if arr[i] == nil || !arr[i].IsInt() || !arr[i].Num().IsInt64() {
+				newVals[i], isNull[i] = 0, true
+				continue
+			}
+			newVals[i] = arr[i].Num().Int64()
+		}
+
+	case []time.Duration:
+		arr := vc.slice.([]time.Duration)
+		for i := range arr {
+			newVals[i] = int64(arr[i])
+		}
+
+	case []interface{}:
+		arr := vc.slice.([]interface{})
+		for i := range arr {
+			switch arr[i].(type) {
+			case string:
+				newVals[i], isNull[i] = convertStringToInt64(arr[i].(string), isNull[i])
+			case float32, float64:
+				newVals[i], isNull[i] = convertFloatToInt64(reflect.ValueOf(arr[i]).Float(), isNull[i])
+			case int, int8, int16, int32, int64:
+				newVals[i] = reflect.ValueOf(arr[i]).Int()
+			case uint, uint8, uint16, uint32, uint64:
+				newVals[i], isNull[i] = convertStringToInt64(fmt.Sprint(arr[i]), isNull[i])
+			case bool:
+				newVals[i] = convertBoolToInt64(arr[i].(bool))
+			default:
+				newVals[i], isNull[i] = 0, true
+			}
+		}
+
+	case []int, []int8, []int16, []int32:
+		d := reflect.ValueOf(vc.slice)
+		for i := 0; i < d.Len(); i++ {
+			newVals[i] = d.Index(i).Int()
+		}
+
+	case []uint, []uint8, []uint16, []uint32, []uint64:
+		d := reflect.ValueOf(vc.slice)
+		for i := 0; i < d.Len(); i++ {
+			newVals[i], isNull[i] = convertStringToInt64(fmt.Sprint(d.Index(i).Interface()), isNull[i])
+		}
+
+	case []float32:
+		arr := vc.slice.([]float32)
+		for i := range arr {
+			newVals[i], isNull[i] = convertFloatToInt64(float64(arr[i]), isNull[i])
+		}
+
+	default:
+		for i := range newVals {
+			newVals[i] = 0
+			isNull[i] = true
+		}
+	}
+	ret := int64ValueContainer{
+		isNull: isNull,
+		slice:  newVals,
+	}
+	return ret
+}
+
+// returns parsed decimal and whether value is null
+func convertStringToDecimal(val string, originalBool bool) (*big.Rat, bool) {
+	parsedVal, ok := new(big.Rat).SetString(val)
+	if ok {
+		return parsedVal, originalBool
+	}
+	return new(big.Rat), true
+}
+
+// floats are converted from their shortest decimal representation (e.g., 0.1 -> 1/10) rather than their exact binary value.
+func convertFloatToDecimal(val float64, bitSize int, originalBool bool) (*big.Rat, bool) {
+	if math.IsNaN(val) || math.IsInf(val, 0) {
+		return new(big.Rat), true
+	}
+	return convertStringToDecimal(strconv.FormatFloat(val, 'g', -1, bitSize), originalBool)
+}
+
+func convertBoolToDecimal(val bool) *big.Rat {
+	if val {
+		return big.NewRat(1, 1)
+	}
+	return new(big.Rat)
+}
+
+// formatDecimal formats val as a decimal string without an exponent.
+// Values with a terminating decimal expansion are formatted exactly,
+// and all others (e.g., 1/3) are rounded to optionDecimalPlaces.
+func formatDecimal(val *big.Rat) string {
+	if val == nil {
+		return ""
+	}
+	if val.IsInt() {
+		return val.Num().String()
+	}
+	return val.FloatString(decimalPlaces(val))
+}
+
+// decimalPlaces returns the number of decimal places with which formatDecimal formats val.
+func decimalPlaces(val *big.Rat) int {
+	if val.IsInt() {
+		return 0
+	}
+	var places int
+	denom := new(big.Int).Set(val.Denom())
+	mod := new(big.Int)
+	for _, factor := range []*big.Int{big.NewInt(2), big.NewInt(5)} {
+		var n int
+		for {
+			q, r := new(big.Int).QuoRem(denom, factor, mod)
+			if r.Sign() != 0 {
+				break
+			}
+			denom = q
+			n++
+		}
+		if n > places {
+			places = n
+		}
+	}
+	if denom.Cmp(big.NewInt(1)) != 0 {
+		places = optionDecimalPlaces
+	}
+	return places
+}
+
+// if already []*big.Rat, returns shared values, not new values.
+// Strings are parsed with (*big.Rat).SetString (unparseable strings are null), and NaN and infinite floats are null.
+// Null values are 0, never nil.
+func (vc *valueContainer) decimal() decimalValueContainer {
+	newVals := make([]*big.Rat, reflect.ValueOf(vc.slice).Len())
+	isNull := vc.isNull
+	switch vc.slice.(type) {
+	case []*big.Rat:
+		newVals = vc.slice.([]*big.Rat)
+		for i := range newVals {
+			if newVals[i] == nil {
+				newVals[i], isNull[i] = new(big.Rat), true
+			}
+		}
+
+	case []float64:
+		arr := vc.slice.([]float64)
+		for i := range arr {
+			newVals[i], isNull[i] = convertFloatToDecimal(arr[i], 64, isNull[i])
+		}
+
+	case []float32:
+		arr := vc.slice.([]float32)
+		for i := range arr {
+			newVals[i], isNull[i] = convertFloatToDecimal(float64(arr[i]), 32, isNull[i])
+		}
+
+	case []string:
+		arr := vc.slice.([]string)
+		for i := range arr {
+			newVals[i], isNull[i] = convertStringToDecimal(arr[i], isNull[i])
+		}
+
+	case [][]byte:
+		arr := vc.slice.([][]byte)
+		for i := range arr {
+			newVals[i], isNull[i] = convertStringToDecimal(string(arr[i]), isNull[i])
+		}
+
+	case []bool:
+		arr := vc.slice.([]bool)
+		for i := range arr {
+			newVals[i] = convertBoolToDecimal(arr[i])
+		}
+
+	case []Category:
+		arr := vc.slice.([]Category)
+		for i := range arr {
+			newVals[i], isNull[i] = convertStringToDecimal(arr[i].String(), isNull[i])
+		}
+
+	case []interface{}:
+		arr := vc.slice.([]interface{})
+		for i := range arr {
+			switch arr[i].(type) {
+			case *big.Rat:
+				if arr[i].(*big.Rat) == nil {
+					newVals[i], isNull[i] = new(big.Rat), true
+				} else {
+					newVals[i] = arr[i].(*big.Rat)
+				}
+			case string:
+				newVals[i], isNull[i] = convertStringToDecimal(arr[i].(string), isNull[i])
+			case float32:
+				newVals[i], isNull[i] = convertFloatToDecimal(float64(arr[i].(float32)), 32, isNull[i])
+			case float64:
+				newVals[i], isNull[i] = convertFloatToDecimal(arr[i].(float64), 64, isNull[i])
+			case int, int8, int16, int32, int64:
+				newVals[i] = new(big.Rat).SetInt64(reflect.ValueOf(arr[i]).Int())
+			case uint, uint8, uint16, uint32, uint64:
+				newVals[i] = new(big.Rat).SetUint64(reflect.ValueOf(arr[i]).Uint())
+			case bool:
+				newVals[i] = convertBoolToDecimal(arr[i].(bool))
+			default:
+				newVals[i], isNull[i] = new(big.Rat), true
+			}
+		}
+
+	case []int, []int8, []int16, []int32, []int64:
+		d := reflect.ValueOf(vc.slice)
+		for i := 0; i < d.Len(); i++ {
+			newVals[i] = new(big.Rat).SetInt64(d.Index(i).Int())
+		}
+
+	case []uint, []uint8, []uint16, []uint32, []uint64:
+		d := reflect.ValueOf(vc.slice)
+		for i := 0; i < d.Len(); i++ {
+			newVals[i] = new(big.Rat).SetUint64(d.Index(i).Uint())
+		}
+
+	default:
+		for i := range newVals {
+			newVals[i] = new(big.Rat)
+			isNull[i] = true
+		}
+	}
+	ret := decimalValueContainer{
+		isNull: isNull,
+		slice:  newVals,
+	}
+	return ret
+}
+
+// returns parsed duration and whether value is null.
+// Accepts Go duration strings (e.g., "1h30m") and ISO 8601 durations (e.g., "PT1H30M").
+func convertStringToDuration(val string, originalBool bool) (time.Duration, bool) {
+	parsedVal, err := time.ParseDuration(val)
+	if err == nil {
+		return parsedVal, originalBool
+	}
+	parsedVal, err = parseISODuration(val)
+	if err == nil {
+		return parsedVal, originalBool
+	}
+	return 0, true
+}
+
+// if already []time.Duration, returns shared values, not new values.
+// Strings are parsed with convertStringToDuration (unparseable strings are null), and numbers are read as nanoseconds.
+func (vc *valueContainer) duration() durationValueContainer {
+	newVals := make([]time.Duration, reflect.ValueOf(vc.slice).Len())
+	isNull := vc.isNull
+	switch vc.slice.(type) {
+	case []time.Duration:
+		newVals = vc.slice.([]time.Duration)
+
+	case []string:
+		arr := vc.slice.([]string)
+		for i := range arr {
+			newVals[i], isNull[i] = convertStringToDuration(arr[i], isNull[i])
+		}
+
+	case [][]byte:
+		arr := vc.slice.([][]byte)
+		for i := range arr {
+			newVals[i], isNull[i] = convertStringToDuration(string(arr[i]), isNull[i])
+		}
+
+	case []Category:
+		arr := vc.slice.([]Category)
+		for i := range arr {
+			newVals[i], isNull[i] = convertStringToDuration(arr[i].String(), isNull[i])
+		}
+
+	case []interface{}:
+		arr := vc.slice.([]interface{})
+		for i := range arr {
+			switch arr[i].(type) {
+			case time.Duration:
+				newVals[i] = arr[i].(time.Duration)
+			case string:
+				newVals[i], isNull[i] = convertStringToDuration(arr[i].(string), isNull[i])
+			case int, int8, int16, int32, int64:
+				newVals[i] = time.Duration(reflect.ValueOf(arr[i]).Int())
+			default:
+				newVals[i], isNull[i] = 0, true
+			}
+		}
+
+	case []int, []int8, []int16, []int32, []int64, []float32, []float64, []uint, []uint8, []uint16, []uint32, []uint64:
+		ints := vc.int64()
+		for i := range ints.slice {
+			newVals[i] = time.Duration(ints.slice[i])
+		}
+
+	default:
+		for i := range newVals {
+			newVals[i] = 0
+			isNull[i] = true
+		}
+	}
+	ret := durationValueContainer{
+		isNull: isNull,
+		slice:  newVals,
+	}
+	return ret
+}
+
+func convertStringToBool(val string, originalBool bool) (bool, bool) {
+	parsedVal, err := strconv.ParseBool(val)
+	if err == nil {
+		return parsedVal, originalBool
+	}
+	return false, true
+}
+
+// if already []bool, returns shared values, not new values.
+// Strings are parsed with strconv.ParseBool (unparseable strings are null), and numbers are true if they are not 0.
+func (vc *valueContainer) bool() boolValueContainer {
+	newVals := make([]bool, reflect.ValueOf(vc.slice).Len())
+	isNull := vc.isNull
+	switch vc.slice.(type) {
+	case []bool:
+		newVals = vc.slice.([]bool)
+
+	case []string:
+		arr := vc.slice.([]string)
+		for i := range arr {
+			newVals[i], isNull[i] = convertStringToBool(arr[i], isNull[i])
+		}
+
+	case [][]byte:
+		arr := vc.slice.([][]byte)
+		for i := range arr {
+			newVals[i], isNull[i] = convertStringToBool(string(arr[i]), isNull[i])
+		}
+
+	case []Category:
+		arr := vc.slice.([]Category)
+		for i := range arr {
+			newVals[i], isNull[i] = convertStringToBool(arr[i].String(), isNull[i])
+		}
+
+	case []*big.Rat:
+		arr := vc.slice.([]*big.Rat)
+		for i := range arr {
+			if arr[i] == nil {
+				newVals[i], isNull[i] = false, true
+				continue
+			}
+			newVals[i] = arr[i].Sign() != 0
+		}
+
+	case []interface{}:
+		arr := vc.slice.([]interface{})
+		for i := range arr {
+			switch arr[i].(type) {
+			case string:
+				newVals[i], isNull[i] = convertStringToBool(arr[i].(string), isNull[i])
+			case bool:
+				newVals[i] = arr[i].(bool)
+			case float32, float64:
+				newVals[i] = reflect.ValueOf(arr[i]).Float() != 0
+			case int, int8, int16, int32, int64:
+				newVals[i] = reflect.ValueOf(arr[i]).Int() != 0
+			case uint, uint8, uint16, uint32, uint64:
+				newVals[i] = reflect.ValueOf(arr[i]).Uint() != 0
+			default:
+				newVals[i], isNull[i] = false, true
+			}
+		}
+
+	case []float64, []float32:
+		d := reflect.ValueOf(vc.slice)
+		for i := 0; i < d.Len(); i++ {
+			newVals[i] = d.Index(i).Float() != 0
+		}
+
+	case []int, []int8, []int16, []int32, []int64:
+		d := reflect.ValueOf(vc.slice)
+		for i := 0; i < d.Len(); i++ {
+			newVals[i] = d.Index(i).Int() != 0
+		}
+
+	case []uint, []uint8, []uint16, []uint32, []uint64:
+		d := reflect.ValueOf(vc.slice)
+		for i := 0; i < d.Len(); i++ {
+			newVals[i] = d.Index(i).Uint() != 0
+		}
+
+	default:
+		for i := range newVals {
+			newVals[i] = false
+			isNull[i] = true
+		}
+	}
+	ret := boolValueContainer{
+		isNull: isNull,
+		slice:  newVals,
+	}
+	return ret
+}
+
 func convertDateTimeToString(v time.Time) string {
 	return v.Format(time.RFC3339)
 }
 
-// if already []string, returns shared values, not new values
+// if already []string, returns shared values, not new values.
+// []Category values are looked up in the dictionary and are not cached.
 func (vc *valueContainer) string() stringValueContainer {
+	if arr, ok := vc.slice.([]Category); ok {
+		return stringValueContainer{
+			slice:  categoryStrings(arr),
+			isNull: vc.isNull,
+		}
+	}
 	newVals := make([]string, reflect.ValueOf(vc.slice).Len())
 	isNull := vc.isNull
 	switch vc.slice.(type) {
@@ -236,6 +955,12 @@ func (vc *valueContainer) string() stringValueContainer {
 			newVals[i] = strconv.Itoa(arr[i])
 		}
 
+	case []*big.Rat:
+		arr := vc.slice.([]*big.Rat)
+		for i := range arr {
+			newVals[i] = formatDecimal(arr[i])
+		}
+
 	case []interface{}:
 		arr := vc.slice.([]interface{})
 		for i := range arr {
@@ -244,6 +969,8 @@ func (vc *valueContainer) string() stringValueContainer {
 				newVals[i] = arr[i].(string)
 			case time.Time:
 				newVals[i] = convertDateTimeToString(arr[i].(time.Time))
+			case *big.Rat:
+				newVals[i] = formatDecimal(arr[i].(*big.Rat))
 			default:
 				d := reflect.ValueOf(vc.slice)
 				newVals[i] = fmt.Sprint(d.Index(i).Interface())
@@ -275,6 +1002,42 @@ func convertStringToDateTime(val string) (time.Time, bool) {
 	return time.Time{}, true
 }
 
+// naiveDateTimeFormats are date time layouts without a time zone.
+// They are recognized only when values are parsed in a location (e.g., RecordReader.Location).
+var naiveDateTimeFormats = []string{"2006-01-02 15:04:05", "2006-01-02T15:04:05"}
+
+// layoutHasZone returns whether layout includes a time zone offset or abbreviation.
+func layoutHasZone(layout string) bool {
+	return strings.Contains(layout, "MST") || strings.Contains(layout, "Z07") || strings.Contains(layout, "-07")
+}
+
+// parseTimeIn parses val with layout. If layout has no time zone, the wall clock is localized to loc
+// with the same rules for daylight saving transitions as Series.Localize (see localizeTime).
+func parseTimeIn(layout string, val string, loc *time.Location) (time.Time, error) {
+	if layoutHasZone(layout) {
+		return time.ParseInLocation(layout, val, loc)
+	}
+	parsedVal, err := time.Parse(layout, val)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return localizeTime(parsedVal, loc), nil
+}
+
+// convertStringToDateTimeIn parses val as in convertStringToDateTime, but also recognizes naiveDateTimeFormats,
+// and values without a time zone are localized to loc.
+func convertStringToDateTimeIn(val string, loc *time.Location) (time.Time, bool) {
+	for _, formats := range [][]string{optionDateTimeFormats, naiveDateTimeFormats} {
+		for _, format := range formats {
+			parsedVal, err := parseTimeIn(format, val, loc)
+			if err == nil {
+				return parsedVal, false
+			}
+		}
+	}
+	return time.Time{}, true
+}
+
 func (vc *valueContainer) dateTime() dateTimeValueContainer {
 	newVals := make([]time.Time, reflect.ValueOf(vc.slice).Len())
 	isNull := vc.isNull
@@ -292,6 +1055,11 @@ func (vc *valueContainer) dateTime() dateTimeValueContainer {
 		}
 	case []time.Time:
 		newVals = vc.slice.([]time.Time)
+	case []Category:
+		arr := vc.slice.([]Category)
+		for i := range arr {
+			newVals[i], isNull[i] = convertStringToDateTime(arr[i].String())
+		}
 	case []civil.Date:
 		arr := vc.slice.([]civil.Date)
 		for i := range arr {
@@ -311,6 +1079,15 @@ func (vc *valueContainer) dateTime() dateTimeValueContainer {
 				newVals[i] = time.Date(0, 0, 0, arr[i].Hour, arr[i].Minute, arr[i].Second, arr[i].Nanosecond, time.UTC)
 			}
 		}
+	case []civil.DateTime:
+		arr := vc.slice.([]civil.DateTime)
+		for i := range arr {
+			if isNull[i] {
+				newVals[i] = time.Time{}
+			} else {
+				newVals[i] = arr[i].In(time.UTC)
+			}
+		}
 	case []interface{}:
 		arr := vc.slice.([]interface{})
 		for i := range arr {
@@ -337,6 +1114,22 @@ func (vc *valueContainer) dateTime() dateTimeValueContainer {
 	return ret
 }
 
+// castIn casts vc to dtype as in cast, except that []string values cast to DateTime are parsed in loc if they have no time zone.
+// If loc is nil, castIn is the same as cast.
+func (vc *valueContainer) castIn(dtype DType, loc *time.Location) {
+	arr, ok := vc.slice.([]string)
+	if !ok || dtype != DateTime || loc == nil {
+		vc.cast(dtype)
+		return
+	}
+	vc.setCache()
+	newVals := make([]time.Time, len(arr))
+	for i := range arr {
+		newVals[i], vc.isNull[i] = convertStringToDateTimeIn(arr[i], loc)
+	}
+	vc.slice = newVals
+}
+
 // cache must be reset after any operation that modifies vc.slice
 func (vc *valueContainer) resetCache() {
 	vc.cache = nil
@@ -378,6 +1171,14 @@ func (vc *valueContainer) setCache() {
 		for i := range arr {
 			vc.cache[i] = arr[i].String()
 		}
+	case []Category:
+		vc.cache = categoryStrings(vc.slice.([]Category))
+	case []*big.Rat:
+		arr := vc.slice.([]*big.Rat)
+		vc.cache = make([]string, len(arr))
+		for i := range arr {
+			vc.cache[i] = formatDecimal(arr[i])
+		}
 	default:
 		arr := reflect.ValueOf(vc.slice)
 		vc.cache = make([]string, arr.Len())
@@ -392,6 +1193,55 @@ func (vc *valueContainer) isString() bool {
 	return ok
 }
 
+func (vc *valueContainer) isInt64() bool {
+	_, ok := vc.slice.([]int64)
+	return ok
+}
+
+func (vc *valueContainer) isDuration() bool {
+	_, ok := vc.slice.([]time.Duration)
+	return ok
+}
+
+func (vc *valueContainer) isDateTime() bool {
+	_, ok := vc.slice.([]time.Time)
+	return ok
+}
+
+func (vc *valueContainer) isDecimal() bool {
+	_, ok := vc.slice.([]*big.Rat)
+	return ok
+}
+
+// isTime returns whether vc has time.Time or civil date/time values
+func (vc *valueContainer) isTime() bool {
+	switch vc.slice.(type) {
+	case []time.Time, []civil.DateTime, []civil.Date, []civil.Time:
+		return true
+	}
+	return false
+}
+
+// isInteger returns whether vc has values of any signed or unsigned integer type
+func (vc *valueContainer) isInteger() bool {
+	switch reflect.TypeOf(vc.slice).Elem().Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		// time.Duration is an int64 kind
+		return !vc.isDuration()
+	}
+	return false
+}
+
+// isNumeric returns whether vc has values of any integer, float, or bool type
+func (vc *valueContainer) isNumeric() bool {
+	switch reflect.TypeOf(vc.slice).Elem().Kind() {
+	case reflect.Float32, reflect.Float64, reflect.Bool:
+		return true
+	}
+	return vc.isInteger()
+}
+
 func (vc *valueContainer) setCacheFromString(arr []string) {
 	vc.cache = arr
 	return
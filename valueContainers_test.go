@@ -1,6 +1,8 @@
 package tada
 
 import (
+	"math"
+	"math/big"
 	"reflect"
 	"testing"
 	"time"
@@ -421,6 +423,186 @@ func Test_valueContainer_float64(t *testing.T) {
 	}
 }
 
+func Test_valueContainer_int64(t *testing.T) {
+	d := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	type fields struct {
+		slice  interface{}
+		isNull []bool
+		name   string
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		want   int64ValueContainer
+	}{
+		{"[]int64", fields{slice: []int64{1}, isNull: []bool{false}},
+			int64ValueContainer{slice: []int64{1}, isNull: []bool{false}}},
+		{"[]float64", fields{slice: []float64{1, 1.5, math.NaN(), 1e20}, isNull: []bool{false, false, false, false}},
+			int64ValueContainer{slice: []int64{1, 0, 0, 0}, isNull: []bool{false, true, true, true}}},
+		{"[]string", fields{slice: []string{"", "foo", "9007199254740993", "2.0"}, isNull: []bool{true, false, false, false}},
+			int64ValueContainer{slice: []int64{0, 0, 9007199254740993, 2}, isNull: []bool{true, true, false, false}}},
+		{"[]bool", fields{slice: []bool{false, true}, isNull: []bool{false, false}},
+			int64ValueContainer{slice: []int64{0, 1}, isNull: []bool{false, false}}},
+		{"[]interface", fields{slice: []interface{}{"3", float64(1.5), int(1), uint(1), d, true}, isNull: []bool{false, false, false, false, false, false}},
+			int64ValueContainer{slice: []int64{3, 0, 1, 1, 0, 1}, isNull: []bool{false, true, false, false, true, false}}},
+		{"[]int", fields{slice: []int{1}, isNull: []bool{false}},
+			int64ValueContainer{slice: []int64{1}, isNull: []bool{false}}},
+		{"[]uint64", fields{slice: []uint64{1, math.MaxUint64}, isNull: []bool{false, false}},
+			int64ValueContainer{slice: []int64{1, 0}, isNull: []bool{false, true}}},
+		{"[]time.Time", fields{slice: []time.Time{d}, isNull: []bool{false}},
+			int64ValueContainer{slice: []int64{0}, isNull: []bool{true}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			vc := &valueContainer{
+				slice:  tt.fields.slice,
+				isNull: tt.fields.isNull,
+				name:   tt.fields.name,
+			}
+			if got := vc.int64(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("valueContainer.int64() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_formatDecimal(t *testing.T) {
+	tests := []struct {
+		name string
+		val  *big.Rat
+		want string
+	}{
+		{"integer", mockDecimal("-12"), "-12"},
+		{"terminating", mockDecimal("1.50"), "1.5"},
+		{"beyond float precision", mockDecimal("90071992547409.93"), "90071992547409.93"},
+		{"non-terminating", big.NewRat(2, 3), "0.6666666666666667"},
+		{"nil", nil, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := formatDecimal(tt.val); got != tt.want {
+				t.Errorf("formatDecimal() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_valueContainer_duration(t *testing.T) {
+	type fields struct {
+		slice  interface{}
+		isNull []bool
+		name   string
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		want   durationValueContainer
+	}{
+		{"[]time.Duration", fields{slice: []time.Duration{time.Hour}, isNull: []bool{false}},
+			durationValueContainer{slice: []time.Duration{time.Hour}, isNull: []bool{false}}},
+		{"[]string - go and iso", fields{slice: []string{"1h30m", "P1DT1S", "foo"}, isNull: []bool{false, false, false}},
+			durationValueContainer{slice: []time.Duration{90 * time.Minute, 24*time.Hour + time.Second, 0}, isNull: []bool{false, false, true}}},
+		{"[]int64 - nanoseconds", fields{slice: []int64{1000}, isNull: []bool{false}},
+			durationValueContainer{slice: []time.Duration{time.Microsecond}, isNull: []bool{false}}},
+		{"[]interface", fields{slice: []interface{}{time.Second, "2s", 3, time.Time{}}, isNull: []bool{false, false, false, false}},
+			durationValueContainer{slice: []time.Duration{time.Second, 2 * time.Second, 3, 0}, isNull: []bool{false, false, false, true}}},
+		{"[]time.Time", fields{slice: []time.Time{{}}, isNull: []bool{false}},
+			durationValueContainer{slice: []time.Duration{0}, isNull: []bool{true}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			vc := &valueContainer{
+				slice:  tt.fields.slice,
+				isNull: tt.fields.isNull,
+				name:   tt.fields.name,
+			}
+			if got := vc.duration(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("valueContainer.duration() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_valueContainer_decimal(t *testing.T) {
+	type fields struct {
+		slice  interface{}
+		isNull []bool
+		name   string
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		want   decimalValueContainer
+	}{
+		{"[]*big.Rat - nil is null", fields{slice: []*big.Rat{mockDecimal("1.5"), nil}, isNull: []bool{false, false}},
+			decimalValueContainer{slice: []*big.Rat{mockDecimal("1.5"), new(big.Rat)}, isNull: []bool{false, true}}},
+		{"[]float64", fields{slice: []float64{0.1, math.NaN()}, isNull: []bool{false, false}},
+			decimalValueContainer{slice: []*big.Rat{mockDecimal("0.1"), new(big.Rat)}, isNull: []bool{false, true}}},
+		{"[]string", fields{slice: []string{"19.99", "foo"}, isNull: []bool{false, false}},
+			decimalValueContainer{slice: []*big.Rat{mockDecimal("19.99"), new(big.Rat)}, isNull: []bool{false, true}}},
+		{"[]int64", fields{slice: []int64{9007199254740993}, isNull: []bool{false}},
+			decimalValueContainer{slice: []*big.Rat{mockDecimal("9007199254740993")}, isNull: []bool{false}}},
+		{"[]bool", fields{slice: []bool{true}, isNull: []bool{false}},
+			decimalValueContainer{slice: []*big.Rat{big.NewRat(1, 1)}, isNull: []bool{false}}},
+		{"[]interface", fields{slice: []interface{}{"1.25", 2.5, uint(3), time.Time{}}, isNull: []bool{false, false, false, false}},
+			decimalValueContainer{slice: []*big.Rat{mockDecimal("1.25"), mockDecimal("2.5"), big.NewRat(3, 1), new(big.Rat)},
+				isNull: []bool{false, false, false, true}}},
+		{"[]time.Time", fields{slice: []time.Time{{}}, isNull: []bool{false}},
+			decimalValueContainer{slice: []*big.Rat{new(big.Rat)}, isNull: []bool{true}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			vc := &valueContainer{
+				slice:  tt.fields.slice,
+				isNull: tt.fields.isNull,
+				name:   tt.fields.name,
+			}
+			if got := vc.decimal(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("valueContainer.decimal() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_valueContainer_bool(t *testing.T) {
+	d := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	type fields struct {
+		slice  interface{}
+		isNull []bool
+		name   string
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		want   boolValueContainer
+	}{
+		{"[]bool", fields{slice: []bool{true}, isNull: []bool{false}},
+			boolValueContainer{slice: []bool{true}, isNull: []bool{false}}},
+		{"[]string", fields{slice: []string{"", "foo", "true", "0"}, isNull: []bool{true, false, false, false}},
+			boolValueContainer{slice: []bool{false, false, true, false}, isNull: []bool{true, true, false, false}}},
+		{"[]float64", fields{slice: []float64{0, 2.5}, isNull: []bool{false, false}},
+			boolValueContainer{slice: []bool{false, true}, isNull: []bool{false, false}}},
+		{"[]int", fields{slice: []int{0, 1}, isNull: []bool{false, false}},
+			boolValueContainer{slice: []bool{false, true}, isNull: []bool{false, false}}},
+		{"[]interface", fields{slice: []interface{}{"false", true, 1, d}, isNull: []bool{false, false, false, false}},
+			boolValueContainer{slice: []bool{false, true, true, false}, isNull: []bool{false, false, false, true}}},
+		{"[]time.Time", fields{slice: []time.Time{d}, isNull: []bool{false}},
+			boolValueContainer{slice: []bool{false}, isNull: []bool{true}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			vc := &valueContainer{
+				slice:  tt.fields.slice,
+				isNull: tt.fields.isNull,
+				name:   tt.fields.name,
+			}
+			if got := vc.bool(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("valueContainer.bool() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
 func Test_valueContainer_string(t *testing.T) {
 	d := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
 	type fields struct {
@@ -619,6 +801,24 @@ func Test_valueContainer_cast(t *testing.T) {
 			args{Date}, &valueContainer{slice: []civil.Date{civil.DateOf(d)}, isNull: []bool{false}, name: "foo"}},
 		{"datetime to civil.Time", fields{slice: []time.Time{d}, isNull: []bool{false}, name: "foo"},
 			args{Time}, &valueContainer{slice: []civil.Time{civil.TimeOf(d)}, isNull: []bool{false}, name: "foo"}},
+		{"float64 to int64", fields{slice: []float64{1, 1.5}, isNull: []bool{false, false}, name: "foo"},
+			args{Int64}, &valueContainer{slice: []int64{1, 0}, isNull: []bool{false, true}, name: "foo"}},
+		{"string to bool", fields{slice: []string{"true", "foo"}, isNull: []bool{false, false}, name: "foo"},
+			args{Bool}, &valueContainer{slice: []bool{true, false}, isNull: []bool{false, true}, name: "foo",
+				cache: []string{"true", "foo"}}},
+		{"string to decimal", fields{slice: []string{"0.10", "foo"}, isNull: []bool{false, false}, name: "foo"},
+			args{Decimal}, &valueContainer{slice: []*big.Rat{mockDecimal("0.1"), new(big.Rat)}, isNull: []bool{false, true}, name: "foo",
+				cache: []string{"0.10", "foo"}}},
+		{"string to duration", fields{slice: []string{"1h30m", "PT1H30M", "foo"}, isNull: []bool{false, false, false}, name: "foo"},
+			args{Duration}, &valueContainer{slice: []time.Duration{90 * time.Minute, 90 * time.Minute, 0}, isNull: []bool{false, false, true}, name: "foo",
+				cache: []string{"1h30m", "PT1H30M", "foo"}}},
+		{"decimal to string", fields{slice: []*big.Rat{mockDecimal("0.10")}, isNull: []bool{false}, name: "foo"},
+			args{String}, &valueContainer{slice: []string{"0.1"}, isNull: []bool{false}, name: "foo",
+				cache: []string{"0.1"}}},
+		{"string to categorical", fields{slice: []string{"b", "a", "", "b"}, isNull: []bool{false, false, true, false}, name: "foo"},
+			args{Categorical}, &valueContainer{
+				slice:  newCategories([]string{"b", "a", "", "b"}, []bool{false, false, true, false}),
+				isNull: []bool{false, false, true, false}, name: "foo"}},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -635,6 +835,43 @@ func Test_valueContainer_cast(t *testing.T) {
 	}
 }
 
+func Test_newCategories(t *testing.T) {
+	type args struct {
+		vals   []string
+		isNull []bool
+	}
+	tests := []struct {
+		name           string
+		args           args
+		wantCodes      []int
+		wantStrings    []string
+		wantCategories []string
+	}{
+		{"pass", args{[]string{"b", "a", "c", "a"}, []bool{false, false, false, false}},
+			[]int{1, 0, 2, 0}, []string{"b", "a", "c", "a"}, []string{"a", "b", "c"}},
+		{"null", args{[]string{"b", "z", "b"}, []bool{false, true, false}},
+			[]int{0, -1, 0}, []string{"b", "", "b"}, []string{"b"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := newCategories(tt.args.vals, tt.args.isNull)
+			codes := make([]int, len(got))
+			for i := range got {
+				codes[i] = got[i].Code()
+			}
+			if !reflect.DeepEqual(codes, tt.wantCodes) {
+				t.Errorf("newCategories() codes = %v, want %v", codes, tt.wantCodes)
+			}
+			if strs := categoryStrings(got); !reflect.DeepEqual(strs, tt.wantStrings) {
+				t.Errorf("newCategories() strings = %v, want %v", strs, tt.wantStrings)
+			}
+			if cats := got[0].Categories(); !reflect.DeepEqual(cats, tt.wantCategories) {
+				t.Errorf("newCategories() categories = %v, want %v", cats, tt.wantCategories)
+			}
+		})
+	}
+}
+
 func Test_valueContainer_setCache(t *testing.T) {
 	d := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
 	type fields struct {